@@ -0,0 +1,8 @@
+-- Harga disimpan dalam minor unit (sen) + kode mata uang ISO 4217.
+-- Harga lama (rupiah utuh) dikonversi ke sen: 15000 -> 1500000 IDR.
+ALTER TABLE products ADD COLUMN price_amount BIGINT;
+ALTER TABLE products ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'IDR';
+UPDATE products SET price_amount = price::BIGINT * 100;
+ALTER TABLE products ALTER COLUMN price_amount SET NOT NULL;
+ALTER TABLE products ADD CONSTRAINT products_price_amount_check CHECK (price_amount >= 0);
+ALTER TABLE products DROP COLUMN price;
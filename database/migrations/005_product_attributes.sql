@@ -0,0 +1,14 @@
+ALTER TABLE products ADD COLUMN IF NOT EXISTS attributes JSONB NOT NULL DEFAULT '{}';
+
+-- jsonb_path_ops mendukung operator @> dan @@ yang dipakai filter attr.*
+CREATE INDEX IF NOT EXISTS products_attributes_idx ON products USING GIN (attributes jsonb_path_ops);
+
+CREATE TABLE IF NOT EXISTS category_attributes (
+    id SERIAL PRIMARY KEY,
+    category_id INTEGER NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    name VARCHAR(64) NOT NULL,
+    type VARCHAR(16) NOT NULL CHECK (type IN ('string', 'number', 'boolean', 'date')),
+    required BOOLEAN NOT NULL DEFAULT false,
+    allowed_values JSONB NOT NULL DEFAULT '[]',
+    UNIQUE (category_id, name)
+);
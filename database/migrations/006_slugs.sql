@@ -0,0 +1,32 @@
+-- Nama kategori harus unik (case-insensitive). Duplikat lama diberi
+-- suffix ID supaya unique index bisa dibuat.
+UPDATE categories c SET name = c.name || ' (' || c.id || ')'
+WHERE EXISTS (
+    SELECT 1 FROM categories o WHERE lower(o.name) = lower(c.name) AND o.id < c.id
+);
+CREATE UNIQUE INDEX IF NOT EXISTS categories_name_lower_key ON categories (lower(name));
+
+-- Slug awal untuk data lama; aplikasi membuat slug dengan transliterasi
+-- untuk data baru. Suffix ID menjamin keunikan.
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS slug VARCHAR(100);
+UPDATE categories SET slug = coalesce(nullif(trim(both '-' from regexp_replace(lower(name), '[^a-z0-9]+', '-', 'g')), ''), 'category') || '-' || id;
+ALTER TABLE categories ALTER COLUMN slug SET NOT NULL;
+ALTER TABLE categories ADD CONSTRAINT categories_slug_key UNIQUE (slug);
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS slug VARCHAR(100);
+UPDATE products SET slug = coalesce(nullif(trim(both '-' from regexp_replace(lower(name), '[^a-z0-9]+', '-', 'g')), ''), 'product') || '-' || id;
+ALTER TABLE products ALTER COLUMN slug SET NOT NULL;
+ALTER TABLE products ADD CONSTRAINT products_slug_key UNIQUE (slug);
+
+-- Slug lama disimpan supaya URL lama bisa di-redirect ke slug baru
+CREATE TABLE IF NOT EXISTS category_slug_history (
+    slug VARCHAR(100) PRIMARY KEY,
+    category_id INTEGER NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS product_slug_history (
+    slug VARCHAR(100) PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
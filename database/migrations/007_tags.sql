@@ -0,0 +1,16 @@
+-- Nama tag disimpan dalam bentuk slug (lowercase, dipisah "-") sehingga
+-- "New Arrival" dan "new-arrival" adalah tag yang sama.
+CREATE TABLE IF NOT EXISTS tags (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(50) NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS product_tags (
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (product_id, tag_id)
+);
+
+-- Filter ?tag= dan hitungan pemakaian tag mencari berdasarkan tag_id
+CREATE INDEX IF NOT EXISTS product_tags_tag_id_idx ON product_tags (tag_id);
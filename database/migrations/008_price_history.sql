@@ -0,0 +1,31 @@
+-- Setiap perubahan harga produk dicatat beserta waktu berlaku dan pelakunya
+CREATE TABLE IF NOT EXISTS price_history (
+    id BIGSERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    price_amount BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    effective_at TIMESTAMPTZ NOT NULL,
+    actor VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS price_history_product_effective_idx ON price_history (product_id, effective_at DESC);
+
+-- Harga saat ini dianggap berlaku sejak perubahan terakhir produk
+INSERT INTO price_history (product_id, price_amount, currency, effective_at, actor)
+SELECT id, price_amount, currency, updated_at, 'migration' FROM products;
+
+-- Harga terjadwal diterapkan oleh scheduler ketika effective_at tercapai
+CREATE TABLE IF NOT EXISTS scheduled_prices (
+    id BIGSERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    price_amount BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    effective_at TIMESTAMPTZ NOT NULL,
+    actor VARCHAR(100) NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'applied', 'cancelled')),
+    applied_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS scheduled_prices_due_idx ON scheduled_prices (effective_at) WHERE status = 'pending';
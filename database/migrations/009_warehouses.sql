@@ -0,0 +1,62 @@
+CREATE TABLE IF NOT EXISTS warehouses (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(32) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    is_default BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Tepat satu gudang default, tempat penyesuaian stok lewat update produk
+CREATE UNIQUE INDEX IF NOT EXISTS warehouses_default_key ON warehouses (is_default) WHERE is_default;
+
+INSERT INTO warehouses (code, name, is_default) VALUES ('MAIN', 'Main warehouse', true)
+ON CONFLICT (code) DO NOTHING;
+
+-- Stok on-hand per gudang; stok produk adalah jumlah dari semua gudang
+CREATE TABLE IF NOT EXISTS warehouse_stock (
+    warehouse_id INTEGER NOT NULL REFERENCES warehouses(id),
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL DEFAULT 0 CHECK (quantity >= 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (warehouse_id, product_id)
+);
+
+CREATE INDEX IF NOT EXISTS warehouse_stock_product_idx ON warehouse_stock (product_id);
+
+CREATE TABLE IF NOT EXISTS stock_transfers (
+    id BIGSERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    from_warehouse_id INTEGER NOT NULL REFERENCES warehouses(id),
+    to_warehouse_id INTEGER NOT NULL REFERENCES warehouses(id),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    note VARCHAR(500) NOT NULL DEFAULT '',
+    actor VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CHECK (from_warehouse_id <> to_warehouse_id)
+);
+
+-- Setiap perubahan stok gudang dicatat di ledger. reference_id menunjuk ke
+-- baris sumber perubahan sesuai reason, misal stock_transfers.id untuk
+-- transfer_out/transfer_in.
+CREATE TABLE IF NOT EXISTS stock_ledger (
+    id BIGSERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    warehouse_id INTEGER NOT NULL REFERENCES warehouses(id),
+    delta INTEGER NOT NULL,
+    reason VARCHAR(32) NOT NULL,
+    reference_id BIGINT,
+    actor VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS stock_ledger_product_idx ON stock_ledger (product_id, created_at);
+
+-- Pindahkan stok lama ke gudang default, lalu products.stock tidak dipakai lagi
+INSERT INTO warehouse_stock (warehouse_id, product_id, quantity)
+SELECT w.id, p.id, p.stock FROM products p CROSS JOIN warehouses w
+WHERE w.is_default AND p.stock > 0;
+
+INSERT INTO stock_ledger (product_id, warehouse_id, delta, reason, actor)
+SELECT product_id, warehouse_id, quantity, 'adjustment', 'migration' FROM warehouse_stock;
+
+ALTER TABLE products DROP COLUMN IF EXISTS stock;
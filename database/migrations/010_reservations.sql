@@ -0,0 +1,19 @@
+-- Reservasi menahan stok di satu gudang tanpa mengurangi stok on-hand.
+-- Stok tersedia (available) = on-hand dikurangi reservasi active yang belum
+-- lewat expires_at, sehingga reservasi kedaluwarsa langsung tidak dihitung
+-- walau reaper belum menandainya expired.
+CREATE TABLE IF NOT EXISTS stock_reservations (
+    id BIGSERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    warehouse_id INTEGER NOT NULL REFERENCES warehouses(id),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    status VARCHAR(16) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'confirmed', 'released', 'expired')),
+    reference VARCHAR(100) NOT NULL DEFAULT '',
+    actor VARCHAR(100) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS stock_reservations_active_idx ON stock_reservations (product_id, warehouse_id) WHERE status = 'active';
+CREATE INDEX IF NOT EXISTS stock_reservations_expiry_idx ON stock_reservations (expires_at) WHERE status = 'active';
@@ -0,0 +1,12 @@
+-- Reorder point 0 berarti produk tidak dipantau
+ALTER TABLE products ADD COLUMN IF NOT EXISTS reorder_point INTEGER NOT NULL DEFAULT 0 CHECK (reorder_point >= 0);
+ALTER TABLE products ADD COLUMN IF NOT EXISTS reorder_qty INTEGER NOT NULL DEFAULT 0 CHECK (reorder_qty >= 0);
+
+-- Produk yang sudah dinotifikasi stoknya rendah. Baris dihapus ketika
+-- stoknya kembali di atas reorder point, sehingga notifikasi hanya dikirim
+-- sekali setiap kali stok turun melewati batas.
+CREATE TABLE IF NOT EXISTS low_stock_alerts (
+    product_id INTEGER PRIMARY KEY REFERENCES products(id) ON DELETE CASCADE,
+    stock INTEGER NOT NULL,
+    alerted_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
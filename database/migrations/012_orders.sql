@@ -0,0 +1,30 @@
+-- Pesanan penjualan. Stok dikurangi saat pesanan dibuat dan dikembalikan
+-- jika pesanan dibatalkan.
+CREATE TABLE IF NOT EXISTS orders (
+    id BIGSERIAL PRIMARY KEY,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'paid', 'shipped', 'completed', 'cancelled')),
+    customer VARCHAR(255) NOT NULL DEFAULT '',
+    note VARCHAR(500) NOT NULL DEFAULT '',
+    total_amount BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    actor VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS orders_status_idx ON orders (status, id);
+
+-- Nama dan harga produk disalin saat pesanan dibuat, sehingga baris
+-- pesanan tidak berubah jika produknya diubah atau dihapus
+CREATE TABLE IF NOT EXISTS order_lines (
+    id BIGSERIAL PRIMARY KEY,
+    order_id BIGINT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    product_id INTEGER REFERENCES products(id) ON DELETE SET NULL,
+    product_name VARCHAR(255) NOT NULL,
+    warehouse_id INTEGER NOT NULL REFERENCES warehouses(id),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    unit_price_amount BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS order_lines_order_idx ON order_lines (order_id);
@@ -0,0 +1,26 @@
+-- Harga pokok produk dalam mata uang harga jualnya. inventory_value adalah
+-- nilai persediaan saat ini; cost_amount adalah nilai per unit, yaitu
+-- inventory_value dibagi stok on-hand, dan tetap dipakai ketika stok habis.
+ALTER TABLE products ADD COLUMN IF NOT EXISTS cost_amount BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS inventory_value BIGINT NOT NULL DEFAULT 0;
+
+-- Nilai persediaan yang masuk (positif) atau keluar (negatif) pada setiap
+-- perubahan stok. Jumlah cost_amount sampai suatu waktu adalah nilai
+-- persediaan produk pada waktu itu. Stok sebelum migrasi ini bernilai 0.
+ALTER TABLE stock_ledger ADD COLUMN IF NOT EXISTS cost_amount BIGINT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS stock_ledger_created_idx ON stock_ledger (created_at);
+CREATE INDEX IF NOT EXISTS stock_ledger_reference_idx ON stock_ledger (reason, reference_id);
+
+-- Lapisan persediaan untuk costing FIFO: setiap stok masuk menjadi satu
+-- lapisan, dan stok keluar memakai lapisan yang paling lama lebih dulu
+CREATE TABLE IF NOT EXISTS cost_layers (
+    id BIGSERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    ledger_id BIGINT NOT NULL REFERENCES stock_ledger(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    remaining INTEGER NOT NULL CHECK (remaining >= 0 AND remaining <= quantity),
+    unit_cost_amount BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS cost_layers_open_idx ON cost_layers (product_id, id) WHERE remaining > 0;
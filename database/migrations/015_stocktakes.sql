@@ -0,0 +1,34 @@
+-- Sesi stocktake (cycle count) untuk satu gudang, satu kategori, atau
+-- kategori di satu gudang. Stok yang diharapkan disalin ke baris saat
+-- sesi dibuat, dan selisih hitungan diposting ke ledger saat disetujui.
+CREATE TABLE IF NOT EXISTS stocktakes (
+    id BIGSERIAL PRIMARY KEY,
+    warehouse_id INTEGER REFERENCES warehouses(id),
+    category_id INTEGER REFERENCES categories(id) ON DELETE SET NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'approved', 'cancelled')),
+    note VARCHAR(500) NOT NULL DEFAULT '',
+    actor VARCHAR(100) NOT NULL,
+    closed_by VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    closed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS stocktakes_status_idx ON stocktakes (status, id);
+
+-- Satu baris per produk per gudang dalam cakupan sesi. counted null
+-- berarti belum dihitung; harga pokok disalin untuk nilai selisih.
+CREATE TABLE IF NOT EXISTS stocktake_lines (
+    id BIGSERIAL PRIMARY KEY,
+    stocktake_id BIGINT NOT NULL REFERENCES stocktakes(id) ON DELETE CASCADE,
+    product_id INTEGER REFERENCES products(id) ON DELETE SET NULL,
+    product_name VARCHAR(255) NOT NULL,
+    warehouse_id INTEGER NOT NULL REFERENCES warehouses(id),
+    expected INTEGER NOT NULL,
+    counted INTEGER CHECK (counted >= 0),
+    unit_cost_amount BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    counted_by VARCHAR(100) NOT NULL DEFAULT '',
+    counted_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS stocktake_lines_product_key ON stocktake_lines (stocktake_id, product_id, warehouse_id);
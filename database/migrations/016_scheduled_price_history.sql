@@ -0,0 +1,13 @@
+-- Riwayat harga yang berasal dari harga terjadwal ditautkan ke jadwalnya,
+-- supaya guard ApplyScheduledPrices bisa membedakan perubahan manual dari
+-- jadwal lain yang sudah diterapkan lebih dulu
+ALTER TABLE price_history ADD COLUMN IF NOT EXISTS scheduled_price_id BIGINT REFERENCES scheduled_prices(id) ON DELETE SET NULL;
+
+UPDATE price_history h SET scheduled_price_id = s.id
+FROM scheduled_prices s
+WHERE s.status = 'applied'
+  AND h.scheduled_price_id IS NULL
+  AND h.product_id = s.product_id
+  AND h.effective_at = s.effective_at
+  AND h.price_amount = s.price_amount
+  AND h.actor = s.actor;
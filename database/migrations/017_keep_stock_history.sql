@@ -0,0 +1,22 @@
+-- Riwayat stok tetap disimpan setelah produknya dihapus, seperti baris
+-- pesanan dan purchase order; product_id-nya menjadi null. Produk yang
+-- masih punya stok on-hand atau reservasi active tidak bisa dihapus.
+ALTER TABLE stock_ledger
+    ALTER COLUMN product_id DROP NOT NULL,
+    DROP CONSTRAINT IF EXISTS stock_ledger_product_id_fkey,
+    ADD CONSTRAINT stock_ledger_product_id_fkey FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE SET NULL;
+
+ALTER TABLE stock_transfers
+    ALTER COLUMN product_id DROP NOT NULL,
+    DROP CONSTRAINT IF EXISTS stock_transfers_product_id_fkey,
+    ADD CONSTRAINT stock_transfers_product_id_fkey FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE SET NULL;
+
+ALTER TABLE stock_reservations
+    ALTER COLUMN product_id DROP NOT NULL,
+    DROP CONSTRAINT IF EXISTS stock_reservations_product_id_fkey,
+    ADD CONSTRAINT stock_reservations_product_id_fkey FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE SET NULL;
+
+ALTER TABLE cost_layers
+    ALTER COLUMN product_id DROP NOT NULL,
+    DROP CONSTRAINT IF EXISTS cost_layers_product_id_fkey,
+    ADD CONSTRAINT cost_layers_product_id_fkey FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE SET NULL;
@@ -0,0 +1,14 @@
+-- Stok hanya dicatat per produk di warehouse_stock dan ledger. Stok varian
+-- berdiri sendiri tanpa ledger, jadi kolomnya dihapus.
+ALTER TABLE product_variants DROP COLUMN IF EXISTS stock;
+
+-- Harga override varian menyimpan mata uangnya sendiri, supaya artinya
+-- tidak ikut berubah jika mata uang produk diganti
+ALTER TABLE product_variants ADD COLUMN IF NOT EXISTS price_currency CHAR(3);
+
+UPDATE product_variants v SET price_currency = p.currency
+FROM products p
+WHERE p.id = v.product_id AND v.price_amount IS NOT NULL;
+
+ALTER TABLE product_variants ADD CONSTRAINT product_variants_price_currency_check
+    CHECK ((price_amount IS NULL) = (price_currency IS NULL));
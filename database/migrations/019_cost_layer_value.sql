@@ -0,0 +1,7 @@
+-- Nilai sisa lapisan FIFO disimpan utuh. Stok keluar mengambil bagian
+-- remaining_value sebanding jumlahnya, sehingga unit terakhir membawa
+-- sisa pembulatan dan nilai lapisan tidak hilang. unit_cost_amount hanya
+-- informasi harga pokok per unit yang sudah dibulatkan.
+ALTER TABLE cost_layers ADD COLUMN IF NOT EXISTS remaining_value BIGINT;
+UPDATE cost_layers SET remaining_value = remaining * unit_cost_amount WHERE remaining_value IS NULL;
+ALTER TABLE cost_layers ALTER COLUMN remaining_value SET NOT NULL;
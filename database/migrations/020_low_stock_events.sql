@@ -0,0 +1,24 @@
+-- Antrian notifikasi stok rendah. Baris dicatat di transaksi yang sama
+-- dengan perubahan stok, saat stok turun melewati reorder point, sehingga
+-- penurunan yang pulih sebelum monitor berjalan tetap dinotifikasi.
+-- available_at menunda baris yang sedang dikirim atau gagal dikirim.
+CREATE TABLE IF NOT EXISTS low_stock_events (
+    id BIGSERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    stock INTEGER NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    available_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS low_stock_events_available_idx ON low_stock_events (available_at, id);
+
+-- Produk yang sedang rendah saat migrasi dan belum dinotifikasi tetap
+-- dikirim sekali
+INSERT INTO low_stock_events (product_id, stock)
+SELECT p.id, s.stock
+FROM products p
+CROSS JOIN LATERAL (SELECT COALESCE(SUM(ws.quantity), 0) AS stock FROM warehouse_stock ws WHERE ws.product_id = p.id) s
+WHERE p.reorder_point > 0 AND s.stock <= p.reorder_point
+    AND NOT EXISTS (SELECT 1 FROM low_stock_alerts a WHERE a.product_id = p.id);
+
+DROP TABLE IF EXISTS low_stock_alerts;
@@ -0,0 +1,20 @@
+package cache
+
+import "time"
+
+// Cache adalah penyimpanan key-value sederhana untuk read-through cache.
+// Value disimpan sebagai []byte supaya implementasi lokal (LRU) dan
+// remote (Redis) bisa dipakai bergantian.
+//
+// Setiap Delete dan DeletePrefix menaikkan Generation. Pembaca yang
+// memuat value dari database mencatat Generation sebelum query lalu
+// menyimpan hasilnya lewat SetIfGeneration, sehingga value yang dimuat
+// sebelum invalidasi tidak menimpa kembali cache yang baru dihapus.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte, ttl time.Duration)
+	SetIfGeneration(key string, value []byte, ttl time.Duration, generation uint64)
+	Generation() uint64
+	Delete(keys ...string)
+	DeletePrefix(prefix string)
+}
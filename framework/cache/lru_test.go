@@ -0,0 +1,101 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewLRU(2)
+	c.Set("a", []byte("1"), 0)
+	c.Set("b", []byte("2"), 0)
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("Get(a) miss, want hit")
+	}
+	c.Set("c", []byte("3"), 0)
+
+	if _, ok := c.Get("b"); ok {
+		t.Error("Get(b) hit, want evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := c.Get(key); !ok {
+			t.Errorf("Get(%s) miss, want hit", key)
+		}
+	}
+}
+
+func TestLRUSetOverwrites(t *testing.T) {
+	c := NewLRU(2)
+	c.Set("a", []byte("1"), 0)
+	c.Set("a", []byte("2"), 0)
+	c.Set("b", []byte("3"), 0)
+
+	value, ok := c.Get("a")
+	if !ok || string(value) != "2" {
+		t.Errorf("Get(a) = %q, %v, want 2, true", value, ok)
+	}
+}
+
+func TestLRUExpires(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewLRU(10).(*lruCache)
+	c.now = func() time.Time { return now }
+
+	c.Set("short", []byte("1"), time.Minute)
+	c.Set("forever", []byte("2"), 0)
+	now = now.Add(2 * time.Minute)
+
+	if _, ok := c.Get("short"); ok {
+		t.Error("Get(short) hit after ttl, want miss")
+	}
+	if _, ok := c.Get("forever"); !ok {
+		t.Error("Get(forever) miss, want hit")
+	}
+	if len(c.items) != 1 {
+		t.Errorf("items = %d, want expired entry removed", len(c.items))
+	}
+}
+
+func TestLRUDeletePrefix(t *testing.T) {
+	c := NewLRU(10)
+	c.Set("category:all", []byte("1"), 0)
+	c.Set("category:id:1", []byte("2"), 0)
+	c.Set("product:id:1", []byte("3"), 0)
+
+	c.DeletePrefix("category:")
+
+	for _, key := range []string{"category:all", "category:id:1"} {
+		if _, ok := c.Get(key); ok {
+			t.Errorf("Get(%s) hit, want deleted", key)
+		}
+	}
+	if _, ok := c.Get("product:id:1"); !ok {
+		t.Error("Get(product:id:1) miss, want hit")
+	}
+}
+
+func TestLRUSetIfGeneration(t *testing.T) {
+	c := NewLRU(10)
+	generation := c.Generation()
+
+	c.Delete("product:id:1")
+	if c.Generation() == generation {
+		t.Fatal("Delete() did not bump generation")
+	}
+	c.SetIfGeneration("product:id:1", []byte("stale"), 0, generation)
+	if _, ok := c.Get("product:id:1"); ok {
+		t.Error("SetIfGeneration() stored value loaded before Delete()")
+	}
+
+	generation = c.Generation()
+	c.DeletePrefix("category:")
+	c.SetIfGeneration("category:all", []byte("stale"), 0, generation)
+	if _, ok := c.Get("category:all"); ok {
+		t.Error("SetIfGeneration() stored value loaded before DeletePrefix()")
+	}
+
+	c.SetIfGeneration("category:all", []byte("fresh"), 0, c.Generation())
+	if value, ok := c.Get("category:all"); !ok || string(value) != "fresh" {
+		t.Errorf("Get(category:all) = %q, %v, want fresh, true", value, ok)
+	}
+}
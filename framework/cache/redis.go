@@ -0,0 +1,123 @@
+package cache
+
+import (
+	"log"
+	"strconv"
+	"time"
+)
+
+// RedisClient adalah subset perintah Redis yang dibutuhkan cache ini.
+// Client apa pun (go-redis, rueidis, dll.) bisa dibungkus agar memenuhi
+// interface ini tanpa menambah dependency ke project.
+type RedisClient interface {
+	Get(key string) ([]byte, error)
+	Set(key string, value []byte, ttl time.Duration) error
+	Incr(key string) (int64, error)
+	Unlink(keys ...string) error
+	Scan(cursor uint64, match string, count int64) ([]string, uint64, error)
+}
+
+// redisScanCount adalah jumlah key yang diminta per SCAN. SCAN dipakai
+// sebagai ganti KEYS supaya DeletePrefix tidak memblokir Redis selama
+// menelusuri seluruh keyspace.
+const redisScanCount = 500
+
+// redisGenerationKey menyimpan generation yang dibagi semua instance.
+const redisGenerationKey = "cache:generation"
+
+type redisCache struct {
+	client RedisClient
+	prefix string
+}
+
+// NewRedis membuat Cache di atas RedisClient. Semua key diberi prefix
+// supaya beberapa service bisa berbagi satu instance Redis.
+func NewRedis(client RedisClient, prefix string) Cache {
+	return &redisCache{client: client, prefix: prefix}
+}
+
+// Get menganggap error dari Redis sebagai cache miss, sehingga request tetap
+// dilayani dari database ketika Redis tidak tersedia.
+func (c *redisCache) Get(key string) ([]byte, bool) {
+	value, err := c.client.Get(c.prefix + key)
+	if err != nil || value == nil {
+		return nil, false
+	}
+	return value, true
+}
+
+func (c *redisCache) Set(key string, value []byte, ttl time.Duration) {
+	if err := c.client.Set(c.prefix+key, value, ttl); err != nil {
+		log.Printf("cache: redis set %s: %v", key, err)
+	}
+}
+
+// SetIfGeneration membandingkan generation lalu menyimpan value dalam dua
+// perintah terpisah. Invalidasi yang jatuh tepat di antara keduanya masih
+// bisa tertimpa, tetapi jendelanya jauh lebih sempit daripada seluruh
+// query database dan tetap dibatasi TTL.
+func (c *redisCache) SetIfGeneration(key string, value []byte, ttl time.Duration, generation uint64) {
+	if c.Generation() != generation {
+		return
+	}
+	c.Set(key, value, ttl)
+}
+
+// Generation mengembalikan 0 ketika key generation belum ada atau Redis
+// tidak bisa dibaca.
+func (c *redisCache) Generation() uint64 {
+	raw, err := c.client.Get(c.prefix + redisGenerationKey)
+	if err != nil || raw == nil {
+		return 0
+	}
+	generation, err := strconv.ParseUint(string(raw), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return generation
+}
+
+// bump menaikkan generation sebelum key dihapus, supaya pembaca yang
+// memuat value sebelum invalidasi tidak menyimpannya lagi.
+func (c *redisCache) bump() {
+	if _, err := c.client.Incr(c.prefix + redisGenerationKey); err != nil {
+		log.Printf("cache: redis incr generation: %v", err)
+	}
+}
+
+func (c *redisCache) Delete(keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+	c.bump()
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = c.prefix + key
+	}
+	if err := c.client.Unlink(prefixed...); err != nil {
+		log.Printf("cache: redis unlink: %v", err)
+	}
+}
+
+// DeletePrefix menelusuri key dengan SCAN dan menghapus setiap batch
+// dengan UNLINK, sehingga Redis tidak terblokir oleh keyspace yang besar.
+func (c *redisCache) DeletePrefix(prefix string) {
+	c.bump()
+	var cursor uint64
+	for {
+		keys, next, err := c.client.Scan(cursor, c.prefix+prefix+"*", redisScanCount)
+		if err != nil {
+			log.Printf("cache: redis scan %s*: %v", prefix, err)
+			return
+		}
+		if len(keys) > 0 {
+			if err := c.client.Unlink(keys...); err != nil {
+				log.Printf("cache: redis unlink: %v", err)
+			}
+		}
+		if next == 0 {
+			return
+		}
+		cursor = next
+	}
+}
@@ -0,0 +1,101 @@
+package cache
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeRedis menyimpan key di map dan mengembalikan SCAN satu key per
+// halaman, sehingga loop cursor benar-benar dijalani. Cursor adalah posisi
+// di order, yang tidak bergeser ketika key dihapus.
+type fakeRedis struct {
+	data  map[string][]byte
+	order []string
+	scans int
+}
+
+func newFakeRedis() *fakeRedis {
+	return &fakeRedis{data: make(map[string][]byte)}
+}
+
+func (r *fakeRedis) Get(key string) ([]byte, error) {
+	return r.data[key], nil
+}
+
+func (r *fakeRedis) Set(key string, value []byte, ttl time.Duration) error {
+	if _, ok := r.data[key]; !ok {
+		r.order = append(r.order, key)
+	}
+	r.data[key] = value
+	return nil
+}
+
+func (r *fakeRedis) Incr(key string) (int64, error) {
+	n, _ := strconv.ParseInt(string(r.data[key]), 10, 64)
+	n++
+	return n, r.Set(key, []byte(strconv.FormatInt(n, 10)), 0)
+}
+
+func (r *fakeRedis) Unlink(keys ...string) error {
+	for _, key := range keys {
+		delete(r.data, key)
+	}
+	return nil
+}
+
+func (r *fakeRedis) Scan(cursor uint64, match string, count int64) ([]string, uint64, error) {
+	r.scans++
+	prefix := strings.TrimSuffix(match, "*")
+	for i := int(cursor); i < len(r.order); i++ {
+		key := r.order[i]
+		if _, ok := r.data[key]; ok && strings.HasPrefix(key, prefix) {
+			next := uint64(i + 1)
+			if i+1 == len(r.order) {
+				next = 0
+			}
+			return []string{key}, next, nil
+		}
+	}
+	return nil, 0, nil
+}
+
+func TestRedisDeletePrefixScans(t *testing.T) {
+	client := newFakeRedis()
+	c := NewRedis(client, "app:")
+	c.Set("category:all", []byte("1"), 0)
+	c.Set("category:id:1", []byte("2"), 0)
+	c.Set("category:id:2", []byte("3"), 0)
+	c.Set("product:id:1", []byte("4"), 0)
+
+	c.DeletePrefix("category:")
+
+	if client.scans < 2 {
+		t.Errorf("Scan() called %d times, want cursor followed", client.scans)
+	}
+	for _, key := range []string{"category:all", "category:id:1", "category:id:2"} {
+		if _, ok := c.Get(key); ok {
+			t.Errorf("Get(%s) hit, want deleted", key)
+		}
+	}
+	if _, ok := c.Get("product:id:1"); !ok {
+		t.Error("Get(product:id:1) miss, want hit")
+	}
+}
+
+func TestRedisSetIfGeneration(t *testing.T) {
+	c := NewRedis(newFakeRedis(), "app:")
+	generation := c.Generation()
+
+	c.Delete("product:id:1")
+	c.SetIfGeneration("product:id:1", []byte("stale"), 0, generation)
+	if _, ok := c.Get("product:id:1"); ok {
+		t.Error("SetIfGeneration() stored value loaded before Delete()")
+	}
+
+	c.SetIfGeneration("product:id:1", []byte("fresh"), 0, c.Generation())
+	if value, ok := c.Get("product:id:1"); !ok || string(value) != "fresh" {
+		t.Errorf("Get(product:id:1) = %q, %v, want fresh, true", value, ok)
+	}
+}
@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+)
+
+// actorHeader adalah header opsional berisi nama pelaku perubahan, dipakai
+// untuk audit.
+const actorHeader = "X-Actor"
+
+// maxActorLength sama dengan panjang kolom actor di database
+const maxActorLength = 100
+
+// requestActor mengembalikan pelaku perubahan untuk audit: header X-Actor,
+// atau "anonymous".
+func requestActor(r *http.Request) string {
+	if actor := strings.TrimSpace(r.Header.Get(actorHeader)); actor != "" {
+		return truncate(actor, maxActorLength)
+	}
+	return "anonymous"
+}
+
+// truncate memotong s menjadi maksimal n rune
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"encoding/json"
+	"go-boot-category-api/framework/router"
+	"go-boot-category-api/model"
+	"go-boot-category-api/service"
+	"net/http"
+	"strconv"
+)
+
+type attributeHandler struct {
+	service service.Attribute
+}
+
+func NewAttributeHandler(service service.Attribute) *attributeHandler {
+	return &attributeHandler{service: service}
+}
+
+// Routes - daftar endpoint /categories/{id}/attributes
+func (h *attributeHandler) Routes() []router.Route {
+	tags := []string{"attributes"}
+	return []router.Route{
+		{Name: "attributes.list", Method: http.MethodGet, Path: "/categories/{id}/attributes", Handler: h.GetAll, Doc: router.Doc{
+			Summary: "List attribute definitions of a category",
+			Tags:    tags,
+			Responses: map[int]interface{}{
+				http.StatusOK:         []model.AttributeDefinition{},
+				http.StatusBadRequest: router.ErrorResponse{},
+				http.StatusNotFound:   router.ErrorResponse{},
+			},
+		}},
+		{Name: "attributes.create", Method: http.MethodPost, Path: "/categories/{id}/attributes", Handler: h.Create, Doc: router.Doc{
+			Summary: "Define a product attribute for a category",
+			Tags:    tags,
+			Request: model.AttributeDefinitionInput{},
+			Responses: map[int]interface{}{
+				http.StatusCreated:               model.AttributeDefinition{},
+				http.StatusBadRequest:            ValidationErrorResponse{},
+				http.StatusNotFound:              router.ErrorResponse{},
+				http.StatusConflict:              router.ErrorResponse{},
+				http.StatusRequestEntityTooLarge: router.ErrorResponse{},
+				http.StatusUnsupportedMediaType:  router.ErrorResponse{},
+			},
+		}},
+		{Name: "attributes.delete", Method: http.MethodDelete, Path: "/categories/{id}/attributes/{attribute_id}", Handler: h.Delete, Doc: router.Doc{
+			Summary: "Delete an attribute definition",
+			Tags:    tags,
+			Responses: map[int]interface{}{
+				http.StatusOK:         map[string]string{},
+				http.StatusBadRequest: router.ErrorResponse{},
+				http.StatusNotFound:   router.ErrorResponse{},
+			},
+		}},
+	}
+}
+
+// GetAll - GET /api/v2/categories/{id}/attributes
+func (h *attributeHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	categoryID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, "Invalid Category ID")
+		return
+	}
+
+	defs, err := h.service.GetByCategory(categoryID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(defs)
+}
+
+// Create - POST /api/v2/categories/{id}/attributes
+func (h *attributeHandler) Create(w http.ResponseWriter, r *http.Request) {
+	categoryID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, "Invalid Category ID")
+		return
+	}
+
+	var input model.AttributeDefinitionInput
+	if err := decodeJSON(w, r, &input); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	def, err := h.service.Create(categoryID, input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(def)
+}
+
+// Delete - DELETE /api/v2/categories/{id}/attributes/{attribute_id}
+func (h *attributeHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	categoryID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, "Invalid Category ID")
+		return
+	}
+	id, err := strconv.Atoi(r.PathValue("attribute_id"))
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, "Invalid attribute ID")
+		return
+	}
+
+	if err := h.service.Delete(categoryID, id); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Attribute deleted successfully",
+	})
+}
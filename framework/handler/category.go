@@ -2,11 +2,12 @@ package handler
 
 import (
 	"encoding/json"
+	"go-boot-category-api/framework/router"
 	"go-boot-category-api/model"
 	"go-boot-category-api/service"
 	"net/http"
 	"strconv"
-	"strings"
+	"time"
 )
 
 type categoryHandler struct {
@@ -17,63 +18,110 @@ func NewCategoryHandler(service service.Category) *categoryHandler {
 	return &categoryHandler{service: service}
 }
 
-// ValidateCategory validates the category data
-func (h *categoryHandler) ValidateCategory(category *model.Category, isUpdate bool) string {
-	if category.Name == "" {
-		return "Category name is required"
-	}
-	if len(category.Name) < 3 {
-		return "Category name must be at least 3 characters"
-	}
-	if len(category.Name) > 255 {
-		return "Category name must not exceed 100 characters"
-	}
-	if len(category.Description) > 500 {
-		return "Category description must not exceed 500 characters"
-	}
-	return ""
-}
-
-// HandleCategorys - GET /api/categories
-func (h *categoryHandler) HandleCategories(w http.ResponseWriter, r *http.Request) {
-	switch r.Method {
-	case http.MethodGet:
-		h.GetAll(w, r)
-	case http.MethodPost:
-		h.Create(w, r)
-	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+// Routes - daftar endpoint /categories, relatif terhadap prefix versi API
+func (h *categoryHandler) Routes() []router.Route {
+	tags := []string{"categories"}
+	return []router.Route{
+		{Name: "categories.list", Method: http.MethodGet, Path: "/categories", Handler: h.GetAll, Doc: router.Doc{
+			Summary: "List categories",
+			Tags:    tags,
+			Query: []router.Param{
+				{Name: "expand", Description: "Comma-separated relations to embed: products"},
+			},
+			Responses: map[int]interface{}{
+				http.StatusOK:          []model.Category{},
+				http.StatusNotModified: nil,
+			},
+		}},
+		{Name: "categories.create", Method: http.MethodPost, Path: "/categories", Handler: h.Create, Doc: router.Doc{
+			Summary: "Create a category",
+			Tags:    tags,
+			Request: model.CategoryInput{},
+			Responses: map[int]interface{}{
+				http.StatusCreated:               model.Category{},
+				http.StatusBadRequest:            ValidationErrorResponse{},
+				http.StatusConflict:              router.ErrorResponse{},
+				http.StatusRequestEntityTooLarge: router.ErrorResponse{},
+				http.StatusUnsupportedMediaType:  router.ErrorResponse{},
+			},
+		}},
+		{Name: "categories.get", Method: http.MethodGet, Path: "/categories/{id}", Handler: h.GetByID, Doc: router.Doc{
+			Summary: "Get a category by ID",
+			Tags:    tags,
+			Query: []router.Param{
+				{Name: "expand", Description: "Comma-separated relations to embed: products"},
+			},
+			Responses: map[int]interface{}{
+				http.StatusOK:          model.Category{},
+				http.StatusNotModified: nil,
+				http.StatusBadRequest:  router.ErrorResponse{},
+				http.StatusNotFound:    router.ErrorResponse{},
+			},
+		}},
+		{Name: "categories.get_by_slug", Method: http.MethodGet, Path: "/slugs/categories/{slug}", Handler: h.GetBySlug, Doc: router.Doc{
+			Summary: "Get a category by slug; old slugs redirect to the current one",
+			Tags:    tags,
+			Responses: map[int]interface{}{
+				http.StatusOK:               model.Category{},
+				http.StatusMovedPermanently: nil,
+				http.StatusNotModified:      nil,
+				http.StatusNotFound:         router.ErrorResponse{},
+			},
+		}},
+		{Name: "categories.update", Method: http.MethodPut, Path: "/categories/{id}", Handler: h.Update, Doc: router.Doc{
+			Summary: "Update a category",
+			Tags:    tags,
+			Request: model.CategoryInput{},
+			Responses: map[int]interface{}{
+				http.StatusOK:                    model.Category{},
+				http.StatusBadRequest:            ValidationErrorResponse{},
+				http.StatusConflict:              router.ErrorResponse{},
+				http.StatusNotFound:              router.ErrorResponse{},
+				http.StatusRequestEntityTooLarge: router.ErrorResponse{},
+				http.StatusUnsupportedMediaType:  router.ErrorResponse{},
+			},
+		}},
+		{Name: "categories.delete", Method: http.MethodDelete, Path: "/categories/{id}", Handler: h.Delete, Doc: router.Doc{
+			Summary: "Delete a category",
+			Tags:    tags,
+			Responses: map[int]interface{}{
+				http.StatusOK:         map[string]string{},
+				http.StatusBadRequest: router.ErrorResponse{},
+				http.StatusConflict:   router.ErrorResponse{},
+				http.StatusNotFound:   router.ErrorResponse{},
+			},
+		}},
 	}
 }
 
+// GetAll - GET /api/v2/categories
 func (h *categoryHandler) GetAll(w http.ResponseWriter, r *http.Request) {
-	Categorys, err := h.service.GetAll()
+	var Categorys []model.Category
+	var err error
+	if expandRequested(r, "products") {
+		Categorys, err = h.service.GetAllWithProducts()
+	} else {
+		Categorys, err = h.service.GetAll()
+	}
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(Categorys)
+	writeConditionalJSON(w, r, Categorys, time.Time{})
 }
 
+// Create - POST /api/v2/categories
 func (h *categoryHandler) Create(w http.ResponseWriter, r *http.Request) {
-	var category model.Category
-	err := json.NewDecoder(r.Body).Decode(&category)
-	if err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
-		return
-	}
-
-	// Validate category data
-	if validationErr := h.ValidateCategory(&category, false); validationErr != "" {
-		http.Error(w, validationErr, http.StatusBadRequest)
+	var input model.CategoryInput
+	if err := decodeJSON(w, r, &input); err != nil {
+		writeError(w, err)
 		return
 	}
 
-	err = h.service.Create(&category)
+	category, err := h.service.Create(input)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, err)
 		return
 	}
 
@@ -82,64 +130,68 @@ func (h *categoryHandler) Create(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(category)
 }
 
-// HandleCategoryByID - GET/PUT/DELETE /api/categories/{id}
-func (h *categoryHandler) HandleCategoryByID(w http.ResponseWriter, r *http.Request) {
-	switch r.Method {
-	case http.MethodGet:
-		h.GetByID(w, r)
-	case http.MethodPut:
-		h.Update(w, r)
-	case http.MethodDelete:
-		h.Delete(w, r)
-	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-	}
-}
-
-// GetByID - GET /api/categories/{id}
+// GetByID - GET /api/v2/categories/{id}
 func (h *categoryHandler) GetByID(w http.ResponseWriter, r *http.Request) {
-	idStr := strings.TrimPrefix(r.URL.Path, "/api/categories/")
-	id, err := strconv.Atoi(idStr)
+	id, err := strconv.Atoi(r.PathValue("id"))
 	if err != nil {
-		http.Error(w, "Invalid Category ID", http.StatusBadRequest)
+		router.WriteError(w, http.StatusBadRequest, "Invalid Category ID")
 		return
 	}
 
-	Category, err := h.service.GetByID(id)
+	// produk yang dihapus tidak memajukan updated_at, jadi response dengan
+	// produk hanya memakai ETag
+	var Category *model.Category
+	var lastModified time.Time
+	if expandRequested(r, "products") {
+		Category, err = h.service.GetByIDWithProducts(id)
+	} else {
+		Category, err = h.service.GetByID(id)
+		if err == nil {
+			lastModified = Category.UpdatedAt
+		}
+	}
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		writeError(w, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(Category)
+	writeConditionalJSON(w, r, Category, lastModified)
 }
 
-func (h *categoryHandler) Update(w http.ResponseWriter, r *http.Request) {
-	idStr := strings.TrimPrefix(r.URL.Path, "/api/categories/")
-	id, err := strconv.Atoi(idStr)
+// GetBySlug - GET /api/v2/slugs/categories/{slug}
+func (h *categoryHandler) GetBySlug(w http.ResponseWriter, r *http.Request) {
+	slug := r.PathValue("slug")
+	category, err := h.service.GetBySlug(slug)
 	if err != nil {
-		http.Error(w, "Invalid Category ID", http.StatusBadRequest)
+		writeError(w, err)
 		return
 	}
 
-	var Category model.Category
-	err = json.NewDecoder(r.Body).Decode(&Category)
+	if category.Slug != slug {
+		redirectToSlug(w, r, category.Slug)
+		return
+	}
+
+	writeConditionalJSON(w, r, category, category.UpdatedAt)
+}
+
+// Update - PUT /api/v2/categories/{id}
+func (h *categoryHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
 	if err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		router.WriteError(w, http.StatusBadRequest, "Invalid Category ID")
 		return
 	}
 
-	// Validate category data
-	if validationErr := h.ValidateCategory(&Category, true); validationErr != "" {
-		http.Error(w, validationErr, http.StatusBadRequest)
+	var input model.CategoryInput
+	if err := decodeJSON(w, r, &input); err != nil {
+		writeError(w, err)
 		return
 	}
 
-	Category.ID = id
-	err = h.service.Update(&Category)
+	Category, err := h.service.Update(id, input)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, err)
 		return
 	}
 
@@ -147,18 +199,17 @@ func (h *categoryHandler) Update(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(Category)
 }
 
-// Delete - DELETE /api/categories/{id}
+// Delete - DELETE /api/v2/categories/{id}
 func (h *categoryHandler) Delete(w http.ResponseWriter, r *http.Request) {
-	idStr := strings.TrimPrefix(r.URL.Path, "/api/categories/")
-	id, err := strconv.Atoi(idStr)
+	id, err := strconv.Atoi(r.PathValue("id"))
 	if err != nil {
-		http.Error(w, "Invalid Category ID", http.StatusBadRequest)
+		router.WriteError(w, http.StatusBadRequest, "Invalid Category ID")
 		return
 	}
 
 	err = h.service.Delete(id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"encoding/json"
+	"go-boot-category-api/framework/router"
+	"go-boot-category-api/model"
+	"go-boot-category-api/service"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// CategoryV1 adalah bentuk kategori di API v1. stock_value (Money per mata
+// uang) hanya ada mulai v2, dan produk yang di-embed memakai ProductV1
+// dengan price integer.
+type CategoryV1 struct {
+	ID           int         `json:"id"`
+	Name         string      `json:"name"`
+	Slug         string      `json:"slug"`
+	Description  string      `json:"description"`
+	ProductCount int         `json:"product_count"`
+	TotalStock   int         `json:"total_stock"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+	Products     []ProductV1 `json:"products,omitempty"`
+}
+
+func toCategoryV1(c *model.Category) CategoryV1 {
+	category := CategoryV1{
+		ID:           c.ID,
+		Name:         c.Name,
+		Slug:         c.Slug,
+		Description:  c.Description,
+		ProductCount: c.ProductCount,
+		TotalStock:   c.TotalStock,
+		UpdatedAt:    c.UpdatedAt,
+	}
+	if c.Products != nil {
+		category.Products = make([]ProductV1, len(c.Products))
+		for i := range c.Products {
+			category.Products[i] = toProductV1(&c.Products[i])
+		}
+	}
+	return category
+}
+
+// categoryV1Handler melayani /api/v1/categories dengan bentuk CategoryV1
+// selama masa transisi ke Money.
+type categoryV1Handler struct {
+	*categoryHandler
+}
+
+func NewCategoryV1Handler(service service.Category) *categoryV1Handler {
+	return &categoryV1Handler{categoryHandler: NewCategoryHandler(service)}
+}
+
+// Routes - daftar endpoint /categories versi 1
+func (h *categoryV1Handler) Routes() []router.Route {
+	routes := h.categoryHandler.Routes()
+	for i := range routes {
+		switch routes[i].Name {
+		case "categories.list":
+			routes[i].Handler = h.GetAll
+			routes[i].Doc.Responses[http.StatusOK] = []CategoryV1{}
+		case "categories.create":
+			routes[i].Handler = h.Create
+			routes[i].Doc.Responses[http.StatusCreated] = CategoryV1{}
+		case "categories.get":
+			routes[i].Handler = h.GetByID
+			routes[i].Doc.Responses[http.StatusOK] = CategoryV1{}
+		case "categories.get_by_slug":
+			routes[i].Handler = h.GetBySlug
+			routes[i].Doc.Responses[http.StatusOK] = CategoryV1{}
+		case "categories.update":
+			routes[i].Handler = h.Update
+			routes[i].Doc.Responses[http.StatusOK] = CategoryV1{}
+		}
+	}
+	return routes
+}
+
+// GetAll - GET /api/v1/categories
+func (h *categoryV1Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+	var categories []model.Category
+	var err error
+	if expandRequested(r, "products") {
+		categories, err = h.service.GetAllWithProducts()
+	} else {
+		categories, err = h.service.GetAll()
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	result := make([]CategoryV1, len(categories))
+	for i := range categories {
+		result[i] = toCategoryV1(&categories[i])
+	}
+
+	writeConditionalJSON(w, r, result, time.Time{})
+}
+
+// Create - POST /api/v1/categories
+func (h *categoryV1Handler) Create(w http.ResponseWriter, r *http.Request) {
+	var input model.CategoryInput
+	if err := decodeJSON(w, r, &input); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	category, err := h.service.Create(input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(toCategoryV1(category))
+}
+
+// GetByID - GET /api/v1/categories/{id}
+func (h *categoryV1Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, "Invalid Category ID")
+		return
+	}
+
+	// sama seperti v2: response dengan produk hanya memakai ETag
+	var category *model.Category
+	var lastModified time.Time
+	if expandRequested(r, "products") {
+		category, err = h.service.GetByIDWithProducts(id)
+	} else {
+		category, err = h.service.GetByID(id)
+		if err == nil {
+			lastModified = category.UpdatedAt
+		}
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeConditionalJSON(w, r, toCategoryV1(category), lastModified)
+}
+
+// GetBySlug - GET /api/v1/slugs/categories/{slug}
+func (h *categoryV1Handler) GetBySlug(w http.ResponseWriter, r *http.Request) {
+	slug := r.PathValue("slug")
+	category, err := h.service.GetBySlug(slug)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if category.Slug != slug {
+		redirectToSlug(w, r, category.Slug)
+		return
+	}
+
+	writeConditionalJSON(w, r, toCategoryV1(category), category.UpdatedAt)
+}
+
+// Update - PUT /api/v1/categories/{id}
+func (h *categoryV1Handler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, "Invalid Category ID")
+		return
+	}
+
+	var input model.CategoryInput
+	if err := decodeJSON(w, r, &input); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	category, err := h.service.Update(id, input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toCategoryV1(category))
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"go-boot-category-api/framework/router"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// writeConditionalJSON menulis response JSON beserta ETag dan Last-Modified.
+// Jika request membawa If-None-Match / If-Modified-Since yang masih cocok,
+// yang dikirim hanya 304 Not Modified tanpa body. lastModified nol berarti
+// hanya ETag; dipakai untuk koleksi, karena menghapus satu item tidak
+// memajukan updated_at terbaru sehingga If-Modified-Since bisa menjawab
+// 304 untuk daftar yang sudah berubah.
+func writeConditionalJSON(w http.ResponseWriter, r *http.Request, data interface{}, lastModified time.Time) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		router.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	w.Header().Set("ETag", etag)
+	if !lastModified.IsZero() {
+		w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if notModified(r, etag, lastModified) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(append(body, '\n'))
+}
+
+// notModified mengikuti RFC 9110: If-None-Match diprioritaskan, dan
+// If-Modified-Since hanya dipakai jika If-None-Match tidak dikirim.
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for _, candidate := range strings.Split(inm, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == "*" || candidate == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" && !lastModified.IsZero() {
+		since, err := http.ParseTime(ims)
+		if err != nil {
+			return false
+		}
+		return !lastModified.Truncate(time.Second).After(since)
+	}
+
+	return false
+}
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+)
+
+// Nilai yang boleh dipakai di ?format=
+const (
+	formatJSON = "json"
+	formatCSV  = "csv"
+)
+
+// parseFormat membaca ?format= dan mengembalikan formatJSON jika kosong.
+func parseFormat(r *http.Request) (string, error) {
+	switch format := r.URL.Query().Get("format"); format {
+	case "", formatJSON:
+		return formatJSON, nil
+	case formatCSV:
+		return formatCSV, nil
+	default:
+		return "", fmt.Errorf("format must be json or csv")
+	}
+}
+
+// writeCSV menulis header dan rows sebagai lampiran CSV bernama filename.
+func writeCSV(w http.ResponseWriter, filename string, header []string, rows [][]string) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	cw := csv.NewWriter(w)
+	cw.Write(header)
+	cw.WriteAll(rows)
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// MaxBodyBytes adalah ukuran maksimum body request JSON.
+var MaxBodyBytes int64 = 1 << 20
+
+type decodeError struct {
+	status  int
+	message string
+}
+
+func (e *decodeError) Error() string {
+	return e.message
+}
+
+// decodeJSON membaca body request ke dst secara ketat: Content-Type harus
+// application/json, ukuran body dibatasi MaxBodyBytes, field yang tidak
+// dikenal ditolak, dan body harus berisi tepat satu objek JSON.
+func decodeJSON(w http.ResponseWriter, r *http.Request, dst interface{}) *decodeError {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/json" {
+		return &decodeError{http.StatusUnsupportedMediaType, "Content-Type must be application/json"}
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, MaxBodyBytes)
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(dst); err != nil {
+		return jsonDecodeError(err)
+	}
+	if err := dec.Decode(&struct{}{}); err != io.EOF {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return jsonDecodeError(err)
+		}
+		return &decodeError{http.StatusBadRequest, "Request body must contain a single JSON object"}
+	}
+	return nil
+}
+
+func jsonDecodeError(err error) *decodeError {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var maxBytesErr *http.MaxBytesError
+
+	switch {
+	case errors.As(err, &maxBytesErr):
+		return &decodeError{http.StatusRequestEntityTooLarge, fmt.Sprintf("Request body must not exceed %d bytes", maxBytesErr.Limit)}
+	case errors.As(err, &syntaxErr):
+		return &decodeError{http.StatusBadRequest, fmt.Sprintf("Malformed JSON at position %d", syntaxErr.Offset)}
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return &decodeError{http.StatusBadRequest, "Malformed JSON"}
+	case errors.As(err, &typeErr):
+		return &decodeError{http.StatusBadRequest, fmt.Sprintf("Field %q has invalid type", typeErr.Field)}
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field := strings.TrimPrefix(err.Error(), "json: unknown field ")
+		return &decodeError{http.StatusBadRequest, fmt.Sprintf("Unknown field %s", field)}
+	case errors.Is(err, io.EOF):
+		return &decodeError{http.StatusBadRequest, "Request body must not be empty"}
+	}
+	// error dari UnmarshalJSON custom, misal amount Money yang tidak valid
+	return &decodeError{http.StatusBadRequest, "Invalid request body: " + err.Error()}
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"go-boot-category-api/framework/router"
+	"go-boot-category-api/framework/validator"
+	"go-boot-category-api/service"
+	"log"
+	"net/http"
+)
+
+// ValidationErrorResponse adalah body 400 ketika input tidak lolos validasi.
+type ValidationErrorResponse struct {
+	Error  string           `json:"error"`
+	Fields validator.Errors `json:"fields,omitempty"`
+}
+
+// writeError menulis err sebagai JSON dengan status sesuai jenis error-nya.
+// Error validasi dirender lengkap dengan daftar field yang salah.
+func writeError(w http.ResponseWriter, err error) {
+	var fieldErrs validator.Errors
+	var decodeErr *decodeError
+
+	switch {
+	case errors.As(err, &fieldErrs):
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(ValidationErrorResponse{Error: "Validation failed", Fields: fieldErrs})
+	case errors.As(err, &decodeErr):
+		router.WriteError(w, decodeErr.status, decodeErr.message)
+	case errors.Is(err, service.ErrInvalidFilter):
+		router.WriteError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, service.ErrNotFound):
+		router.WriteError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrConflict), errors.Is(err, service.ErrInsufficientStock):
+		router.WriteError(w, http.StatusConflict, err.Error())
+	default:
+		// detail error internal tidak dikirim ke client
+		log.Printf("internal error: %v", err)
+		router.WriteError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
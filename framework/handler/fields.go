@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-boot-category-api/model"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// productRelations adalah relasi produk yang bisa di-embed lewat ?expand=
+var productRelations = []string{"category", "variants", "locations"}
+
+// productProjection adalah bentuk response produk yang diminta client
+// lewat ?fields=a,b dan ?expand=. fields nil berarti semua field; relasi
+// yang di-expand selalu ikut walaupun tidak disebut di fields.
+type productProjection struct {
+	fields []string
+	expand []string
+}
+
+// parseProductProjection membaca ?fields= dan ?expand=. Hanya field di
+// model.ProductFields yang diterima, ditambah relasi yang di-expand.
+func parseProductProjection(r *http.Request) (productProjection, error) {
+	var projection productProjection
+	for _, relation := range productRelations {
+		if expandRequested(r, relation) {
+			projection.expand = append(projection.expand, relation)
+		}
+	}
+
+	value := r.URL.Query().Get("fields")
+	if value == "" {
+		return projection, nil
+	}
+
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimSpace(field)
+		switch {
+		case field == "":
+			continue
+		case slices.Contains(projection.expand, field):
+		case !slices.Contains(model.ProductFields, field):
+			return projection, fmt.Errorf("unknown field %q, allowed: %s", field, strings.Join(model.ProductFields, ", "))
+		}
+		if !slices.Contains(projection.fields, field) {
+			projection.fields = append(projection.fields, field)
+		}
+	}
+	if len(projection.fields) == 0 {
+		return projection, fmt.Errorf("fields must not be empty")
+	}
+	return projection, nil
+}
+
+// expanded cek apakah relasi di-expand
+func (p productProjection) expanded(relation string) bool {
+	return slices.Contains(p.expand, relation)
+}
+
+// sqlFields adalah field yang perlu dibaca dari database. category_id dan
+// id selalu ikut jika relasi yang membutuhkannya di-expand.
+func (p productProjection) sqlFields() []string {
+	if p.fields == nil {
+		return nil
+	}
+	fields := slices.DeleteFunc(slices.Clone(p.fields), func(field string) bool {
+		return slices.Contains(productRelations, field)
+	})
+	if p.expanded("category") && !slices.Contains(fields, "category_id") {
+		fields = append(fields, "category_id")
+	}
+	if p.expanded("locations") && !slices.Contains(fields, "id") {
+		fields = append(fields, "id")
+	}
+	return fields
+}
+
+// apply mengubah produk menjadi response sesuai projection. Jika kategori
+// di-expand, pasangan category_id/category_name diganti objek category.
+func (p productProjection) apply(product model.Product) (interface{}, error) {
+	if p.fields == nil && !p.expanded("category") {
+		return product, nil
+	}
+
+	raw, err := json.Marshal(product)
+	if err != nil {
+		return nil, err
+	}
+	var view map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &view); err != nil {
+		return nil, err
+	}
+
+	if p.expanded("category") {
+		delete(view, "category_id")
+		delete(view, "category_name")
+		if _, ok := view["category"]; !ok {
+			view["category"] = json.RawMessage("null")
+		}
+	}
+	if p.fields != nil {
+		for key := range view {
+			if !slices.Contains(p.fields, key) && !slices.Contains(p.expand, key) {
+				delete(view, key)
+			}
+		}
+	}
+	return view, nil
+}
+
+// applyAll menerapkan apply ke setiap produk
+func (p productProjection) applyAll(products []model.Product) (interface{}, error) {
+	if p.fields == nil && !p.expanded("category") {
+		return products, nil
+	}
+
+	views := make([]interface{}, len(products))
+	for i, product := range products {
+		view, err := p.apply(product)
+		if err != nil {
+			return nil, err
+		}
+		views[i] = view
+	}
+	return views, nil
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"fmt"
+	"go-boot-category-api/framework/slug"
+	"go-boot-category-api/model"
+	"net/http"
+	"strings"
+)
+
+var attributeFilterOps = []string{"gte", "lte", "gt", "lt", "ne"}
+
+// parseProductFilter membaca filter produk dari query string.
+// attr.<key>=<value> berarti sama dengan, dan attr.<key>_<op>=<value>
+// dengan op salah satu dari gt, gte, lt, lte, ne. tag=a,b berarti produk
+// memiliki tag a atau b; parameter tag yang diulang harus terpenuhi semua.
+func parseProductFilter(r *http.Request) (model.ProductFilter, error) {
+	var filter model.ProductFilter
+	for _, value := range r.URL.Query()["tag"] {
+		var group []string
+		for _, raw := range strings.Split(value, ",") {
+			if name := slug.Make(raw); name != "" {
+				group = append(group, name)
+			}
+		}
+		if len(group) == 0 {
+			return filter, fmt.Errorf("invalid tag filter %q", value)
+		}
+		filter.Tags = append(filter.Tags, group)
+	}
+
+	for param, values := range r.URL.Query() {
+		name, ok := strings.CutPrefix(param, "attr.")
+		if !ok {
+			continue
+		}
+		if name == "" {
+			return filter, fmt.Errorf("invalid attribute filter %q", param)
+		}
+
+		op := "eq"
+		for _, candidate := range attributeFilterOps {
+			if key, found := strings.CutSuffix(name, "_"+candidate); found && key != "" {
+				name, op = key, candidate
+				break
+			}
+		}
+		for _, value := range values {
+			filter.Attributes = append(filter.Attributes, model.AttributeFilter{Key: name, Op: op, Value: value})
+		}
+	}
+	return filter, nil
+}
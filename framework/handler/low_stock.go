@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"encoding/json"
+	"go-boot-category-api/framework/router"
+	"go-boot-category-api/model"
+	"go-boot-category-api/service"
+	"net/http"
+)
+
+type lowStockHandler struct {
+	service service.LowStock
+}
+
+func NewLowStockHandler(service service.LowStock) *lowStockHandler {
+	return &lowStockHandler{service: service}
+}
+
+// Routes - laporan stok rendah
+func (h *lowStockHandler) Routes() []router.Route {
+	return []router.Route{
+		{Name: "products.low_stock", Method: http.MethodGet, Path: "/products/low-stock", Handler: h.GetAll, Doc: router.Doc{
+			Summary: "List products with on-hand stock at or below their reorder point",
+			Tags:    []string{"products"},
+			Responses: map[int]interface{}{
+				http.StatusOK: []model.LowStock{},
+			},
+		}},
+	}
+}
+
+// GetAll - GET /api/v2/products/low-stock
+func (h *lowStockHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	items, err := h.service.GetAll()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(items)
+}
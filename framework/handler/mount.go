@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"go-boot-category-api/framework/router"
+	"go-boot-category-api/service"
+)
+
+// Services adalah semua service yang dilayani lewat API.
+type Services struct {
+	Product       service.Product
+	Category      service.Category
+	Attribute     service.Attribute
+	Variant       service.Variant
+	Tag           service.Tag
+	Price         service.Price
+	Warehouse     service.Warehouse
+	Stock         service.Stock
+	Reservation   service.Reservation
+	LowStock      service.LowStock
+	Order         service.Order
+	Supplier      service.Supplier
+	PurchaseOrder service.PurchaseOrder
+	Stocktake     service.Stocktake
+	Report        service.Report
+}
+
+// Mount mendaftarkan semua resource API ke rt. /api/v2 adalah versi aktif
+// dengan price berupa Money; v1 masih memakai price integer selama masa
+// transisi, dan legacy (/api tanpa versi) melayani route yang persis sama
+// dengan v1.
+func Mount(rt *router.Router, s Services, v1, legacy router.Version) {
+	productHandler := NewProductHandler(s.Product)
+	productV1Handler := NewProductV1Handler(s.Product)
+	categoryHandler := NewCategoryHandler(s.Category)
+	categoryV1Handler := NewCategoryV1Handler(s.Category)
+	attributeHandler := NewAttributeHandler(s.Attribute)
+	variantHandler := NewVariantHandler(s.Variant)
+	tagHandler := NewTagHandler(s.Tag)
+	priceHandler := NewPriceHandler(s.Price)
+	warehouseHandler := NewWarehouseHandler(s.Warehouse)
+	stockHandler := NewStockHandler(s.Stock)
+	reservationHandler := NewReservationHandler(s.Reservation)
+	lowStockHandler := NewLowStockHandler(s.LowStock)
+	orderHandler := NewOrderHandler(s.Order)
+	supplierHandler := NewSupplierHandler(s.Supplier)
+	purchaseOrderHandler := NewPurchaseOrderHandler(s.PurchaseOrder)
+	stocktakeHandler := NewStocktakeHandler(s.Stocktake)
+	reportHandler := NewReportHandler(s.Report)
+
+	rt.MountVersion(router.Version{Prefix: "/api/v2"}, productHandler, categoryHandler, variantHandler, attributeHandler, tagHandler, priceHandler, warehouseHandler, stockHandler, reservationHandler, lowStockHandler, orderHandler, supplierHandler, purchaseOrderHandler, stocktakeHandler, reportHandler)
+
+	// Resource yang body-nya memakai Money (variant, harga, produk hasil
+	// tagging, pesanan, purchase order, laporan, stocktake) hanya ada di
+	// v2 karena kontrak v1 adalah price integer.
+	v1Resources := []router.Resource{productV1Handler, categoryV1Handler,
+		router.Without(tagHandler, "products.tags.add", "products.tags.remove"),
+		warehouseHandler, stockHandler, reservationHandler, lowStockHandler, supplierHandler}
+	rt.MountVersion(v1, v1Resources...)
+	rt.MountVersion(legacy, v1Resources...)
+}
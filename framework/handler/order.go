@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"encoding/json"
+	"go-boot-category-api/framework/router"
+	"go-boot-category-api/model"
+	"go-boot-category-api/service"
+	"net/http"
+	"slices"
+	"strconv"
+)
+
+// orderStatuses adalah nilai yang boleh dipakai di ?status=
+var orderStatuses = []string{model.OrderPending, model.OrderPaid, model.OrderShipped, model.OrderCompleted, model.OrderCancelled}
+
+type orderHandler struct {
+	service service.Order
+}
+
+func NewOrderHandler(service service.Order) *orderHandler {
+	return &orderHandler{service: service}
+}
+
+// Routes - daftar endpoint /orders
+func (h *orderHandler) Routes() []router.Route {
+	tags := []string{"orders"}
+	return []router.Route{
+		{Name: "orders.list", Method: http.MethodGet, Path: "/orders", Handler: h.GetAll, Doc: router.Doc{
+			Summary: "List orders, newest first",
+			Tags:    tags,
+			Query: []router.Param{
+				{Name: "status", Description: "Only orders with this status: pending, paid, shipped, completed or cancelled"},
+				{Name: "page", Description: "Page number, starting at 1"},
+				{Name: "per_page", Description: "Items per page, 1 to 100 (default 20)"},
+			},
+			Responses: map[int]interface{}{
+				http.StatusOK:         model.OrderPage{},
+				http.StatusBadRequest: router.ErrorResponse{},
+			},
+		}},
+		{Name: "orders.create", Method: http.MethodPost, Path: "/orders", Handler: h.Create, Doc: router.Doc{
+			Summary: "Place an order, deducting stock for every line or rejecting the whole order",
+			Tags:    tags,
+			Request: model.OrderInput{},
+			Responses: map[int]interface{}{
+				http.StatusCreated:               model.Order{},
+				http.StatusBadRequest:            ValidationErrorResponse{},
+				http.StatusConflict:              router.ErrorResponse{},
+				http.StatusRequestEntityTooLarge: router.ErrorResponse{},
+				http.StatusUnsupportedMediaType:  router.ErrorResponse{},
+			},
+		}},
+		{Name: "orders.get", Method: http.MethodGet, Path: "/orders/{id}", Handler: h.GetByID, Doc: router.Doc{
+			Summary: "Get an order by ID",
+			Tags:    tags,
+			Responses: map[int]interface{}{
+				http.StatusOK:         model.Order{},
+				http.StatusBadRequest: router.ErrorResponse{},
+				http.StatusNotFound:   router.ErrorResponse{},
+			},
+		}},
+		{Name: "orders.status", Method: http.MethodPost, Path: "/orders/{id}/status", Handler: h.Transition, Doc: router.Doc{
+			Summary: "Move an order to its next status; pending and paid orders can be cancelled, which restocks them",
+			Tags:    tags,
+			Request: model.OrderStatusInput{},
+			Responses: map[int]interface{}{
+				http.StatusOK:                    model.Order{},
+				http.StatusBadRequest:            ValidationErrorResponse{},
+				http.StatusNotFound:              router.ErrorResponse{},
+				http.StatusConflict:              router.ErrorResponse{},
+				http.StatusRequestEntityTooLarge: router.ErrorResponse{},
+				http.StatusUnsupportedMediaType:  router.ErrorResponse{},
+			},
+		}},
+	}
+}
+
+// GetAll - GET /api/v2/orders
+func (h *orderHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	page, err := parsePagination(r)
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	status := r.URL.Query().Get("status")
+	if status != "" && !slices.Contains(orderStatuses, status) {
+		router.WriteError(w, http.StatusBadRequest, "Invalid order status")
+		return
+	}
+
+	orders, err := h.service.GetAll(status, page)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(orders)
+}
+
+// Create - POST /api/v2/orders
+func (h *orderHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var input model.OrderInput
+	if err := decodeJSON(w, r, &input); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	order, err := h.service.Create(input, requestActor(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(order)
+}
+
+// GetByID - GET /api/v2/orders/{id}
+func (h *orderHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, "Invalid order ID")
+		return
+	}
+
+	order, err := h.service.GetByID(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(order)
+}
+
+// Transition - POST /api/v2/orders/{id}/status
+func (h *orderHandler) Transition(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, "Invalid order ID")
+		return
+	}
+
+	var input model.OrderStatusInput
+	if err := decodeJSON(w, r, &input); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	order, err := h.service.Transition(id, input, requestActor(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(order)
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"fmt"
+	"go-boot-category-api/model"
+	"net/http"
+	"strconv"
+)
+
+const (
+	defaultPerPage = 20
+	maxPerPage     = 100
+)
+
+// parsePagination membaca ?page= (mulai dari 1) dan ?per_page= (maksimal
+// maxPerPage) dari query string.
+func parsePagination(r *http.Request) (model.Pagination, error) {
+	page := model.Pagination{Page: 1, PerPage: defaultPerPage}
+	query := r.URL.Query()
+
+	if value := query.Get("page"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return page, fmt.Errorf("page must be a positive integer")
+		}
+		page.Page = n
+	}
+	if value := query.Get("per_page"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > maxPerPage {
+			return page, fmt.Errorf("per_page must be between 1 and %d", maxPerPage)
+		}
+		page.PerPage = n
+	}
+	return page, nil
+}
@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"encoding/json"
+	"go-boot-category-api/framework/router"
+	"go-boot-category-api/model"
+	"go-boot-category-api/service"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type priceHandler struct {
+	service service.Price
+}
+
+func NewPriceHandler(service service.Price) *priceHandler {
+	return &priceHandler{service: service}
+}
+
+// Routes - daftar endpoint riwayat dan jadwal harga produk
+func (h *priceHandler) Routes() []router.Route {
+	tags := []string{"prices"}
+	return []router.Route{
+		{Name: "prices.list", Method: http.MethodGet, Path: "/products/{id}/prices", Handler: h.GetSchedule, Doc: router.Doc{
+			Summary: "List price history and pending scheduled prices of a product",
+			Tags:    tags,
+			Responses: map[int]interface{}{
+				http.StatusOK:         model.PriceSchedule{},
+				http.StatusBadRequest: router.ErrorResponse{},
+				http.StatusNotFound:   router.ErrorResponse{},
+			},
+		}},
+		{Name: "prices.schedule", Method: http.MethodPost, Path: "/products/{id}/prices", Handler: h.Schedule, Doc: router.Doc{
+			Summary: "Schedule a future price",
+			Tags:    tags,
+			Request: model.ScheduledPriceInput{},
+			Responses: map[int]interface{}{
+				http.StatusCreated:               model.ScheduledPrice{},
+				http.StatusBadRequest:            ValidationErrorResponse{},
+				http.StatusNotFound:              router.ErrorResponse{},
+				http.StatusRequestEntityTooLarge: router.ErrorResponse{},
+				http.StatusUnsupportedMediaType:  router.ErrorResponse{},
+			},
+		}},
+		{Name: "prices.cancel", Method: http.MethodDelete, Path: "/products/{id}/prices/{price_id}", Handler: h.Cancel, Doc: router.Doc{
+			Summary: "Cancel a pending scheduled price",
+			Tags:    tags,
+			Responses: map[int]interface{}{
+				http.StatusOK:         map[string]string{},
+				http.StatusBadRequest: router.ErrorResponse{},
+				http.StatusNotFound:   router.ErrorResponse{},
+				http.StatusConflict:   router.ErrorResponse{},
+			},
+		}},
+		{Name: "prices.at", Method: http.MethodGet, Path: "/products/{id}/price", Handler: h.At, Doc: router.Doc{
+			Summary: "Get the price of a product in effect at a point in time",
+			Tags:    tags,
+			Query: []router.Param{
+				{Name: "at", Description: "RFC 3339 timestamp, e.g. 2026-01-31T17:00:00+07:00 (default now)"},
+			},
+			Responses: map[int]interface{}{
+				http.StatusOK:         model.PriceChange{},
+				http.StatusBadRequest: router.ErrorResponse{},
+				http.StatusNotFound:   router.ErrorResponse{},
+			},
+		}},
+	}
+}
+
+// GetSchedule - GET /api/v2/products/{id}/prices
+func (h *priceHandler) GetSchedule(w http.ResponseWriter, r *http.Request) {
+	productID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	schedule, err := h.service.GetSchedule(productID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(schedule)
+}
+
+// Schedule - POST /api/v2/products/{id}/prices
+func (h *priceHandler) Schedule(w http.ResponseWriter, r *http.Request) {
+	productID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var input model.ScheduledPriceInput
+	if err := decodeJSON(w, r, &input); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	price, err := h.service.Schedule(productID, input, requestActor(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(price)
+}
+
+// Cancel - DELETE /api/v2/products/{id}/prices/{price_id}
+func (h *priceHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	productID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+	id, err := strconv.ParseInt(r.PathValue("price_id"), 10, 64)
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, "Invalid scheduled price ID")
+		return
+	}
+
+	if err := h.service.Cancel(productID, id); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Scheduled price cancelled successfully",
+	})
+}
+
+// At - GET /api/v2/products/{id}/price?at=
+func (h *priceHandler) At(w http.ResponseWriter, r *http.Request) {
+	productID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	at := time.Now()
+	if value := r.URL.Query().Get("at"); value != "" {
+		at, err = time.Parse(time.RFC3339, value)
+		if err != nil {
+			router.WriteError(w, http.StatusBadRequest, "at must be an RFC 3339 timestamp")
+			return
+		}
+	}
+
+	price, err := h.service.At(productID, at)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(price)
+}
@@ -2,11 +2,13 @@ package handler
 
 import (
 	"encoding/json"
+	"go-boot-category-api/framework/router"
 	"go-boot-category-api/model"
 	"go-boot-category-api/service"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type productHandler struct {
@@ -17,135 +19,279 @@ func NewProductHandler(service service.Product) *productHandler {
 	return &productHandler{service: service}
 }
 
-// ValidateProduct validates the product data
-func (h *productHandler) ValidateProduct(product *model.Product, isUpdate bool) string {
-	if product.Name == "" {
-		return "Product name is required"
+// Routes - daftar endpoint /products, relatif terhadap prefix versi API
+func (h *productHandler) Routes() []router.Route {
+	tags := []string{"products"}
+	return []router.Route{
+		{Name: "products.list", Method: http.MethodGet, Path: "/products", Handler: h.GetAll, Doc: router.Doc{
+			Summary: "List products",
+			Tags:    tags,
+			Query: []router.Param{
+				{Name: "attr.{name}", Description: "Filter by attribute value; append _gt, _gte, _lt, _lte or _ne to the name for comparisons, e.g. attr.voltage_gte=110"},
+				{Name: "tag", Description: "Filter by tag; comma-separated tags match any of them, repeat the parameter to require all, e.g. tag=sale,new-arrival&tag=halal"},
+				{Name: "fields", Description: "Comma-separated fields to return, e.g. fields=id,name,price"},
+				{Name: "expand", Description: "Comma-separated relations to embed: category (replaces category_id and category_name), locations (stock per warehouse)"},
+			},
+			Responses: map[int]interface{}{
+				http.StatusOK:          []model.Product{},
+				http.StatusNotModified: nil,
+				http.StatusBadRequest:  router.ErrorResponse{},
+			},
+		}},
+		{Name: "products.create", Method: http.MethodPost, Path: "/products", Handler: h.Create, Doc: router.Doc{
+			Summary: "Create a product",
+			Tags:    tags,
+			Request: model.ProductInput{},
+			Responses: map[int]interface{}{
+				http.StatusCreated:               model.Product{},
+				http.StatusBadRequest:            ValidationErrorResponse{},
+				http.StatusRequestEntityTooLarge: router.ErrorResponse{},
+				http.StatusUnsupportedMediaType:  router.ErrorResponse{},
+			},
+		}},
+		{Name: "products.get", Method: http.MethodGet, Path: "/products/{id}", Handler: h.GetByID, Doc: router.Doc{
+			Summary: "Get a product by ID",
+			Tags:    tags,
+			Query: []router.Param{
+				{Name: "fields", Description: "Comma-separated fields to return, e.g. fields=id,name,price"},
+				{Name: "expand", Description: "Comma-separated relations to embed: variants, category (replaces category_id and category_name), locations (stock per warehouse)"},
+			},
+			Responses: map[int]interface{}{
+				http.StatusOK:          model.Product{},
+				http.StatusNotModified: nil,
+				http.StatusBadRequest:  router.ErrorResponse{},
+				http.StatusNotFound:    router.ErrorResponse{},
+			},
+		}},
+		{Name: "products.get_by_slug", Method: http.MethodGet, Path: "/slugs/products/{slug}", Handler: h.GetBySlug, Doc: router.Doc{
+			Summary: "Get a product by slug; old slugs redirect to the current one",
+			Tags:    tags,
+			Responses: map[int]interface{}{
+				http.StatusOK:               model.Product{},
+				http.StatusMovedPermanently: nil,
+				http.StatusNotModified:      nil,
+				http.StatusNotFound:         router.ErrorResponse{},
+			},
+		}},
+		{Name: "products.by_category", Method: http.MethodGet, Path: "/categories/{id}/products", Handler: h.GetByCategory, Doc: router.Doc{
+			Summary: "List products in a category, paginated",
+			Tags:    tags,
+			Query: []router.Param{
+				{Name: "page", Description: "Page number, starting at 1"},
+				{Name: "per_page", Description: "Items per page, 1 to 100 (default 20)"},
+				{Name: "attr.{name}", Description: "Filter by attribute value, as on the product list"},
+				{Name: "tag", Description: "Filter by tag, as on the product list"},
+				{Name: "fields", Description: "Comma-separated fields to return, e.g. fields=id,name,price"},
+				{Name: "expand", Description: "Comma-separated relations to embed: category (replaces category_id and category_name), locations (stock per warehouse)"},
+			},
+			Responses: map[int]interface{}{
+				http.StatusOK:          model.ProductPage{},
+				http.StatusNotModified: nil,
+				http.StatusBadRequest:  router.ErrorResponse{},
+				http.StatusNotFound:    router.ErrorResponse{},
+			},
+		}},
+		{Name: "products.update", Method: http.MethodPut, Path: "/products/{id}", Handler: h.Update, Doc: router.Doc{
+			Summary: "Update a product",
+			Tags:    tags,
+			Request: model.ProductInput{},
+			Responses: map[int]interface{}{
+				http.StatusOK:                    model.Product{},
+				http.StatusBadRequest:            ValidationErrorResponse{},
+				http.StatusNotFound:              router.ErrorResponse{},
+				http.StatusConflict:              router.ErrorResponse{},
+				http.StatusRequestEntityTooLarge: router.ErrorResponse{},
+				http.StatusUnsupportedMediaType:  router.ErrorResponse{},
+			},
+		}},
+		{Name: "products.delete", Method: http.MethodDelete, Path: "/products/{id}", Handler: h.Delete, Doc: router.Doc{
+			Summary: "Delete a product that has no stock on hand or active reservations",
+			Tags:    tags,
+			Responses: map[int]interface{}{
+				http.StatusOK:         map[string]string{},
+				http.StatusBadRequest: router.ErrorResponse{},
+				http.StatusNotFound:   router.ErrorResponse{},
+				http.StatusConflict:   router.ErrorResponse{},
+			},
+		}},
 	}
-	if len(product.Name) < 3 {
-		return "Product name must be at least 3 characters"
+}
+
+// GetAll - GET /api/v2/products
+func (h *productHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseProductFilter(r)
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, err.Error())
+		return
 	}
-	if len(product.Name) > 255 {
-		return "Product name must not exceed 100 characters"
+	projection, err := parseProductProjection(r)
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, err.Error())
+		return
 	}
-	if product.Price < 0 {
-		return "Product price cannot be negative"
+	filter.Fields = projection.sqlFields()
+
+	products, err := h.service.GetAll(filter)
+	if err != nil {
+		writeError(w, err)
+		return
 	}
-	if product.Stock < 0 {
-		return "Product stock cannot be negative"
+	if err := h.expand(projection, products); err != nil {
+		writeError(w, err)
+		return
 	}
-	if product.CategoryId <= 0 {
-		return "Category ID must be greater than 0"
+
+	body, err := projection.applyAll(products)
+	if err != nil {
+		writeError(w, err)
+		return
 	}
-	return ""
+	writeConditionalJSON(w, r, body, time.Time{})
 }
 
-// HandleProducts - GET /api/products
-func (h *productHandler) HandleProducts(w http.ResponseWriter, r *http.Request) {
-	switch r.Method {
-	case http.MethodGet:
-		h.GetAll(w, r)
-	case http.MethodPost:
-		h.Create(w, r)
-	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+// Create - POST /api/v2/products
+func (h *productHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var input model.ProductInput
+	if err := decodeJSON(w, r, &input); err != nil {
+		writeError(w, err)
+		return
 	}
-}
 
-func (h *productHandler) GetAll(w http.ResponseWriter, r *http.Request) {
-	products, err := h.service.GetAll()
+	product, err := h.service.Create(input, requestActor(r))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(products)
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(product)
 }
 
-func (h *productHandler) Create(w http.ResponseWriter, r *http.Request) {
-	var product model.Product
-	err := json.NewDecoder(r.Body).Decode(&product)
+// GetByID - GET /api/v2/products/{id}
+func (h *productHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
 	if err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		router.WriteError(w, http.StatusBadRequest, "Invalid product ID")
 		return
 	}
 
-	// Validate product data
-	if validationErr := h.ValidateProduct(&product, false); validationErr != "" {
-		http.Error(w, validationErr, http.StatusBadRequest)
+	projection, err := parseProductProjection(r)
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	err = h.service.Create(&product)
+	var product *model.Product
+	if expandRequested(r, "variants") {
+		product, err = h.service.GetByIDWithVariants(id)
+	} else {
+		product, err = h.service.GetByID(id)
+	}
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, err)
+		return
+	}
+	// salinan, karena produk dari cache bisa dipakai request lain
+	products := []model.Product{*product}
+	if err := h.expand(projection, products); err != nil {
+		writeError(w, err)
 		return
 	}
+	product = &products[0]
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(product)
+	// varian yang dihapus tidak memajukan updated_at, jadi response dengan
+	// varian hanya memakai ETag
+	lastModified := product.UpdatedAt
+	if expandRequested(r, "variants") {
+		lastModified = time.Time{}
+	}
+
+	body, err := projection.apply(*product)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeConditionalJSON(w, r, body, lastModified)
 }
 
-// HandleProductByID - GET/PUT/DELETE /api/products/{id}
-func (h *productHandler) HandleProductByID(w http.ResponseWriter, r *http.Request) {
-	switch r.Method {
-	case http.MethodGet:
-		h.GetByID(w, r)
-	case http.MethodPut:
-		h.Update(w, r)
-	case http.MethodDelete:
-		h.Delete(w, r)
-	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+// GetBySlug - GET /api/v2/slugs/products/{slug}
+func (h *productHandler) GetBySlug(w http.ResponseWriter, r *http.Request) {
+	slug := r.PathValue("slug")
+	product, err := h.service.GetBySlug(slug)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if product.Slug != slug {
+		redirectToSlug(w, r, product.Slug)
+		return
 	}
+
+	writeConditionalJSON(w, r, product, product.UpdatedAt)
 }
 
-// GetByID - GET /api/products/{id}
-func (h *productHandler) GetByID(w http.ResponseWriter, r *http.Request) {
-	idStr := strings.TrimPrefix(r.URL.Path, "/api/products/")
-	id, err := strconv.Atoi(idStr)
+// GetByCategory - GET /api/v2/categories/{id}/products
+func (h *productHandler) GetByCategory(w http.ResponseWriter, r *http.Request) {
+	categoryID, err := strconv.Atoi(r.PathValue("id"))
 	if err != nil {
-		http.Error(w, "Invalid product ID", http.StatusBadRequest)
+		router.WriteError(w, http.StatusBadRequest, "Invalid Category ID")
 		return
 	}
 
-	product, err := h.service.GetByID(id)
+	page, err := parsePagination(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		router.WriteError(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	filter, err := parseProductFilter(r)
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	projection, err := parseProductProjection(r)
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	filter.Fields = projection.sqlFields()
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(product)
-}
+	products, err := h.service.GetByCategory(categoryID, filter, page)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := h.expand(projection, products.Items); err != nil {
+		writeError(w, err)
+		return
+	}
 
-func (h *productHandler) Update(w http.ResponseWriter, r *http.Request) {
-	idStr := strings.TrimPrefix(r.URL.Path, "/api/products/")
-	id, err := strconv.Atoi(idStr)
+	items, err := projection.applyAll(products.Items)
 	if err != nil {
-		http.Error(w, "Invalid product ID", http.StatusBadRequest)
+		writeError(w, err)
 		return
 	}
+	writeConditionalJSON(w, r, productPageView{Items: items, Page: products.Page, PerPage: products.PerPage, Total: products.Total}, time.Time{})
+}
 
-	var product model.Product
-	err = json.NewDecoder(r.Body).Decode(&product)
+// Update - PUT /api/v2/products/{id}
+func (h *productHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
 	if err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		router.WriteError(w, http.StatusBadRequest, "Invalid product ID")
 		return
 	}
 
-	// Validate product data
-	if validationErr := h.ValidateProduct(&product, true); validationErr != "" {
-		http.Error(w, validationErr, http.StatusBadRequest)
+	var input model.ProductInput
+	if err := decodeJSON(w, r, &input); err != nil {
+		writeError(w, err)
 		return
 	}
 
-	product.ID = id
-	err = h.service.Update(&product)
+	product, err := h.service.Update(id, input, requestActor(r))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, err)
 		return
 	}
 
@@ -153,18 +299,17 @@ func (h *productHandler) Update(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(product)
 }
 
-// Delete - DELETE /api/products/{id}
+// Delete - DELETE /api/v2/products/{id}
 func (h *productHandler) Delete(w http.ResponseWriter, r *http.Request) {
-	idStr := strings.TrimPrefix(r.URL.Path, "/api/products/")
-	id, err := strconv.Atoi(idStr)
+	id, err := strconv.Atoi(r.PathValue("id"))
 	if err != nil {
-		http.Error(w, "Invalid product ID", http.StatusBadRequest)
+		router.WriteError(w, http.StatusBadRequest, "Invalid product ID")
 		return
 	}
 
 	err = h.service.Delete(id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
@@ -173,3 +318,38 @@ func (h *productHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		"message": "Product deleted successfully",
 	})
 }
+
+// expand mengisi relasi category dan locations yang diminta lewat ?expand=.
+// variants diambil terpisah karena hanya tersedia untuk satu produk.
+func (h *productHandler) expand(projection productProjection, products []model.Product) error {
+	if projection.expanded("category") {
+		if err := h.service.ExpandCategory(products); err != nil {
+			return err
+		}
+	}
+	if projection.expanded("locations") {
+		if err := h.service.ExpandLocations(products); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// productPageView adalah model.ProductPage dengan item yang sudah melewati
+// productProjection
+type productPageView struct {
+	Items   interface{} `json:"items"`
+	Page    int         `json:"page"`
+	PerPage int         `json:"per_page"`
+	Total   int         `json:"total"`
+}
+
+// expandRequested cek apakah relasi name ada di query ?expand=a,b
+func expandRequested(r *http.Request, name string) bool {
+	for _, item := range strings.Split(r.URL.Query().Get("expand"), ",") {
+		if strings.TrimSpace(item) == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,269 @@
+package handler
+
+import (
+	"encoding/json"
+	"go-boot-category-api/framework/router"
+	"go-boot-category-api/framework/validator"
+	"go-boot-category-api/model"
+	"go-boot-category-api/service"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// ProductV1 adalah bentuk produk di API v1, di mana price masih berupa
+// integer dalam unit utuh mata uang produk (rupiah untuk produk IDR).
+type ProductV1 struct {
+	ID           int       `json:"id"`
+	Name         string    `json:"name"`
+	Price        int64     `json:"price"`
+	Stock        int       `json:"stock"`
+	CategoryId   int       `json:"category_id"`
+	CategoryName string    `json:"category_name"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// ProductPageV1 adalah satu halaman daftar produk di API v1.
+type ProductPageV1 struct {
+	Items   []ProductV1 `json:"items"`
+	Page    int         `json:"page"`
+	PerPage int         `json:"per_page"`
+	Total   int         `json:"total"`
+}
+
+// ProductInputV1 adalah body create/update produk di API v1. Seperti di
+// v2, stock hanya dipakai sebagai stok awal saat create.
+type ProductInputV1 struct {
+	Name       string `json:"name" validate:"required,min=3,max=255"`
+	Price      int64  `json:"price" validate:"min=0"`
+	Stock      *int   `json:"stock,omitempty" validate:"min=0"`
+	CategoryId int    `json:"category_id" validate:"required,min=1"`
+}
+
+func toProductV1(p *model.Product) ProductV1 {
+	return ProductV1{
+		ID:           p.ID,
+		Name:         p.Name,
+		Price:        p.Price.Major(),
+		Stock:        p.Stock,
+		CategoryId:   p.CategoryId,
+		CategoryName: p.CategoryName,
+		UpdatedAt:    p.UpdatedAt,
+	}
+}
+
+// input mengubah body v1 menjadi ProductInput. Field yang tidak ada di v1
+// (mata uang, atribut, reorder point) diambil dari produk yang sudah ada,
+// atau default jika existing nil. Price v1 dibulatkan ke bawah ke unit
+// utuh, jadi jika price tidak berubah harga lama dipakai apa adanya
+// supaya unit pecahannya tidak hilang. UpdatedAt diisi dari existing
+// supaya update ditolak jika produk berubah setelah dibaca.
+func (in ProductInputV1) input(existing *model.Product) (model.ProductInput, error) {
+	currency := "IDR"
+	input := model.ProductInput{
+		Name:       in.Name,
+		Stock:      in.Stock,
+		CategoryId: in.CategoryId,
+	}
+	if existing != nil {
+		currency = existing.Price.Currency
+		input.Attributes = existing.Attributes
+		input.ReorderPoint = existing.ReorderPoint
+		input.ReorderQty = existing.ReorderQty
+		input.UpdatedAt = &existing.UpdatedAt
+		if in.Price == existing.Price.Major() {
+			input.Price = existing.Price
+			return input, nil
+		}
+	}
+
+	price, err := model.NewMoneyFromMajor(in.Price, currency)
+	if err != nil {
+		return input, validator.Errors{{Field: "price", Rule: "max", Message: "price is too large"}}
+	}
+	input.Price = price
+	return input, nil
+}
+
+// productV1Handler melayani /api/v1/products dengan price integer selama
+// masa transisi ke Money. Produk baru dari v1 selalu dalam IDR.
+type productV1Handler struct {
+	*productHandler
+}
+
+func NewProductV1Handler(service service.Product) *productV1Handler {
+	return &productV1Handler{productHandler: NewProductHandler(service)}
+}
+
+// Routes - daftar endpoint /products versi 1
+func (h *productV1Handler) Routes() []router.Route {
+	// lookup by slug hanya tersedia mulai v2
+	routes := slices.DeleteFunc(h.productHandler.Routes(), func(route router.Route) bool {
+		return route.Name == "products.get_by_slug"
+	})
+	for i := range routes {
+		switch routes[i].Name {
+		case "products.list":
+			routes[i].Handler = h.GetAll
+			routes[i].Doc.Query = slices.DeleteFunc(routes[i].Doc.Query, func(param router.Param) bool {
+				return param.Name == "fields" || param.Name == "expand"
+			})
+			routes[i].Doc.Responses[http.StatusOK] = []ProductV1{}
+		case "products.by_category":
+			routes[i].Handler = h.GetByCategory
+			routes[i].Doc.Query = slices.DeleteFunc(routes[i].Doc.Query, func(param router.Param) bool {
+				return param.Name == "fields" || param.Name == "expand"
+			})
+			routes[i].Doc.Responses[http.StatusOK] = ProductPageV1{}
+		case "products.create":
+			routes[i].Handler = h.Create
+			routes[i].Doc.Request = ProductInputV1{}
+			routes[i].Doc.Responses[http.StatusCreated] = ProductV1{}
+		case "products.get":
+			routes[i].Handler = h.GetByID
+			routes[i].Doc.Query = nil
+			routes[i].Doc.Responses[http.StatusOK] = ProductV1{}
+		case "products.update":
+			routes[i].Handler = h.Update
+			routes[i].Doc.Request = ProductInputV1{}
+			routes[i].Doc.Responses[http.StatusOK] = ProductV1{}
+		}
+	}
+	return routes
+}
+
+// GetAll - GET /api/v1/products
+func (h *productV1Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseProductFilter(r)
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	products, err := h.service.GetAll(filter)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	result := make([]ProductV1, len(products))
+	for i := range products {
+		result[i] = toProductV1(&products[i])
+	}
+
+	writeConditionalJSON(w, r, result, time.Time{})
+}
+
+// GetByCategory - GET /api/v1/categories/{id}/products
+func (h *productV1Handler) GetByCategory(w http.ResponseWriter, r *http.Request) {
+	categoryID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, "Invalid Category ID")
+		return
+	}
+
+	page, err := parsePagination(r)
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	filter, err := parseProductFilter(r)
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	products, err := h.service.GetByCategory(categoryID, filter, page)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	result := ProductPageV1{Items: make([]ProductV1, len(products.Items)), Page: products.Page, PerPage: products.PerPage, Total: products.Total}
+	for i := range products.Items {
+		result.Items[i] = toProductV1(&products.Items[i])
+	}
+
+	writeConditionalJSON(w, r, result, time.Time{})
+}
+
+// Create - POST /api/v1/products
+func (h *productV1Handler) Create(w http.ResponseWriter, r *http.Request) {
+	var input ProductInputV1
+	if err := decodeJSON(w, r, &input); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	productInput, err := input.input(nil)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	product, err := h.service.Create(productInput, requestActor(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(toProductV1(product))
+}
+
+// GetByID - GET /api/v1/products/{id}
+func (h *productV1Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	product, err := h.service.GetByID(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeConditionalJSON(w, r, toProductV1(product), product.UpdatedAt)
+}
+
+// Update - PUT /api/v1/products/{id}
+// Mata uang dan atribut produk tidak berubah; price diartikan dalam mata
+// uang yang sama.
+func (h *productV1Handler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var input ProductInputV1
+	if err := decodeJSON(w, r, &input); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	existing, err := h.service.GetByID(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	productInput, err := input.input(existing)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	product, err := h.service.Update(id, productInput, requestActor(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toProductV1(product))
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"go-boot-category-api/model"
+	"testing"
+	"time"
+)
+
+func TestProductInputV1KeepsMinorUnits(t *testing.T) {
+	updatedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	existing := &model.Product{Price: model.Money{Amount: 1999, Currency: "USD"}, UpdatedAt: updatedAt}
+
+	tests := []struct {
+		name  string
+		price int64
+		want  model.Money
+	}{
+		{name: "unchanged", price: 19, want: model.Money{Amount: 1999, Currency: "USD"}},
+		{name: "changed", price: 25, want: model.Money{Amount: 2500, Currency: "USD"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input, err := ProductInputV1{Name: "Shirt", Price: tt.price, CategoryId: 1}.input(existing)
+			if err != nil {
+				t.Fatalf("input() error = %v", err)
+			}
+			if input.Price != tt.want {
+				t.Errorf("price = %+v, want %+v", input.Price, tt.want)
+			}
+			if input.UpdatedAt == nil || !input.UpdatedAt.Equal(updatedAt) {
+				t.Errorf("updated_at = %v, want %v", input.UpdatedAt, updatedAt)
+			}
+		})
+	}
+}
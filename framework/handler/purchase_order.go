@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"encoding/json"
+	"go-boot-category-api/framework/router"
+	"go-boot-category-api/model"
+	"go-boot-category-api/service"
+	"net/http"
+	"slices"
+	"strconv"
+)
+
+// purchaseOrderStatuses adalah nilai yang boleh dipakai di ?status=
+var purchaseOrderStatuses = []string{model.PurchaseOrderOpen, model.PurchaseOrderPartiallyReceived, model.PurchaseOrderReceived}
+
+type purchaseOrderHandler struct {
+	service service.PurchaseOrder
+}
+
+func NewPurchaseOrderHandler(service service.PurchaseOrder) *purchaseOrderHandler {
+	return &purchaseOrderHandler{service: service}
+}
+
+// Routes - daftar endpoint /purchase-orders
+func (h *purchaseOrderHandler) Routes() []router.Route {
+	tags := []string{"purchase orders"}
+	return []router.Route{
+		{Name: "purchase_orders.list", Method: http.MethodGet, Path: "/purchase-orders", Handler: h.GetAll, Doc: router.Doc{
+			Summary: "List purchase orders, newest first",
+			Tags:    tags,
+			Query: []router.Param{
+				{Name: "status", Description: "Only purchase orders with this status: open, partially_received or received"},
+				{Name: "page", Description: "Page number, starting at 1"},
+				{Name: "per_page", Description: "Items per page, 1 to 100 (default 20)"},
+			},
+			Responses: map[int]interface{}{
+				http.StatusOK:         model.PurchaseOrderPage{},
+				http.StatusBadRequest: router.ErrorResponse{},
+			},
+		}},
+		{Name: "purchase_orders.create", Method: http.MethodPost, Path: "/purchase-orders", Handler: h.Create, Doc: router.Doc{
+			Summary: "Create a purchase order",
+			Tags:    tags,
+			Request: model.PurchaseOrderInput{},
+			Responses: map[int]interface{}{
+				http.StatusCreated:               model.PurchaseOrder{},
+				http.StatusBadRequest:            ValidationErrorResponse{},
+				http.StatusRequestEntityTooLarge: router.ErrorResponse{},
+				http.StatusUnsupportedMediaType:  router.ErrorResponse{},
+			},
+		}},
+		{Name: "purchase_orders.get", Method: http.MethodGet, Path: "/purchase-orders/{id}", Handler: h.GetByID, Doc: router.Doc{
+			Summary: "Get a purchase order by ID",
+			Tags:    tags,
+			Responses: map[int]interface{}{
+				http.StatusOK:         model.PurchaseOrder{},
+				http.StatusBadRequest: router.ErrorResponse{},
+				http.StatusNotFound:   router.ErrorResponse{},
+			},
+		}},
+		{Name: "purchase_orders.receipts", Method: http.MethodGet, Path: "/purchase-orders/{id}/receipts", Handler: h.Receipts, Doc: router.Doc{
+			Summary: "List goods received against a purchase order",
+			Tags:    tags,
+			Responses: map[int]interface{}{
+				http.StatusOK:         []model.PurchaseReceipt{},
+				http.StatusBadRequest: router.ErrorResponse{},
+				http.StatusNotFound:   router.ErrorResponse{},
+			},
+		}},
+		{Name: "purchase_orders.receive", Method: http.MethodPost, Path: "/purchase-orders/{id}/receipts", Handler: h.Receive, Doc: router.Doc{
+			Summary: "Receive goods into a warehouse; partial receipts are allowed, over-receipts are rejected",
+			Tags:    tags,
+			Request: model.PurchaseReceiptInput{},
+			Responses: map[int]interface{}{
+				http.StatusCreated:               model.PurchaseReceipt{},
+				http.StatusBadRequest:            ValidationErrorResponse{},
+				http.StatusNotFound:              router.ErrorResponse{},
+				http.StatusConflict:              router.ErrorResponse{},
+				http.StatusRequestEntityTooLarge: router.ErrorResponse{},
+				http.StatusUnsupportedMediaType:  router.ErrorResponse{},
+			},
+		}},
+	}
+}
+
+// GetAll - GET /api/v2/purchase-orders
+func (h *purchaseOrderHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	page, err := parsePagination(r)
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	status := r.URL.Query().Get("status")
+	if status != "" && !slices.Contains(purchaseOrderStatuses, status) {
+		router.WriteError(w, http.StatusBadRequest, "Invalid purchase order status")
+		return
+	}
+
+	orders, err := h.service.GetAll(status, page)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(orders)
+}
+
+// Create - POST /api/v2/purchase-orders
+func (h *purchaseOrderHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var input model.PurchaseOrderInput
+	if err := decodeJSON(w, r, &input); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	order, err := h.service.Create(input, requestActor(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(order)
+}
+
+// GetByID - GET /api/v2/purchase-orders/{id}
+func (h *purchaseOrderHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, "Invalid purchase order ID")
+		return
+	}
+
+	order, err := h.service.GetByID(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(order)
+}
+
+// Receipts - GET /api/v2/purchase-orders/{id}/receipts
+func (h *purchaseOrderHandler) Receipts(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, "Invalid purchase order ID")
+		return
+	}
+
+	receipts, err := h.service.Receipts(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(receipts)
+}
+
+// Receive - POST /api/v2/purchase-orders/{id}/receipts
+func (h *purchaseOrderHandler) Receive(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, "Invalid purchase order ID")
+		return
+	}
+
+	var input model.PurchaseReceiptInput
+	if err := decodeJSON(w, r, &input); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	receipt, err := h.service.Receive(id, input, requestActor(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(receipt)
+}
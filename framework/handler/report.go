@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-boot-category-api/framework/router"
+	"go-boot-category-api/model"
+	"go-boot-category-api/service"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// defaultReportRange adalah rentang laporan margin jika ?from= kosong
+const defaultReportRange = 30 * 24 * time.Hour
+
+type reportHandler struct {
+	service service.Report
+}
+
+func NewReportHandler(service service.Report) *reportHandler {
+	return &reportHandler{service: service}
+}
+
+// Routes - laporan margin dan nilai persediaan
+func (h *reportHandler) Routes() []router.Route {
+	tags := []string{"reports"}
+	rangeQuery := []router.Param{
+		{Name: "from", Description: "Start of the range, RFC 3339 or YYYY-MM-DD (default 30 days before to)"},
+		{Name: "to", Description: "End of the range, exclusive; a date means the end of that day (default now)"},
+		{Name: "format", Description: "json (default) or csv"},
+	}
+	return []router.Route{
+		{Name: "reports.margin.products", Method: http.MethodGet, Path: "/reports/margin/products", Handler: h.ProductMargins, Doc: router.Doc{
+			Summary: "Gross margin per product for orders placed in a date range, excluding cancelled orders",
+			Tags:    tags,
+			Query:   rangeQuery,
+			Responses: map[int]interface{}{
+				http.StatusOK:         model.ProductMarginReport{},
+				http.StatusBadRequest: ValidationErrorResponse{},
+			},
+		}},
+		{Name: "reports.margin.categories", Method: http.MethodGet, Path: "/reports/margin/categories", Handler: h.CategoryMargins, Doc: router.Doc{
+			Summary: "Gross margin per category and currency for orders placed in a date range, excluding cancelled orders",
+			Tags:    tags,
+			Query:   rangeQuery,
+			Responses: map[int]interface{}{
+				http.StatusOK:         model.CategoryMarginReport{},
+				http.StatusBadRequest: ValidationErrorResponse{},
+			},
+		}},
+		{Name: "reports.inventory_value", Method: http.MethodGet, Path: "/reports/inventory-value", Handler: h.InventoryValuation, Doc: router.Doc{
+			Summary: "On-hand quantity and inventory value per product as of a point in time",
+			Tags:    tags,
+			Query: []router.Param{
+				{Name: "at", Description: "Point in time, RFC 3339 or YYYY-MM-DD; a date means the end of that day (default now)"},
+				{Name: "format", Description: "json (default) or csv"},
+			},
+			Responses: map[int]interface{}{
+				http.StatusOK:         model.InventoryValuation{},
+				http.StatusBadRequest: router.ErrorResponse{},
+			},
+		}},
+	}
+}
+
+// ProductMargins - GET /api/v2/reports/margin/products
+func (h *reportHandler) ProductMargins(w http.ResponseWriter, r *http.Request) {
+	format, from, to, ok := parseReportRange(w, r)
+	if !ok {
+		return
+	}
+
+	report, err := h.service.ProductMargins(from, to)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if format == formatCSV {
+		rows := make([][]string, 0, len(report.Items))
+		for _, m := range report.Items {
+			rows = append(rows, []string{
+				strconv.Itoa(m.ProductID), m.ProductName, strconv.Itoa(m.CategoryID), m.CategoryName, strconv.Itoa(m.QuantitySold),
+				m.Revenue.Currency, m.Revenue.String(), m.Cost.String(), m.Margin.String(), formatPercent(m.MarginPercent),
+			})
+		}
+		header := []string{"product_id", "product_name", "category_id", "category_name", "quantity_sold", "currency", "revenue", "cost", "margin", "margin_percent"}
+		writeCSV(w, reportFilename("product-margins", from, to), header, rows)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+// CategoryMargins - GET /api/v2/reports/margin/categories
+func (h *reportHandler) CategoryMargins(w http.ResponseWriter, r *http.Request) {
+	format, from, to, ok := parseReportRange(w, r)
+	if !ok {
+		return
+	}
+
+	report, err := h.service.CategoryMargins(from, to)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if format == formatCSV {
+		rows := make([][]string, 0, len(report.Items))
+		for _, m := range report.Items {
+			rows = append(rows, []string{
+				strconv.Itoa(m.CategoryID), m.CategoryName, strconv.Itoa(m.QuantitySold),
+				m.Revenue.Currency, m.Revenue.String(), m.Cost.String(), m.Margin.String(), formatPercent(m.MarginPercent),
+			})
+		}
+		header := []string{"category_id", "category_name", "quantity_sold", "currency", "revenue", "cost", "margin", "margin_percent"}
+		writeCSV(w, reportFilename("category-margins", from, to), header, rows)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+// InventoryValuation - GET /api/v2/reports/inventory-value
+func (h *reportHandler) InventoryValuation(w http.ResponseWriter, r *http.Request) {
+	format, err := parseFormat(r)
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	at := time.Now().UTC()
+	if value := r.URL.Query().Get("at"); value != "" {
+		if at, err = parseReportTime(value, true); err != nil {
+			router.WriteError(w, http.StatusBadRequest, "at must be an RFC 3339 timestamp or a YYYY-MM-DD date")
+			return
+		}
+	}
+
+	valuation, err := h.service.InventoryValuation(at)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if format == formatCSV {
+		rows := make([][]string, 0, len(valuation.Items))
+		for _, v := range valuation.Items {
+			rows = append(rows, []string{
+				strconv.Itoa(v.ProductID), v.ProductName, strconv.Itoa(v.CategoryID), v.CategoryName,
+				strconv.Itoa(v.Quantity), v.Value.Currency, v.Value.String(),
+			})
+		}
+		header := []string{"product_id", "product_name", "category_id", "category_name", "quantity", "currency", "value"}
+		writeCSV(w, fmt.Sprintf("inventory-value-%s.csv", at.Format("20060102")), header, rows)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(valuation)
+}
+
+// parseReportRange membaca ?format=, ?from= dan ?to=, lalu menulis 400 dan
+// mengembalikan ok false jika ada yang tidak valid.
+func parseReportRange(w http.ResponseWriter, r *http.Request) (format string, from, to time.Time, ok bool) {
+	format, err := parseFormat(r)
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	query := r.URL.Query()
+	to = time.Now().UTC()
+	if value := query.Get("to"); value != "" {
+		if to, err = parseReportTime(value, true); err != nil {
+			router.WriteError(w, http.StatusBadRequest, "to must be an RFC 3339 timestamp or a YYYY-MM-DD date")
+			return
+		}
+	}
+	from = to.Add(-defaultReportRange)
+	if value := query.Get("from"); value != "" {
+		if from, err = parseReportTime(value, false); err != nil {
+			router.WriteError(w, http.StatusBadRequest, "from must be an RFC 3339 timestamp or a YYYY-MM-DD date")
+			return
+		}
+	}
+	return format, from, to, true
+}
+
+// parseReportTime menerima RFC 3339 atau tanggal YYYY-MM-DD (UTC). Jika
+// endOfDay, tanggal berarti akhir hari itu, yaitu tengah malam berikutnya.
+func parseReportTime(value string, endOfDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		if endOfDay {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+func reportFilename(name string, from, to time.Time) string {
+	return fmt.Sprintf("%s-%s-%s.csv", name, from.Format("20060102"), to.Format("20060102"))
+}
+
+func formatPercent(p float64) string {
+	return strconv.FormatFloat(p, 'f', 2, 64)
+}
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"encoding/json"
+	"go-boot-category-api/framework/router"
+	"go-boot-category-api/model"
+	"go-boot-category-api/service"
+	"net/http"
+	"strconv"
+)
+
+type reservationHandler struct {
+	service service.Reservation
+}
+
+func NewReservationHandler(service service.Reservation) *reservationHandler {
+	return &reservationHandler{service: service}
+}
+
+// Routes - daftar endpoint /reservations
+func (h *reservationHandler) Routes() []router.Route {
+	tags := []string{"reservations"}
+	transition := map[int]interface{}{
+		http.StatusOK:         model.Reservation{},
+		http.StatusBadRequest: router.ErrorResponse{},
+		http.StatusNotFound:   router.ErrorResponse{},
+		http.StatusConflict:   router.ErrorResponse{},
+	}
+	return []router.Route{
+		{Name: "reservations.create", Method: http.MethodPost, Path: "/reservations", Handler: h.Create, Doc: router.Doc{
+			Summary: "Hold stock of a product for checkout until the reservation expires",
+			Tags:    tags,
+			Request: model.ReservationInput{},
+			Responses: map[int]interface{}{
+				http.StatusCreated:               model.Reservation{},
+				http.StatusBadRequest:            ValidationErrorResponse{},
+				http.StatusConflict:              router.ErrorResponse{},
+				http.StatusRequestEntityTooLarge: router.ErrorResponse{},
+				http.StatusUnsupportedMediaType:  router.ErrorResponse{},
+			},
+		}},
+		{Name: "reservations.get", Method: http.MethodGet, Path: "/reservations/{id}", Handler: h.GetByID, Doc: router.Doc{
+			Summary: "Get a reservation by ID",
+			Tags:    tags,
+			Responses: map[int]interface{}{
+				http.StatusOK:         model.Reservation{},
+				http.StatusBadRequest: router.ErrorResponse{},
+				http.StatusNotFound:   router.ErrorResponse{},
+			},
+		}},
+		{Name: "reservations.confirm", Method: http.MethodPost, Path: "/reservations/{id}/confirm", Handler: h.Confirm, Doc: router.Doc{
+			Summary:   "Confirm an active reservation, deducting its quantity from on-hand stock",
+			Tags:      tags,
+			Responses: transition,
+		}},
+		{Name: "reservations.release", Method: http.MethodPost, Path: "/reservations/{id}/release", Handler: h.Release, Doc: router.Doc{
+			Summary:   "Release an active reservation without changing on-hand stock",
+			Tags:      tags,
+			Responses: transition,
+		}},
+	}
+}
+
+// Create - POST /api/v2/reservations
+func (h *reservationHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var input model.ReservationInput
+	if err := decodeJSON(w, r, &input); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	reservation, err := h.service.Create(input, requestActor(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(reservation)
+}
+
+// GetByID - GET /api/v2/reservations/{id}
+func (h *reservationHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, "Invalid reservation ID")
+		return
+	}
+
+	reservation, err := h.service.GetByID(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reservation)
+}
+
+// Confirm - POST /api/v2/reservations/{id}/confirm
+func (h *reservationHandler) Confirm(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, "Invalid reservation ID")
+		return
+	}
+
+	reservation, err := h.service.Confirm(id, requestActor(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reservation)
+}
+
+// Release - POST /api/v2/reservations/{id}/release
+func (h *reservationHandler) Release(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, "Invalid reservation ID")
+		return
+	}
+
+	reservation, err := h.service.Release(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reservation)
+}
@@ -0,0 +1,17 @@
+package handler
+
+import (
+	"net/http"
+	"net/url"
+	"path"
+)
+
+// redirectToSlug mengirim 301 ke URL yang sama dengan segmen terakhir diganti
+// slug aktif, dipakai ketika request memakai slug lama dari history.
+func redirectToSlug(w http.ResponseWriter, r *http.Request, slug string) {
+	location := path.Dir(r.URL.Path) + "/" + url.PathEscape(slug)
+	if r.URL.RawQuery != "" {
+		location += "?" + r.URL.RawQuery
+	}
+	http.Redirect(w, r, location, http.StatusMovedPermanently)
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"encoding/json"
+	"go-boot-category-api/framework/router"
+	"go-boot-category-api/model"
+	"go-boot-category-api/service"
+	"net/http"
+	"strconv"
+)
+
+type stockHandler struct {
+	service service.Stock
+}
+
+func NewStockHandler(service service.Stock) *stockHandler {
+	return &stockHandler{service: service}
+}
+
+// Routes - daftar endpoint /stock dan penyesuaian stok produk
+func (h *stockHandler) Routes() []router.Route {
+	tags := []string{"stock"}
+	return []router.Route{
+		{Name: "stock.transfers.create", Method: http.MethodPost, Path: "/stock/transfers", Handler: h.Transfer, Doc: router.Doc{
+			Summary: "Move stock of a product between warehouses",
+			Tags:    tags,
+			Request: model.StockTransferInput{},
+			Responses: map[int]interface{}{
+				http.StatusCreated:               model.StockTransfer{},
+				http.StatusBadRequest:            ValidationErrorResponse{},
+				http.StatusConflict:              router.ErrorResponse{},
+				http.StatusRequestEntityTooLarge: router.ErrorResponse{},
+				http.StatusUnsupportedMediaType:  router.ErrorResponse{},
+			},
+		}},
+		{Name: "stock.transfers.get", Method: http.MethodGet, Path: "/stock/transfers/{id}", Handler: h.GetTransfer, Doc: router.Doc{
+			Summary: "Get a stock transfer with its ledger entries",
+			Tags:    tags,
+			Responses: map[int]interface{}{
+				http.StatusOK:         model.StockTransfer{},
+				http.StatusBadRequest: router.ErrorResponse{},
+				http.StatusNotFound:   router.ErrorResponse{},
+			},
+		}},
+		{Name: "products.stock.adjust", Method: http.MethodPost, Path: "/products/{id}/stock", Handler: h.Adjust, Doc: router.Doc{
+			Summary: "Add or remove stock of a product in one warehouse",
+			Tags:    tags,
+			Request: model.StockAdjustmentInput{},
+			Responses: map[int]interface{}{
+				http.StatusCreated:               model.LedgerEntry{},
+				http.StatusBadRequest:            ValidationErrorResponse{},
+				http.StatusNotFound:              router.ErrorResponse{},
+				http.StatusConflict:              router.ErrorResponse{},
+				http.StatusRequestEntityTooLarge: router.ErrorResponse{},
+				http.StatusUnsupportedMediaType:  router.ErrorResponse{},
+			},
+		}},
+	}
+}
+
+// Transfer - POST /api/v2/stock/transfers
+func (h *stockHandler) Transfer(w http.ResponseWriter, r *http.Request) {
+	var input model.StockTransferInput
+	if err := decodeJSON(w, r, &input); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	transfer, err := h.service.Transfer(input, requestActor(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(transfer)
+}
+
+// GetTransfer - GET /api/v2/stock/transfers/{id}
+func (h *stockHandler) GetTransfer(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, "Invalid transfer ID")
+		return
+	}
+
+	transfer, err := h.service.GetTransfer(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(transfer)
+}
+
+// Adjust - POST /api/v2/products/{id}/stock
+func (h *stockHandler) Adjust(w http.ResponseWriter, r *http.Request) {
+	productID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var input model.StockAdjustmentInput
+	if err := decodeJSON(w, r, &input); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	entry, err := h.service.Adjust(productID, input, requestActor(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(entry)
+}
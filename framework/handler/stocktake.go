@@ -0,0 +1,265 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-boot-category-api/framework/router"
+	"go-boot-category-api/model"
+	"go-boot-category-api/service"
+	"net/http"
+	"slices"
+	"strconv"
+)
+
+// stocktakeStatuses adalah nilai yang boleh dipakai di ?status=
+var stocktakeStatuses = []string{model.StocktakeOpen, model.StocktakeApproved, model.StocktakeCancelled}
+
+type stocktakeHandler struct {
+	service service.Stocktake
+}
+
+func NewStocktakeHandler(service service.Stocktake) *stocktakeHandler {
+	return &stocktakeHandler{service: service}
+}
+
+// Routes - daftar endpoint /stocktakes
+func (h *stocktakeHandler) Routes() []router.Route {
+	tags := []string{"stocktakes"}
+	transition := map[int]interface{}{
+		http.StatusOK:         model.Stocktake{},
+		http.StatusBadRequest: router.ErrorResponse{},
+		http.StatusNotFound:   router.ErrorResponse{},
+		http.StatusConflict:   router.ErrorResponse{},
+	}
+	return []router.Route{
+		{Name: "stocktakes.list", Method: http.MethodGet, Path: "/stocktakes", Handler: h.GetAll, Doc: router.Doc{
+			Summary: "List stocktake sessions, newest first, without their lines",
+			Tags:    tags,
+			Query: []router.Param{
+				{Name: "status", Description: "Only sessions with this status: open, approved or cancelled"},
+				{Name: "page", Description: "Page number, starting at 1"},
+				{Name: "per_page", Description: "Items per page, 1 to 100 (default 20)"},
+			},
+			Responses: map[int]interface{}{
+				http.StatusOK:         model.StocktakePage{},
+				http.StatusBadRequest: router.ErrorResponse{},
+			},
+		}},
+		{Name: "stocktakes.create", Method: http.MethodPost, Path: "/stocktakes", Handler: h.Create, Doc: router.Doc{
+			Summary: "Start a stocktake for a warehouse, a category or both, snapshotting the expected on-hand stock",
+			Tags:    tags,
+			Request: model.StocktakeInput{},
+			Responses: map[int]interface{}{
+				http.StatusCreated:               model.Stocktake{},
+				http.StatusBadRequest:            ValidationErrorResponse{},
+				http.StatusRequestEntityTooLarge: router.ErrorResponse{},
+				http.StatusUnsupportedMediaType:  router.ErrorResponse{},
+			},
+		}},
+		{Name: "stocktakes.get", Method: http.MethodGet, Path: "/stocktakes/{id}", Handler: h.GetByID, Doc: router.Doc{
+			Summary: "Get a stocktake session with its lines",
+			Tags:    tags,
+			Responses: map[int]interface{}{
+				http.StatusOK:         model.Stocktake{},
+				http.StatusBadRequest: router.ErrorResponse{},
+				http.StatusNotFound:   router.ErrorResponse{},
+			},
+		}},
+		{Name: "stocktakes.counts", Method: http.MethodPost, Path: "/stocktakes/{id}/counts", Handler: h.Count, Doc: router.Doc{
+			Summary: "Submit counted quantities for an open stocktake; a recount replaces the previous count",
+			Tags:    tags,
+			Request: model.StocktakeCountInput{},
+			Responses: map[int]interface{}{
+				http.StatusOK:                    model.Stocktake{},
+				http.StatusBadRequest:            ValidationErrorResponse{},
+				http.StatusNotFound:              router.ErrorResponse{},
+				http.StatusConflict:              router.ErrorResponse{},
+				http.StatusRequestEntityTooLarge: router.ErrorResponse{},
+				http.StatusUnsupportedMediaType:  router.ErrorResponse{},
+			},
+		}},
+		{Name: "stocktakes.approve", Method: http.MethodPost, Path: "/stocktakes/{id}/approve", Handler: h.Approve, Doc: router.Doc{
+			Summary:   "Approve an open stocktake, posting the variance of every counted line as a stock adjustment",
+			Tags:      tags,
+			Responses: transition,
+		}},
+		{Name: "stocktakes.cancel", Method: http.MethodPost, Path: "/stocktakes/{id}/cancel", Handler: h.Cancel, Doc: router.Doc{
+			Summary:   "Cancel an open stocktake without changing stock",
+			Tags:      tags,
+			Responses: transition,
+		}},
+		{Name: "stocktakes.variance", Method: http.MethodGet, Path: "/stocktakes/{id}/variance", Handler: h.Variance, Doc: router.Doc{
+			Summary: "Variance report of a stocktake: counted lines that differ from the snapshot, with totals",
+			Tags:    tags,
+			Query: []router.Param{
+				{Name: "format", Description: "json (default) or csv"},
+			},
+			Responses: map[int]interface{}{
+				http.StatusOK:         model.StocktakeVariance{},
+				http.StatusBadRequest: router.ErrorResponse{},
+				http.StatusNotFound:   router.ErrorResponse{},
+			},
+		}},
+	}
+}
+
+// GetAll - GET /api/v2/stocktakes
+func (h *stocktakeHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	page, err := parsePagination(r)
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	status := r.URL.Query().Get("status")
+	if status != "" && !slices.Contains(stocktakeStatuses, status) {
+		router.WriteError(w, http.StatusBadRequest, "Invalid stocktake status")
+		return
+	}
+
+	stocktakes, err := h.service.GetAll(status, page)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(stocktakes)
+}
+
+// Create - POST /api/v2/stocktakes
+func (h *stocktakeHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var input model.StocktakeInput
+	if err := decodeJSON(w, r, &input); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	stocktake, err := h.service.Create(input, requestActor(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(stocktake)
+}
+
+// GetByID - GET /api/v2/stocktakes/{id}
+func (h *stocktakeHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, "Invalid stocktake ID")
+		return
+	}
+
+	stocktake, err := h.service.GetByID(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(stocktake)
+}
+
+// Count - POST /api/v2/stocktakes/{id}/counts
+func (h *stocktakeHandler) Count(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, "Invalid stocktake ID")
+		return
+	}
+
+	var input model.StocktakeCountInput
+	if err := decodeJSON(w, r, &input); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	stocktake, err := h.service.Count(id, input, requestActor(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(stocktake)
+}
+
+// Approve - POST /api/v2/stocktakes/{id}/approve
+func (h *stocktakeHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, "Invalid stocktake ID")
+		return
+	}
+
+	stocktake, err := h.service.Approve(id, requestActor(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(stocktake)
+}
+
+// Cancel - POST /api/v2/stocktakes/{id}/cancel
+func (h *stocktakeHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, "Invalid stocktake ID")
+		return
+	}
+
+	stocktake, err := h.service.Cancel(id, requestActor(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(stocktake)
+}
+
+// Variance - GET /api/v2/stocktakes/{id}/variance
+func (h *stocktakeHandler) Variance(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, "Invalid stocktake ID")
+		return
+	}
+	format, err := parseFormat(r)
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := h.service.Variance(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if format == formatCSV {
+		rows := make([][]string, 0, len(report.Items))
+		for _, line := range report.Items {
+			productID := ""
+			if line.ProductID != nil {
+				productID = strconv.Itoa(*line.ProductID)
+			}
+			value := model.Money{Amount: line.UnitCost.Amount * int64(*line.Variance), Currency: line.UnitCost.Currency}
+			rows = append(rows, []string{
+				productID, line.ProductName, strconv.Itoa(line.WarehouseID), strconv.Itoa(line.Expected), strconv.Itoa(*line.Counted),
+				strconv.Itoa(*line.Variance), line.UnitCost.Currency, line.UnitCost.String(), value.String(),
+			})
+		}
+		header := []string{"product_id", "product_name", "warehouse_id", "expected", "counted", "variance", "currency", "unit_cost", "variance_value"}
+		writeCSV(w, fmt.Sprintf("stocktake-%d-variance.csv", id), header, rows)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"encoding/json"
+	"go-boot-category-api/framework/router"
+	"go-boot-category-api/model"
+	"go-boot-category-api/service"
+	"net/http"
+	"strconv"
+)
+
+type supplierHandler struct {
+	service service.Supplier
+}
+
+func NewSupplierHandler(service service.Supplier) *supplierHandler {
+	return &supplierHandler{service: service}
+}
+
+// Routes - daftar endpoint /suppliers
+func (h *supplierHandler) Routes() []router.Route {
+	tags := []string{"suppliers"}
+	return []router.Route{
+		{Name: "suppliers.list", Method: http.MethodGet, Path: "/suppliers", Handler: h.GetAll, Doc: router.Doc{
+			Summary: "List suppliers",
+			Tags:    tags,
+			Responses: map[int]interface{}{
+				http.StatusOK: []model.Supplier{},
+			},
+		}},
+		{Name: "suppliers.create", Method: http.MethodPost, Path: "/suppliers", Handler: h.Create, Doc: router.Doc{
+			Summary: "Create a supplier",
+			Tags:    tags,
+			Request: model.SupplierInput{},
+			Responses: map[int]interface{}{
+				http.StatusCreated:               model.Supplier{},
+				http.StatusBadRequest:            ValidationErrorResponse{},
+				http.StatusConflict:              router.ErrorResponse{},
+				http.StatusRequestEntityTooLarge: router.ErrorResponse{},
+				http.StatusUnsupportedMediaType:  router.ErrorResponse{},
+			},
+		}},
+		{Name: "suppliers.get", Method: http.MethodGet, Path: "/suppliers/{id}", Handler: h.GetByID, Doc: router.Doc{
+			Summary: "Get a supplier by ID",
+			Tags:    tags,
+			Responses: map[int]interface{}{
+				http.StatusOK:         model.Supplier{},
+				http.StatusBadRequest: router.ErrorResponse{},
+				http.StatusNotFound:   router.ErrorResponse{},
+			},
+		}},
+	}
+}
+
+// GetAll - GET /api/v2/suppliers
+func (h *supplierHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	suppliers, err := h.service.GetAll()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(suppliers)
+}
+
+// Create - POST /api/v2/suppliers
+func (h *supplierHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var input model.SupplierInput
+	if err := decodeJSON(w, r, &input); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	supplier, err := h.service.Create(input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(supplier)
+}
+
+// GetByID - GET /api/v2/suppliers/{id}
+func (h *supplierHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, "Invalid supplier ID")
+		return
+	}
+
+	supplier, err := h.service.GetByID(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(supplier)
+}
@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"encoding/json"
+	"go-boot-category-api/framework/router"
+	"go-boot-category-api/model"
+	"go-boot-category-api/service"
+	"net/http"
+	"strconv"
+)
+
+type tagHandler struct {
+	service service.Tag
+}
+
+func NewTagHandler(service service.Tag) *tagHandler {
+	return &tagHandler{service: service}
+}
+
+// Routes - daftar endpoint /tags dan /products/{id}/tags
+func (h *tagHandler) Routes() []router.Route {
+	tags := []string{"tags"}
+	return []router.Route{
+		{Name: "tags.list", Method: http.MethodGet, Path: "/tags", Handler: h.GetAll, Doc: router.Doc{
+			Summary: "List tags with product counts, most used first",
+			Tags:    tags,
+			Responses: map[int]interface{}{
+				http.StatusOK: []model.Tag{},
+			},
+		}},
+		{Name: "tags.create", Method: http.MethodPost, Path: "/tags", Handler: h.Create, Doc: router.Doc{
+			Summary: "Create a tag",
+			Tags:    tags,
+			Request: model.TagInput{},
+			Responses: map[int]interface{}{
+				http.StatusCreated:               model.Tag{},
+				http.StatusBadRequest:            ValidationErrorResponse{},
+				http.StatusConflict:              router.ErrorResponse{},
+				http.StatusRequestEntityTooLarge: router.ErrorResponse{},
+				http.StatusUnsupportedMediaType:  router.ErrorResponse{},
+			},
+		}},
+		{Name: "tags.delete", Method: http.MethodDelete, Path: "/tags/{id}", Handler: h.Delete, Doc: router.Doc{
+			Summary: "Delete a tag and remove it from all products",
+			Tags:    tags,
+			Responses: map[int]interface{}{
+				http.StatusOK:         map[string]string{},
+				http.StatusBadRequest: router.ErrorResponse{},
+				http.StatusNotFound:   router.ErrorResponse{},
+			},
+		}},
+		{Name: "products.tags.add", Method: http.MethodPost, Path: "/products/{id}/tags", Handler: h.AddToProduct, Doc: router.Doc{
+			Summary: "Add tags to a product, creating unknown tags",
+			Tags:    tags,
+			Request: model.ProductTagsInput{},
+			Responses: map[int]interface{}{
+				http.StatusOK:                    model.Product{},
+				http.StatusBadRequest:            ValidationErrorResponse{},
+				http.StatusNotFound:              router.ErrorResponse{},
+				http.StatusRequestEntityTooLarge: router.ErrorResponse{},
+				http.StatusUnsupportedMediaType:  router.ErrorResponse{},
+			},
+		}},
+		{Name: "products.tags.remove", Method: http.MethodDelete, Path: "/products/{id}/tags", Handler: h.RemoveFromProduct, Doc: router.Doc{
+			Summary: "Remove tags from a product",
+			Tags:    tags,
+			Request: model.ProductTagsInput{},
+			Responses: map[int]interface{}{
+				http.StatusOK:                    model.Product{},
+				http.StatusBadRequest:            ValidationErrorResponse{},
+				http.StatusNotFound:              router.ErrorResponse{},
+				http.StatusRequestEntityTooLarge: router.ErrorResponse{},
+				http.StatusUnsupportedMediaType:  router.ErrorResponse{},
+			},
+		}},
+	}
+}
+
+// GetAll - GET /api/v2/tags
+func (h *tagHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	tags, err := h.service.GetAll()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tags)
+}
+
+// Create - POST /api/v2/tags
+func (h *tagHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var input model.TagInput
+	if err := decodeJSON(w, r, &input); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	tag, err := h.service.Create(input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(tag)
+}
+
+// Delete - DELETE /api/v2/tags/{id}
+func (h *tagHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, "Invalid tag ID")
+		return
+	}
+
+	if err := h.service.Delete(id); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Tag deleted successfully",
+	})
+}
+
+// AddToProduct - POST /api/v2/products/{id}/tags
+func (h *tagHandler) AddToProduct(w http.ResponseWriter, r *http.Request) {
+	h.changeProductTags(w, r, h.service.AddToProduct)
+}
+
+// RemoveFromProduct - DELETE /api/v2/products/{id}/tags
+func (h *tagHandler) RemoveFromProduct(w http.ResponseWriter, r *http.Request) {
+	h.changeProductTags(w, r, h.service.RemoveFromProduct)
+}
+
+func (h *tagHandler) changeProductTags(w http.ResponseWriter, r *http.Request, change func(int, model.ProductTagsInput) (*model.Product, error)) {
+	productID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var input model.ProductTagsInput
+	if err := decodeJSON(w, r, &input); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	product, err := change(productID, input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(product)
+}
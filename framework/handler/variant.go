@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"encoding/json"
+	"go-boot-category-api/framework/router"
+	"go-boot-category-api/model"
+	"go-boot-category-api/service"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type variantHandler struct {
+	service service.Variant
+}
+
+func NewVariantHandler(service service.Variant) *variantHandler {
+	return &variantHandler{service: service}
+}
+
+// Routes - daftar endpoint /products/{id}/variants
+func (h *variantHandler) Routes() []router.Route {
+	tags := []string{"variants"}
+	return []router.Route{
+		{Name: "variants.list", Method: http.MethodGet, Path: "/products/{id}/variants", Handler: h.GetAll, Doc: router.Doc{
+			Summary: "List variants of a product",
+			Tags:    tags,
+			Responses: map[int]interface{}{
+				http.StatusOK:          []model.Variant{},
+				http.StatusNotModified: nil,
+				http.StatusBadRequest:  router.ErrorResponse{},
+				http.StatusNotFound:    router.ErrorResponse{},
+			},
+		}},
+		{Name: "variants.create", Method: http.MethodPost, Path: "/products/{id}/variants", Handler: h.Create, Doc: router.Doc{
+			Summary: "Create a variant",
+			Tags:    tags,
+			Request: model.VariantInput{},
+			Responses: map[int]interface{}{
+				http.StatusCreated:               model.Variant{},
+				http.StatusBadRequest:            ValidationErrorResponse{},
+				http.StatusNotFound:              router.ErrorResponse{},
+				http.StatusConflict:              router.ErrorResponse{},
+				http.StatusRequestEntityTooLarge: router.ErrorResponse{},
+				http.StatusUnsupportedMediaType:  router.ErrorResponse{},
+			},
+		}},
+		{Name: "variants.get", Method: http.MethodGet, Path: "/products/{id}/variants/{variant_id}", Handler: h.GetByID, Doc: router.Doc{
+			Summary: "Get a variant",
+			Tags:    tags,
+			Responses: map[int]interface{}{
+				http.StatusOK:          model.Variant{},
+				http.StatusNotModified: nil,
+				http.StatusBadRequest:  router.ErrorResponse{},
+				http.StatusNotFound:    router.ErrorResponse{},
+			},
+		}},
+		{Name: "variants.update", Method: http.MethodPut, Path: "/products/{id}/variants/{variant_id}", Handler: h.Update, Doc: router.Doc{
+			Summary: "Update a variant",
+			Tags:    tags,
+			Request: model.VariantInput{},
+			Responses: map[int]interface{}{
+				http.StatusOK:                    model.Variant{},
+				http.StatusBadRequest:            ValidationErrorResponse{},
+				http.StatusNotFound:              router.ErrorResponse{},
+				http.StatusConflict:              router.ErrorResponse{},
+				http.StatusRequestEntityTooLarge: router.ErrorResponse{},
+				http.StatusUnsupportedMediaType:  router.ErrorResponse{},
+			},
+		}},
+		{Name: "variants.delete", Method: http.MethodDelete, Path: "/products/{id}/variants/{variant_id}", Handler: h.Delete, Doc: router.Doc{
+			Summary: "Delete a variant",
+			Tags:    tags,
+			Responses: map[int]interface{}{
+				http.StatusOK:         map[string]string{},
+				http.StatusBadRequest: router.ErrorResponse{},
+				http.StatusNotFound:   router.ErrorResponse{},
+			},
+		}},
+	}
+}
+
+// variantPathIDs membaca {id} produk dan {variant_id} dari path
+func variantPathIDs(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	productID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, "Invalid product ID")
+		return 0, 0, false
+	}
+	if r.PathValue("variant_id") == "" {
+		return productID, 0, true
+	}
+	variantID, err := strconv.Atoi(r.PathValue("variant_id"))
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, "Invalid variant ID")
+		return 0, 0, false
+	}
+	return productID, variantID, true
+}
+
+// GetAll - GET /api/v2/products/{id}/variants
+func (h *variantHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	productID, _, ok := variantPathIDs(w, r)
+	if !ok {
+		return
+	}
+
+	variants, err := h.service.GetByProduct(productID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var lastModified time.Time
+	for _, item := range variants {
+		if item.UpdatedAt.After(lastModified) {
+			lastModified = item.UpdatedAt
+		}
+	}
+
+	writeConditionalJSON(w, r, variants, lastModified)
+}
+
+// Create - POST /api/v2/products/{id}/variants
+func (h *variantHandler) Create(w http.ResponseWriter, r *http.Request) {
+	productID, _, ok := variantPathIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var input model.VariantInput
+	if err := decodeJSON(w, r, &input); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	variant, err := h.service.Create(productID, input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(variant)
+}
+
+// GetByID - GET /api/v2/products/{id}/variants/{variant_id}
+func (h *variantHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	productID, variantID, ok := variantPathIDs(w, r)
+	if !ok {
+		return
+	}
+
+	variant, err := h.service.GetByID(productID, variantID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeConditionalJSON(w, r, variant, variant.UpdatedAt)
+}
+
+// Update - PUT /api/v2/products/{id}/variants/{variant_id}
+func (h *variantHandler) Update(w http.ResponseWriter, r *http.Request) {
+	productID, variantID, ok := variantPathIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var input model.VariantInput
+	if err := decodeJSON(w, r, &input); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	variant, err := h.service.Update(productID, variantID, input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(variant)
+}
+
+// Delete - DELETE /api/v2/products/{id}/variants/{variant_id}
+func (h *variantHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	productID, variantID, ok := variantPathIDs(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(productID, variantID); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Variant deleted successfully",
+	})
+}
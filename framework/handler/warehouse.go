@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"encoding/json"
+	"go-boot-category-api/framework/router"
+	"go-boot-category-api/model"
+	"go-boot-category-api/service"
+	"net/http"
+	"strconv"
+)
+
+type warehouseHandler struct {
+	service service.Warehouse
+}
+
+func NewWarehouseHandler(service service.Warehouse) *warehouseHandler {
+	return &warehouseHandler{service: service}
+}
+
+// Routes - daftar endpoint /warehouses
+func (h *warehouseHandler) Routes() []router.Route {
+	tags := []string{"warehouses"}
+	return []router.Route{
+		{Name: "warehouses.list", Method: http.MethodGet, Path: "/warehouses", Handler: h.GetAll, Doc: router.Doc{
+			Summary: "List warehouses",
+			Tags:    tags,
+			Responses: map[int]interface{}{
+				http.StatusOK: []model.Warehouse{},
+			},
+		}},
+		{Name: "warehouses.create", Method: http.MethodPost, Path: "/warehouses", Handler: h.Create, Doc: router.Doc{
+			Summary: "Create a warehouse",
+			Tags:    tags,
+			Request: model.WarehouseInput{},
+			Responses: map[int]interface{}{
+				http.StatusCreated:               model.Warehouse{},
+				http.StatusBadRequest:            ValidationErrorResponse{},
+				http.StatusConflict:              router.ErrorResponse{},
+				http.StatusRequestEntityTooLarge: router.ErrorResponse{},
+				http.StatusUnsupportedMediaType:  router.ErrorResponse{},
+			},
+		}},
+		{Name: "warehouses.get", Method: http.MethodGet, Path: "/warehouses/{id}", Handler: h.GetByID, Doc: router.Doc{
+			Summary: "Get a warehouse by ID",
+			Tags:    tags,
+			Responses: map[int]interface{}{
+				http.StatusOK:         model.Warehouse{},
+				http.StatusBadRequest: router.ErrorResponse{},
+				http.StatusNotFound:   router.ErrorResponse{},
+			},
+		}},
+	}
+}
+
+// GetAll - GET /api/v2/warehouses
+func (h *warehouseHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	warehouses, err := h.service.GetAll()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(warehouses)
+}
+
+// Create - POST /api/v2/warehouses
+func (h *warehouseHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var input model.WarehouseInput
+	if err := decodeJSON(w, r, &input); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	warehouse, err := h.service.Create(input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(warehouse)
+}
+
+// GetByID - GET /api/v2/warehouses/{id}
+func (h *warehouseHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		router.WriteError(w, http.StatusBadRequest, "Invalid warehouse ID")
+		return
+	}
+
+	warehouse, err := h.service.GetByID(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(warehouse)
+}
@@ -0,0 +1,14 @@
+package middleware
+
+import "net/http"
+
+// CacheControl menambahkan header Cache-Control pada response GET/HEAD.
+// Method lain (POST/PUT/DELETE) tidak diberi header agar tidak di-cache.
+func CacheControl(value string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if value != "" && (r.Method == http.MethodGet || r.Method == http.MethodHead) {
+			w.Header().Set("Cache-Control", value)
+		}
+		next(w, r)
+	}
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCORSConfigValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  CORSConfig
+		want error
+	}{
+		{"wildcard without credentials", CORSConfig{AllowedOrigins: []string{"*"}}, nil},
+		{"explicit origin with credentials", CORSConfig{AllowedOrigins: []string{"https://app.example.com"}, AllowCredentials: true}, nil},
+		{"subdomain wildcard with credentials", CORSConfig{AllowedOrigins: []string{"https://*.example.com"}, AllowCredentials: true}, nil},
+		{"wildcard with credentials", CORSConfig{AllowedOrigins: []string{"https://app.example.com", "*"}, AllowCredentials: true}, ErrCORSCredentialsWildcard},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cfg.Validate(); !errors.Is(err, tt.want) {
+				t.Errorf("Validate() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCORSNeverReflectsOriginWithCredentialsForWildcard(t *testing.T) {
+	handler := CORS(CORSConfig{AllowedOrigins: []string{"*"}, AllowCredentials: true},
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	r := httptest.NewRequest(http.MethodGet, "/api/products", nil)
+	r.Header.Set("Origin", "https://evil.example")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	if got := w.Header().Get("Access-Control-Allow-Credentials"); got != "" {
+		t.Errorf("Access-Control-Allow-Credentials = %q, want empty", got)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Errorf("Access-Control-Allow-Origin = %q, want *", got)
+	}
+}
@@ -0,0 +1,171 @@
+package middleware
+
+import (
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimitRule mengatur limit untuk path prefix dan method tertentu.
+// Method kosong berarti berlaku untuk semua method.
+type RateLimitRule struct {
+	Method     string
+	PathPrefix string
+	Limit      Limit
+}
+
+// RateLimitConfig adalah konfigurasi middleware RateLimit.
+type RateLimitConfig struct {
+	Store          RateLimitStore
+	Default        Limit
+	Rules          []RateLimitRule
+	TrustedProxies []*net.IPNet
+	APIKeyHeader   string
+	// APIKeys adalah API key yang valid. Hanya key di daftar ini yang
+	// mendapat bucket sendiri; key lain diabaikan supaya client tidak bisa
+	// lolos dari limit dengan mengganti-ganti key palsu.
+	APIKeys []string
+}
+
+// RateLimit membatasi request per client dengan token bucket. Client
+// diidentifikasi dari API key yang valid, atau IP address jika tidak ada. Route dengan limit kosong tidak dibatasi dan tidak diberi
+// header RateLimit-*.
+func RateLimit(cfg RateLimitConfig, next http.Handler) http.Handler {
+	if cfg.APIKeyHeader == "" {
+		cfg.APIKeyHeader = "X-API-Key"
+	}
+	apiKeys := make(map[string]bool, len(cfg.APIKeys))
+	for _, key := range cfg.APIKeys {
+		apiKeys[key] = true
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit, scope := cfg.limitFor(r)
+		if limit.Unlimited() {
+			next.ServeHTTP(w, r)
+			return
+		}
+		key := scope + "|" + cfg.clientKey(r, apiKeys)
+
+		result, err := cfg.Store.Take(key, limit)
+		if err != nil {
+			// Store bermasalah: jangan blokir semua traffic
+			log.Printf("ratelimit: store error: %v", err)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+		w.Header().Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		w.Header().Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.ResetAfter)))
+
+		if !result.Allowed {
+			w.Header().Set("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+			http.Error(w, "Too many requests", http.StatusTooManyRequests)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// limitFor memilih rule dengan prefix terpanjang yang cocok. Rule dengan
+// method spesifik menang atas rule tanpa method untuk prefix yang sama.
+func (cfg RateLimitConfig) limitFor(r *http.Request) (Limit, string) {
+	best := -1
+	for i, rule := range cfg.Rules {
+		if rule.Method != "" && rule.Method != r.Method {
+			continue
+		}
+		if !strings.HasPrefix(r.URL.Path, rule.PathPrefix) {
+			continue
+		}
+		if best == -1 || len(rule.PathPrefix) > len(cfg.Rules[best].PathPrefix) ||
+			(len(rule.PathPrefix) == len(cfg.Rules[best].PathPrefix) && rule.Method != "") {
+			best = i
+		}
+	}
+	if best == -1 {
+		return cfg.Default, "default"
+	}
+	rule := cfg.Rules[best]
+	return rule.Limit, rule.Method + " " + rule.PathPrefix
+}
+
+func (cfg RateLimitConfig) clientKey(r *http.Request, apiKeys map[string]bool) string {
+	if apiKey := r.Header.Get(cfg.APIKeyHeader); apiKeys[apiKey] {
+		return "key:" + apiKey
+	}
+	return "ip:" + ClientIP(r, cfg.TrustedProxies)
+}
+
+// ClientIP mengambil IP client. X-Forwarded-For hanya dipercaya jika
+// request datang dari trusted proxy; daftar IP dibaca dari kanan dan IP
+// pertama yang bukan trusted proxy dianggap sebagai client.
+func ClientIP(r *http.Request, trusted []*net.IPNet) string {
+	remote, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		remote = r.RemoteAddr
+	}
+	if !isTrusted(remote, trusted) {
+		return remote
+	}
+
+	forwarded := strings.Split(r.Header.Get("X-Forwarded-For"), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		ip := strings.TrimSpace(forwarded[i])
+		if ip == "" {
+			continue
+		}
+		if !isTrusted(ip, trusted) {
+			return ip
+		}
+	}
+	return remote
+}
+
+func isTrusted(ip string, trusted []*net.IPNet) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, network := range trusted {
+		if network.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseCIDRs mengubah daftar CIDR / IP dipisah koma menjadi []*net.IPNet.
+// IP tunggal dianggap /32 (IPv4) atau /128 (IPv6).
+func ParseCIDRs(value string) ([]*net.IPNet, error) {
+	var networks []*net.IPNet
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if !strings.Contains(item, "/") {
+			if ip := net.ParseIP(item); ip != nil && ip.To4() != nil {
+				item += "/32"
+			} else {
+				item += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(item)
+		if err != nil {
+			return nil, err
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
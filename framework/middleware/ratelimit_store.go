@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Limit adalah konfigurasi token bucket: Rate token per detik dengan
+// kapasitas maksimum Burst token.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Unlimited cek apakah limit kosong, yaitu request tidak dibatasi.
+func (l Limit) Unlimited() bool {
+	return l.Rate <= 0 || l.Burst <= 0
+}
+
+// Result adalah hasil pengambilan satu token dari bucket.
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration
+	ResetAfter time.Duration
+}
+
+// RateLimitStore menyimpan state bucket per key. Implementasi shared
+// (misal Redis dengan script Lua) cukup memenuhi interface ini supaya
+// limit berlaku di semua instance.
+type RateLimitStore interface {
+	Take(key string, limit Limit) (Result, error)
+}
+
+type bucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+type memoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	idleTTL   time.Duration
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryStore membuat RateLimitStore in-memory. Bucket yang tidak dipakai
+// lebih lama dari idleTTL akan dibuang agar memori tidak terus bertambah.
+func NewMemoryStore(idleTTL time.Duration) RateLimitStore {
+	return &memoryStore{
+		buckets: make(map[string]*bucket),
+		idleTTL: idleTTL,
+		now:     time.Now,
+	}
+}
+
+func (s *memoryStore) Take(key string, limit Limit) (Result, error) {
+	if limit.Unlimited() {
+		return Result{Allowed: true, Limit: limit.Burst}, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), lastSeen: now}
+		s.buckets[key] = b
+	}
+
+	elapsed := now.Sub(b.lastSeen).Seconds()
+	b.tokens = math.Min(float64(limit.Burst), b.tokens+elapsed*limit.Rate)
+	b.lastSeen = now
+
+	result := Result{Limit: limit.Burst}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = secondsToDuration((1 - b.tokens) / limit.Rate)
+	}
+	result.Remaining = int(b.tokens)
+	result.ResetAfter = secondsToDuration((float64(limit.Burst) - b.tokens) / limit.Rate)
+
+	return result, nil
+}
+
+func (s *memoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < s.idleTTL {
+		return
+	}
+	for key, b := range s.buckets {
+		if now.Sub(b.lastSeen) > s.idleTTL {
+			delete(s.buckets, key)
+		}
+	}
+	s.lastSweep = now
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimitIgnoresUnknownAPIKeys(t *testing.T) {
+	handler := RateLimit(RateLimitConfig{
+		Store:   NewMemoryStore(time.Minute),
+		Default: Limit{Rate: 0.001, Burst: 1},
+		APIKeys: []string{"valid"},
+	}, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	tests := []struct {
+		name   string
+		remote string
+		apiKey string
+		want   int
+	}{
+		{"first request from IP", "192.0.2.1:1234", "", http.StatusOK},
+		{"random key falls back to IP", "192.0.2.1:1234", "random-1", http.StatusTooManyRequests},
+		{"another random key falls back to IP", "192.0.2.1:1234", "random-2", http.StatusTooManyRequests},
+		{"valid key has its own bucket", "192.0.2.1:1234", "valid", http.StatusOK},
+		{"valid key bucket is limited too", "192.0.2.1:1234", "valid", http.StatusTooManyRequests},
+		{"valid key bucket is shared across IPs", "192.0.2.2:1234", "valid", http.StatusTooManyRequests},
+		{"other IP has its own bucket", "192.0.2.2:1234", "", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/products", nil)
+			r.RemoteAddr = tt.remote
+			if tt.apiKey != "" {
+				r.Header.Set("X-API-Key", tt.apiKey)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimitUnlimitedRouteHasNoHeaders(t *testing.T) {
+	store := NewMemoryStore(time.Minute).(*memoryStore)
+	handler := RateLimit(RateLimitConfig{
+		Store:   store,
+		Default: Limit{Rate: 1, Burst: 1},
+		Rules:   []RateLimitRule{{PathPrefix: "/health", Limit: Limit{}}},
+	}, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	for i := 0; i < 3; i++ {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("request %d: status = %d, want 200", i, w.Code)
+		}
+		for _, header := range []string{"RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset"} {
+			if value := w.Header().Get(header); value != "" {
+				t.Errorf("request %d: %s = %q, want no header", i, header, value)
+			}
+		}
+	}
+	if len(store.buckets) != 0 {
+		t.Errorf("store has %d buckets, want 0 for unlimited route", len(store.buckets))
+	}
+}
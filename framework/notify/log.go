@@ -0,0 +1,19 @@
+package notify
+
+import (
+	"context"
+	"log"
+)
+
+type logNotifier struct{}
+
+// NewLog membuat Notifier yang hanya menulis notifikasi ke log, untuk
+// development atau jika belum ada channel lain.
+func NewLog() Notifier {
+	return logNotifier{}
+}
+
+func (logNotifier) Notify(ctx context.Context, msg Message) error {
+	log.Printf("notify: [%s] %s: %s", msg.Event, msg.Subject, msg.Text)
+	return nil
+}
@@ -0,0 +1,19 @@
+package notify
+
+import "context"
+
+// Message adalah satu notifikasi. Subject dan Text untuk dibaca manusia;
+// Data adalah detail terstruktur untuk penerima yang memprosesnya secara
+// otomatis, misal webhook.
+type Message struct {
+	Event   string      `json:"event"`
+	Subject string      `json:"subject"`
+	Text    string      `json:"text"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// Notifier mengirim notifikasi ke satu channel. Implementasi log, webhook,
+// dan SMTP bisa dipakai bergantian.
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
@@ -0,0 +1,127 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// SMTPConfig adalah konfigurasi pengiriman email. Username kosong berarti
+// tanpa AUTH, cocok untuk server lokal seperti MailHog atau Mailpit.
+// Timeout membatasi seluruh pengiriman, dari dial sampai QUIT; 0 berarti
+// defaultSMTPTimeout.
+type SMTPConfig struct {
+	Addr     string
+	From     string
+	To       []string
+	Username string
+	Password string
+	Timeout  time.Duration
+}
+
+const defaultSMTPTimeout = 30 * time.Second
+
+type smtpNotifier struct {
+	config SMTPConfig
+}
+
+// NewSMTP membuat Notifier yang mengirim Message sebagai email plain text.
+func NewSMTP(config SMTPConfig) Notifier {
+	return &smtpNotifier{config: config}
+}
+
+func (n *smtpNotifier) Notify(ctx context.Context, msg Message) error {
+	host, _, err := net.SplitHostPort(n.config.Addr)
+	if err != nil {
+		return err
+	}
+
+	var body bytes.Buffer
+	fmt.Fprintf(&body, "From: %s\r\n", n.config.From)
+	fmt.Fprintf(&body, "To: %s\r\n", strings.Join(n.config.To, ", "))
+	fmt.Fprintf(&body, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&body, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	body.WriteString("MIME-Version: 1.0\r\n")
+	body.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	body.WriteString(strings.ReplaceAll(msg.Text, "\n", "\r\n"))
+	body.WriteString("\r\n")
+
+	timeout := n.config.Timeout
+	if timeout <= 0 {
+		timeout = defaultSMTPTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", n.config.Addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// net/smtp tidak menerima context; deadline koneksi membatasi setiap
+	// perintah, dan koneksi ditutup jika ctx dibatalkan lebih dulu
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	if err := n.send(conn, host, body.Bytes()); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+	return nil
+}
+
+// send menjalankan percakapan SMTP seperti smtp.SendMail di atas conn:
+// STARTTLS jika server mendukung, AUTH jika Username diisi, lalu satu
+// pesan ke semua penerima.
+func (n *smtpNotifier) send(conn net.Conn, host string, msg []byte) error {
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+	if n.config.Username != "" {
+		auth := smtp.PlainAuth("", n.config.Username, n.config.Password, host)
+		if err := client.Auth(auth); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(n.config.From); err != nil {
+		return err
+	}
+	for _, to := range n.config.To {
+		if err := client.Rcpt(to); err != nil {
+			return err
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
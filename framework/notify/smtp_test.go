@@ -0,0 +1,58 @@
+package notify
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+// silentServer menerima koneksi tapi tidak pernah mengirim greeting SMTP
+func silentServer(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			// baca sampai client menutup koneksi
+			go io.Copy(io.Discard, conn)
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func TestSMTPTimeout(t *testing.T) {
+	n := NewSMTP(SMTPConfig{Addr: silentServer(t), From: "a@localhost", To: []string{"b@localhost"}, Timeout: 100 * time.Millisecond})
+
+	start := time.Now()
+	err := n.Notify(context.Background(), Message{Subject: "s", Text: "t"})
+	if err == nil {
+		t.Fatal("Notify() = nil, want timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Notify() took %v, want it bounded by Timeout", elapsed)
+	}
+}
+
+func TestSMTPCanceled(t *testing.T) {
+	n := NewSMTP(SMTPConfig{Addr: silentServer(t), From: "a@localhost", To: []string{"b@localhost"}, Timeout: time.Minute})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+	start := time.Now()
+	err := n.Notify(ctx, Message{Subject: "s", Text: "t"})
+	if err != context.Canceled {
+		t.Errorf("Notify() error = %v, want context.Canceled", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Notify() took %v after cancel", elapsed)
+	}
+}
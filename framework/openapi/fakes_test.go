@@ -0,0 +1,319 @@
+package openapi_test
+
+import (
+	"context"
+	"go-boot-category-api/model"
+	"reflect"
+	"time"
+)
+
+var moneyType = reflect.TypeOf(model.Money{})
+
+// sample membuat T dengan semua field terisi, termasuk slice, map, dan
+// pointer, supaya setiap field ikut dicek terhadap schema-nya
+func sample[T any]() *T {
+	v := new(T)
+	fill(reflect.ValueOf(v).Elem(), 0)
+	return v
+}
+
+func fill(v reflect.Value, depth int) {
+	if depth > 8 {
+		return
+	}
+	switch {
+	case v.Type() == moneyType:
+		v.Set(reflect.ValueOf(model.Money{Amount: 12345, Currency: "IDR"}))
+	case v.Type() == reflect.TypeOf(time.Time{}):
+		v.Set(reflect.ValueOf(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)))
+	case v.Kind() == reflect.String:
+		v.SetString("x")
+	case v.Kind() == reflect.Bool:
+		v.SetBool(true)
+	case v.Kind() >= reflect.Int && v.Kind() <= reflect.Int64:
+		v.SetInt(1)
+	case v.Kind() >= reflect.Uint && v.Kind() <= reflect.Uint64:
+		v.SetUint(1)
+	case v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64:
+		v.SetFloat(1.5)
+	case v.Kind() == reflect.Pointer:
+		p := reflect.New(v.Type().Elem())
+		fill(p.Elem(), depth+1)
+		v.Set(p)
+	case v.Kind() == reflect.Slice:
+		s := reflect.MakeSlice(v.Type(), 1, 1)
+		fill(s.Index(0), depth+1)
+		v.Set(s)
+	case v.Kind() == reflect.Map:
+		m := reflect.MakeMap(v.Type())
+		key := reflect.New(v.Type().Key()).Elem()
+		fill(key, depth+1)
+		value := reflect.New(v.Type().Elem()).Elem()
+		fill(value, depth+1)
+		m.SetMapIndex(key, value)
+		v.Set(m)
+	case v.Kind() == reflect.Interface && v.NumMethod() == 0:
+		v.Set(reflect.ValueOf("x"))
+	case v.Kind() == reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				fill(v.Field(i), depth+1)
+			}
+		}
+	}
+}
+
+// fakeServices mengembalikan sample untuk setiap pemanggilan, atau err
+// jika diisi
+type fakeServices struct {
+	err error
+}
+
+func result[T any](f *fakeServices) (*T, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return sample[T](), nil
+}
+
+func list[T any](f *fakeServices) ([]T, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return *sample[[]T](), nil
+}
+
+type fakeProducts struct{ *fakeServices }
+
+func (f fakeProducts) GetAll(filter model.ProductFilter) ([]model.Product, error) {
+	return list[model.Product](f.fakeServices)
+}
+func (f fakeProducts) GetByID(id int) (*model.Product, error) {
+	return result[model.Product](f.fakeServices)
+}
+func (f fakeProducts) GetByCategory(categoryID int, filter model.ProductFilter, page model.Pagination) (*model.ProductPage, error) {
+	return result[model.ProductPage](f.fakeServices)
+}
+func (f fakeProducts) GetByIDWithVariants(id int) (*model.Product, error) {
+	return result[model.Product](f.fakeServices)
+}
+func (f fakeProducts) ExpandCategory(products []model.Product) error  { return f.err }
+func (f fakeProducts) ExpandLocations(products []model.Product) error { return f.err }
+func (f fakeProducts) Create(input model.ProductInput, actor string) (*model.Product, error) {
+	return result[model.Product](f.fakeServices)
+}
+func (f fakeProducts) Update(id int, input model.ProductInput, actor string) (*model.Product, error) {
+	return result[model.Product](f.fakeServices)
+}
+func (f fakeProducts) Delete(id int) error { return f.err }
+func (f fakeProducts) GetBySlug(slug string) (*model.Product, error) {
+	return result[model.Product](f.fakeServices)
+}
+
+type fakeCategories struct{ *fakeServices }
+
+func (f fakeCategories) GetAll() ([]model.Category, error) {
+	return list[model.Category](f.fakeServices)
+}
+func (f fakeCategories) GetAllWithProducts() ([]model.Category, error) {
+	return list[model.Category](f.fakeServices)
+}
+func (f fakeCategories) GetByID(id int) (*model.Category, error) {
+	return result[model.Category](f.fakeServices)
+}
+func (f fakeCategories) GetByIDWithProducts(id int) (*model.Category, error) {
+	return result[model.Category](f.fakeServices)
+}
+func (f fakeCategories) Create(input model.CategoryInput) (*model.Category, error) {
+	return result[model.Category](f.fakeServices)
+}
+func (f fakeCategories) Update(id int, input model.CategoryInput) (*model.Category, error) {
+	return result[model.Category](f.fakeServices)
+}
+func (f fakeCategories) Delete(id int) error { return f.err }
+func (f fakeCategories) GetBySlug(slug string) (*model.Category, error) {
+	return result[model.Category](f.fakeServices)
+}
+
+type fakeAttributes struct{ *fakeServices }
+
+func (f fakeAttributes) GetByCategory(categoryID int) ([]model.AttributeDefinition, error) {
+	return list[model.AttributeDefinition](f.fakeServices)
+}
+func (f fakeAttributes) Create(categoryID int, input model.AttributeDefinitionInput) (*model.AttributeDefinition, error) {
+	return result[model.AttributeDefinition](f.fakeServices)
+}
+func (f fakeAttributes) Delete(categoryID, id int) error { return f.err }
+
+type fakeVariants struct{ *fakeServices }
+
+func (f fakeVariants) GetByProduct(productID int) ([]model.Variant, error) {
+	return list[model.Variant](f.fakeServices)
+}
+func (f fakeVariants) GetByID(productID, id int) (*model.Variant, error) {
+	return result[model.Variant](f.fakeServices)
+}
+func (f fakeVariants) Create(productID int, input model.VariantInput) (*model.Variant, error) {
+	return result[model.Variant](f.fakeServices)
+}
+func (f fakeVariants) Update(productID, id int, input model.VariantInput) (*model.Variant, error) {
+	return result[model.Variant](f.fakeServices)
+}
+func (f fakeVariants) Delete(productID, id int) error { return f.err }
+
+type fakeTags struct{ *fakeServices }
+
+func (f fakeTags) GetAll() ([]model.Tag, error) { return list[model.Tag](f.fakeServices) }
+func (f fakeTags) Create(input model.TagInput) (*model.Tag, error) {
+	return result[model.Tag](f.fakeServices)
+}
+func (f fakeTags) Delete(id int) error { return f.err }
+func (f fakeTags) AddToProduct(productID int, input model.ProductTagsInput) (*model.Product, error) {
+	return result[model.Product](f.fakeServices)
+}
+func (f fakeTags) RemoveFromProduct(productID int, input model.ProductTagsInput) (*model.Product, error) {
+	return result[model.Product](f.fakeServices)
+}
+
+type fakePrices struct{ *fakeServices }
+
+func (f fakePrices) GetSchedule(productID int) (*model.PriceSchedule, error) {
+	return result[model.PriceSchedule](f.fakeServices)
+}
+func (f fakePrices) At(productID int, at time.Time) (*model.PriceChange, error) {
+	return result[model.PriceChange](f.fakeServices)
+}
+func (f fakePrices) Schedule(productID int, input model.ScheduledPriceInput, actor string) (*model.ScheduledPrice, error) {
+	return result[model.ScheduledPrice](f.fakeServices)
+}
+func (f fakePrices) Cancel(productID int, id int64) error                     { return f.err }
+func (f fakePrices) ApplyDue() (int, error)                                   { return 1, f.err }
+func (f fakePrices) RunScheduler(ctx context.Context, interval time.Duration) {}
+
+type fakeWarehouses struct{ *fakeServices }
+
+func (f fakeWarehouses) GetAll() ([]model.Warehouse, error) {
+	return list[model.Warehouse](f.fakeServices)
+}
+func (f fakeWarehouses) GetByID(id int) (*model.Warehouse, error) {
+	return result[model.Warehouse](f.fakeServices)
+}
+func (f fakeWarehouses) Create(input model.WarehouseInput) (*model.Warehouse, error) {
+	return result[model.Warehouse](f.fakeServices)
+}
+
+type fakeStock struct{ *fakeServices }
+
+func (f fakeStock) Transfer(input model.StockTransferInput, actor string) (*model.StockTransfer, error) {
+	return result[model.StockTransfer](f.fakeServices)
+}
+func (f fakeStock) GetTransfer(id int64) (*model.StockTransfer, error) {
+	return result[model.StockTransfer](f.fakeServices)
+}
+func (f fakeStock) Adjust(productID int, input model.StockAdjustmentInput, actor string) (*model.LedgerEntry, error) {
+	return result[model.LedgerEntry](f.fakeServices)
+}
+
+type fakeReservations struct{ *fakeServices }
+
+func (f fakeReservations) GetByID(id int64) (*model.Reservation, error) {
+	return result[model.Reservation](f.fakeServices)
+}
+func (f fakeReservations) Create(input model.ReservationInput, actor string) (*model.Reservation, error) {
+	return result[model.Reservation](f.fakeServices)
+}
+func (f fakeReservations) Confirm(id int64, actor string) (*model.Reservation, error) {
+	return result[model.Reservation](f.fakeServices)
+}
+func (f fakeReservations) Release(id int64) (*model.Reservation, error) {
+	return result[model.Reservation](f.fakeServices)
+}
+func (f fakeReservations) ExpireDue() (int, error)                               { return 1, f.err }
+func (f fakeReservations) RunReaper(ctx context.Context, interval time.Duration) {}
+
+type fakeLowStock struct{ *fakeServices }
+
+func (f fakeLowStock) GetAll() ([]model.LowStock, error)                      { return list[model.LowStock](f.fakeServices) }
+func (f fakeLowStock) Check(ctx context.Context) (int, error)                 { return 1, f.err }
+func (f fakeLowStock) RunMonitor(ctx context.Context, interval time.Duration) {}
+
+type fakeOrders struct{ *fakeServices }
+
+func (f fakeOrders) GetAll(status string, page model.Pagination) (*model.OrderPage, error) {
+	return result[model.OrderPage](f.fakeServices)
+}
+func (f fakeOrders) GetByID(id int64) (*model.Order, error) {
+	return result[model.Order](f.fakeServices)
+}
+func (f fakeOrders) Create(input model.OrderInput, actor string) (*model.Order, error) {
+	return result[model.Order](f.fakeServices)
+}
+func (f fakeOrders) Transition(id int64, input model.OrderStatusInput, actor string) (*model.Order, error) {
+	return result[model.Order](f.fakeServices)
+}
+
+type fakeSuppliers struct{ *fakeServices }
+
+func (f fakeSuppliers) GetAll() ([]model.Supplier, error) {
+	return list[model.Supplier](f.fakeServices)
+}
+func (f fakeSuppliers) GetByID(id int) (*model.Supplier, error) {
+	return result[model.Supplier](f.fakeServices)
+}
+func (f fakeSuppliers) Create(input model.SupplierInput) (*model.Supplier, error) {
+	return result[model.Supplier](f.fakeServices)
+}
+
+type fakePurchaseOrders struct{ *fakeServices }
+
+func (f fakePurchaseOrders) GetAll(status string, page model.Pagination) (*model.PurchaseOrderPage, error) {
+	return result[model.PurchaseOrderPage](f.fakeServices)
+}
+func (f fakePurchaseOrders) GetByID(id int64) (*model.PurchaseOrder, error) {
+	return result[model.PurchaseOrder](f.fakeServices)
+}
+func (f fakePurchaseOrders) Create(input model.PurchaseOrderInput, actor string) (*model.PurchaseOrder, error) {
+	return result[model.PurchaseOrder](f.fakeServices)
+}
+func (f fakePurchaseOrders) Receive(id int64, input model.PurchaseReceiptInput, actor string) (*model.PurchaseReceipt, error) {
+	return result[model.PurchaseReceipt](f.fakeServices)
+}
+func (f fakePurchaseOrders) Receipts(id int64) ([]model.PurchaseReceipt, error) {
+	return list[model.PurchaseReceipt](f.fakeServices)
+}
+
+type fakeStocktakes struct{ *fakeServices }
+
+func (f fakeStocktakes) GetAll(status string, page model.Pagination) (*model.StocktakePage, error) {
+	return result[model.StocktakePage](f.fakeServices)
+}
+func (f fakeStocktakes) GetByID(id int64) (*model.Stocktake, error) {
+	return result[model.Stocktake](f.fakeServices)
+}
+func (f fakeStocktakes) Create(input model.StocktakeInput, actor string) (*model.Stocktake, error) {
+	return result[model.Stocktake](f.fakeServices)
+}
+func (f fakeStocktakes) Count(id int64, input model.StocktakeCountInput, actor string) (*model.Stocktake, error) {
+	return result[model.Stocktake](f.fakeServices)
+}
+func (f fakeStocktakes) Approve(id int64, actor string) (*model.Stocktake, error) {
+	return result[model.Stocktake](f.fakeServices)
+}
+func (f fakeStocktakes) Cancel(id int64, actor string) (*model.Stocktake, error) {
+	return result[model.Stocktake](f.fakeServices)
+}
+func (f fakeStocktakes) Variance(id int64) (*model.StocktakeVariance, error) {
+	return result[model.StocktakeVariance](f.fakeServices)
+}
+
+type fakeReports struct{ *fakeServices }
+
+func (f fakeReports) ProductMargins(from, to time.Time) (*model.ProductMarginReport, error) {
+	return result[model.ProductMarginReport](f.fakeServices)
+}
+func (f fakeReports) CategoryMargins(from, to time.Time) (*model.CategoryMarginReport, error) {
+	return result[model.CategoryMarginReport](f.fakeServices)
+}
+func (f fakeReports) InventoryValuation(at time.Time) (*model.InventoryValuation, error) {
+	return result[model.InventoryValuation](f.fakeServices)
+}
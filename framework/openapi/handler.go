@@ -0,0 +1,149 @@
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"go-boot-category-api/framework/router"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+//go:embed ui.html
+var uiHTML []byte
+
+// swaggerUI adalah aset swagger-ui-dist 5.18.2 (lisensi Apache 2.0), diambil
+// dari github.com/swaggo/files/v2 v2.0.2. Aset disajikan sendiri supaya
+// halaman docs tidak memuat script dari CDN pihak ketiga.
+//
+//go:embed swagger-ui/swagger-ui-bundle.js
+var swaggerUIScript []byte
+
+//go:embed swagger-ui/swagger-ui.css
+var swaggerUIStyle []byte
+
+// RouteSource adalah sumber daftar route, biasanya *router.Router.
+type RouteSource interface {
+	Routes() []router.Route
+}
+
+type specHandler struct {
+	info   Info
+	source RouteSource
+	once   sync.Once
+	spec   []byte
+	err    error
+}
+
+// NewHandler membuat resource yang melayani GET /openapi.json dan GET /docs
+// beserta aset swagger-ui-nya.
+// Spec dibuat sekali saat pertama kali diminta, sehingga route yang
+// di-mount setelah handler ini tetap ikut terdokumentasi.
+func NewHandler(info Info, source RouteSource) *specHandler {
+	return &specHandler{info: info, source: source}
+}
+
+func (h *specHandler) Routes() []router.Route {
+	return []router.Route{
+		{Name: "openapi.spec", Method: http.MethodGet, Path: "/openapi.json", Handler: h.Spec, Doc: router.Doc{
+			Summary:   "OpenAPI 3.1 document",
+			Tags:      []string{"docs"},
+			Responses: map[int]interface{}{http.StatusOK: map[string]interface{}{}},
+		}},
+		{Name: "openapi.ui", Method: http.MethodGet, Path: "/docs", Handler: h.UI, Doc: router.Doc{
+			Summary:   "API documentation UI",
+			Tags:      []string{"docs"},
+			Responses: map[int]interface{}{http.StatusOK: ""},
+		}},
+		{Name: "openapi.ui.script", Method: http.MethodGet, Path: "/docs/swagger-ui-bundle.js", Handler: asset("text/javascript; charset=utf-8", swaggerUIScript), Doc: router.Doc{
+			Summary:   "Swagger UI script",
+			Tags:      []string{"docs"},
+			Responses: map[int]interface{}{http.StatusOK: ""},
+		}},
+		{Name: "openapi.ui.style", Method: http.MethodGet, Path: "/docs/swagger-ui.css", Handler: asset("text/css; charset=utf-8", swaggerUIStyle), Doc: router.Doc{
+			Summary:   "Swagger UI stylesheet",
+			Tags:      []string{"docs"},
+			Responses: map[int]interface{}{http.StatusOK: ""},
+		}},
+	}
+}
+
+// Spec - GET /openapi.json
+func (h *specHandler) Spec(w http.ResponseWriter, r *http.Request) {
+	h.once.Do(func() {
+		h.spec, h.err = json.MarshalIndent(Build(h.info, h.source.Routes()), "", "  ")
+	})
+	if h.err != nil {
+		http.Error(w, h.err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(h.spec)
+}
+
+// UI - GET /docs
+func (h *specHandler) UI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(uiHTML)
+}
+
+// asset - GET /docs/swagger-ui-bundle.js dan GET /docs/swagger-ui.css
+func asset(contentType string, body []byte) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Cache-Control", "public, max-age=86400")
+		w.Write(body)
+	}
+}
+
+// LogDrift adalah middleware router yang mencatat response dengan status
+// code yang tidak ada di dokumentasi route. Aktifkan di development/CI untuk
+// mendeteksi handler yang perilakunya sudah berbeda dari spec.
+func LogDrift(route router.Route, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next(rec, r)
+
+		if documented(route, rec.status) {
+			return
+		}
+		log.Printf("openapi drift: %s %s responded %d, documented: %v",
+			route.Method, route.Path, rec.status, documentedStatuses(route))
+	}
+}
+
+func documented(route router.Route, status int) bool {
+	if _, ok := route.Doc.Responses[status]; ok {
+		return true
+	}
+	// ditambahkan otomatis oleh Build / middleware
+	return status == http.StatusTooManyRequests || status == http.StatusInternalServerError
+}
+
+func documentedStatuses(route router.Route) []string {
+	var statuses []string
+	for status := range route.Doc.Responses {
+		statuses = append(statuses, strconv.Itoa(status))
+	}
+	return statuses
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (rec *statusRecorder) WriteHeader(status int) {
+	if !rec.wroteHeader {
+		rec.status = status
+		rec.wroteHeader = true
+	}
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *statusRecorder) Unwrap() http.ResponseWriter {
+	return rec.ResponseWriter
+}
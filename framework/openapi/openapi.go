@@ -0,0 +1,169 @@
+package openapi
+
+import (
+	"fmt"
+	"go-boot-category-api/framework/router"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Document adalah dokumen OpenAPI 3.1.
+type Document struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       Info                             `json:"info"`
+	Paths      map[string]map[string]*Operation `json:"paths"`
+	Components Components                       `json:"components"`
+}
+
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+type Operation struct {
+	OperationID string               `json:"operationId,omitempty"`
+	Summary     string               `json:"summary,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Deprecated  bool                 `json:"deprecated,omitempty"`
+	Parameters  []Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+var pathParam = regexp.MustCompile(`\{([^}.$]+)(\.\.\.)?\}`)
+
+type builder struct {
+	doc *Document
+}
+
+// Build membuat dokumen OpenAPI dari route yang terdaftar di router.
+// Route tanpa Doc.Summary dianggap belum didokumentasikan dan dilewati;
+// gunakan Verify untuk memastikan semua route terdokumentasi.
+func Build(info Info, routes []router.Route) *Document {
+	b := &builder{doc: &Document{
+		OpenAPI:    "3.1.0",
+		Info:       info,
+		Paths:      make(map[string]map[string]*Operation),
+		Components: Components{Schemas: make(map[string]*Schema)},
+	}}
+
+	for _, route := range routes {
+		if route.Doc.Summary == "" {
+			continue
+		}
+		path := strings.TrimSuffix(pathParam.ReplaceAllString(route.Path, "{$1}"), "{$}")
+		if b.doc.Paths[path] == nil {
+			b.doc.Paths[path] = make(map[string]*Operation)
+		}
+		b.doc.Paths[path][strings.ToLower(route.Method)] = b.operation(route)
+	}
+
+	return b.doc
+}
+
+func (b *builder) operation(route router.Route) *Operation {
+	op := &Operation{
+		OperationID: operationID(route),
+		Summary:     route.Doc.Summary,
+		Tags:        route.Doc.Tags,
+		Deprecated:  route.Deprecated,
+		Responses:   make(map[string]*Response),
+	}
+
+	for _, match := range pathParam.FindAllStringSubmatch(route.Path, -1) {
+		schema := &Schema{Type: "string"}
+		if match[1] == "id" || strings.HasSuffix(match[1], "_id") {
+			schema = &Schema{Type: "integer"}
+		}
+		op.Parameters = append(op.Parameters, Parameter{Name: match[1], In: "path", Required: true, Schema: schema})
+	}
+	for _, q := range route.Doc.Query {
+		typ := q.Type
+		if typ == "" {
+			typ = "string"
+		}
+		op.Parameters = append(op.Parameters, Parameter{Name: q.Name, In: "query", Description: q.Description, Schema: &Schema{Type: typ}})
+	}
+
+	if route.Doc.Request != nil {
+		op.RequestBody = &RequestBody{Required: true, Content: b.content(route.Doc.Request)}
+	}
+
+	for status, body := range route.Doc.Responses {
+		resp := &Response{Description: http.StatusText(status)}
+		if body != nil {
+			resp.Content = b.content(body)
+		}
+		op.Responses[strconv.Itoa(status)] = resp
+	}
+
+	// Response dari router dan middleware berlaku untuk semua route
+	if len(op.Parameters) > 0 && op.Responses["404"] == nil {
+		op.Responses["404"] = &Response{Description: http.StatusText(http.StatusNotFound), Content: b.content(router.ErrorResponse{})}
+	}
+	op.Responses["429"] = &Response{Description: http.StatusText(http.StatusTooManyRequests), Content: b.content("")}
+	if op.Responses["500"] == nil {
+		op.Responses["500"] = &Response{Description: http.StatusText(http.StatusInternalServerError), Content: b.content(router.ErrorResponse{})}
+	}
+
+	return op
+}
+
+func (b *builder) content(sample interface{}) map[string]MediaType {
+	if _, ok := sample.(string); ok {
+		return map[string]MediaType{"text/plain": {Schema: &Schema{Type: "string"}}}
+	}
+	return map[string]MediaType{"application/json": {Schema: b.schemaFor(reflect.TypeOf(sample))}}
+}
+
+func operationID(route router.Route) string {
+	id := route.Name
+	if route.Version != "" {
+		id = strings.Trim(strings.ReplaceAll(route.Version, "/", "."), ".") + "." + id
+	}
+	return id
+}
+
+// Verify memastikan setiap route terdokumentasi dan setiap status code yang
+// didokumentasikan masuk akal, supaya spec tidak tertinggal dari handler.
+func Verify(routes []router.Route) error {
+	var missing []string
+	for _, route := range routes {
+		if route.Doc.Summary == "" || len(route.Doc.Responses) == 0 {
+			missing = append(missing, route.Method+" "+route.Path)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("routes without OpenAPI documentation: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,321 @@
+package openapi_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go-boot-category-api/framework/handler"
+	"go-boot-category-api/framework/openapi"
+	"go-boot-category-api/framework/router"
+	"go-boot-category-api/service"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var pathParam = regexp.MustCompile(`\{([^}.$]+)(\.\.\.)?\}`)
+
+// newRouter membuat router dengan mounting yang sama seperti main.go, di
+// atas service palsu
+func newRouter(fakes *fakeServices) *router.Router {
+	mux := router.New()
+	handler.Mount(mux, handler.Services{
+		Product:       fakeProducts{fakes},
+		Category:      fakeCategories{fakes},
+		Attribute:     fakeAttributes{fakes},
+		Variant:       fakeVariants{fakes},
+		Tag:           fakeTags{fakes},
+		Price:         fakePrices{fakes},
+		Warehouse:     fakeWarehouses{fakes},
+		Stock:         fakeStock{fakes},
+		Reservation:   fakeReservations{fakes},
+		LowStock:      fakeLowStock{fakes},
+		Order:         fakeOrders{fakes},
+		Supplier:      fakeSuppliers{fakes},
+		PurchaseOrder: fakePurchaseOrders{fakes},
+		Stocktake:     fakeStocktakes{fakes},
+		Report:        fakeReports{fakes},
+	},
+		router.Version{Prefix: "/api/v1", Deprecated: &router.Deprecation{Since: 1792281600, Successor: "/api/v2"}},
+		router.Version{Prefix: "/api", Deprecated: &router.Deprecation{Since: 1767225600, Successor: "/api/v2"}},
+	)
+	mux.Mount(openapi.NewHandler(openapi.Info{Title: "Category API", Version: "1.0"}, mux))
+	return mux
+}
+
+// fetchSpec mengambil spec dari GET /openapi.json router itu sendiri
+func fetchSpec(t *testing.T, mux *router.Router) *openapi.Document {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /openapi.json = %d", rec.Code)
+	}
+	var doc openapi.Document
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("decode spec: %v", err)
+	}
+	return &doc
+}
+
+// call adalah satu variasi request untuk sebuah route
+type call struct {
+	name  string
+	id    string
+	query string
+	err   error
+}
+
+// requestFor membuat request untuk route dengan semua path parameter
+// terisi; parameter ID diisi id, yang lain "x"
+func requestFor(route router.Route, id, query string) *http.Request {
+	path := pathParam.ReplaceAllStringFunc(route.Path, func(param string) string {
+		name := pathParam.FindStringSubmatch(param)[1]
+		if name == "id" || strings.HasSuffix(name, "_id") {
+			return id
+		}
+		return "x"
+	})
+	path = strings.TrimSuffix(path, "{$}")
+	if query != "" {
+		path += "?" + query
+	}
+
+	var body []byte
+	if route.Doc.Request != nil {
+		input := reflect.New(reflect.TypeOf(route.Doc.Request))
+		fill(input.Elem(), 0)
+		body, _ = json.Marshal(input.Interface())
+	}
+	r := httptest.NewRequest(route.Method, path, bytes.NewReader(body))
+	if body != nil {
+		r.Header.Set("Content-Type", "application/json")
+	}
+	return r
+}
+
+// expandValues mengambil relasi yang bisa di-embed dari dokumentasi query
+// parameter expand, misal "Comma-separated relations to embed: variants,
+// category (replaces ...)" menjadi "variants,category"
+func expandValues(route router.Route) string {
+	for _, param := range route.Doc.Query {
+		if param.Name == "expand" {
+			_, list, _ := strings.Cut(param.Description, ": ")
+			var values []string
+			for _, item := range strings.Split(list, ", ") {
+				values = append(values, strings.Fields(item)[0])
+			}
+			return strings.Join(values, ",")
+		}
+	}
+	return ""
+}
+
+func hasIDParam(route router.Route) bool {
+	for _, match := range pathParam.FindAllStringSubmatch(route.Path, -1) {
+		if match[1] == "id" || strings.HasSuffix(match[1], "_id") {
+			return true
+		}
+	}
+	return false
+}
+
+// TestResponsesMatchSpec memanggil setiap route yang terdaftar dan
+// memastikan status code dan bentuk body response-nya ada di spec.
+func TestResponsesMatchSpec(t *testing.T) {
+	fakes := &fakeServices{}
+	mux := newRouter(fakes)
+	doc := fetchSpec(t, mux)
+
+	for _, route := range mux.Routes() {
+		path := strings.TrimSuffix(pathParam.ReplaceAllString(route.Path, "{$1}"), "{$}")
+		op := doc.Paths[path][strings.ToLower(route.Method)]
+		if op == nil {
+			t.Errorf("%s %s is not in the spec", route.Method, route.Path)
+			continue
+		}
+
+		cases := []call{{name: "ok", id: "1"}}
+		if expand := expandValues(route); expand != "" {
+			cases = append(cases, call{name: "expanded", id: "1", query: "expand=" + expand})
+		}
+		if strings.Contains(route.Path, "{") {
+			cases = append(cases, call{name: "not found", id: "1", err: fmt.Errorf("resource %w", service.ErrNotFound)})
+		}
+		if hasIDParam(route) {
+			cases = append(cases, call{name: "invalid id", id: "abc"})
+		}
+
+		for _, tc := range cases {
+			t.Run(route.Method+" "+route.Path+" "+tc.name, func(t *testing.T) {
+				fakes.err = tc.err
+				rec := httptest.NewRecorder()
+				mux.ServeHTTP(rec, requestFor(route, tc.id, tc.query))
+				checkResponse(t, doc, op, rec)
+			})
+		}
+	}
+}
+
+func checkResponse(t *testing.T, doc *openapi.Document, op *openapi.Operation, rec *httptest.ResponseRecorder) {
+	t.Helper()
+	resp := op.Responses[strconv.Itoa(rec.Code)]
+	if resp == nil {
+		statuses := make([]string, 0, len(op.Responses))
+		for status := range op.Responses {
+			statuses = append(statuses, status)
+		}
+		sort.Strings(statuses)
+		t.Fatalf("status %d is not documented (documented: %v), body: %s", rec.Code, statuses, rec.Body)
+	}
+
+	media, ok := resp.Content["application/json"]
+	if !ok {
+		return
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+		t.Fatalf("status %d: Content-Type = %q, documented application/json", rec.Code, ct)
+	}
+	var body interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("status %d: body is not JSON: %v", rec.Code, err)
+	}
+	for _, problem := range checkSchema(doc, media.Schema, body, "body") {
+		t.Errorf("status %d: %s", rec.Code, problem)
+	}
+}
+
+// checkSchema mencocokkan value JSON dengan schema: tipe setiap value dan
+// setiap field object harus terdokumentasi. null diterima di mana saja
+// karena slice, map, dan pointer nil di Go ditulis sebagai null.
+func checkSchema(doc *openapi.Document, schema *openapi.Schema, value interface{}, at string) []string {
+	if schema == nil || value == nil {
+		return nil
+	}
+	if schema.Ref != "" {
+		name := strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+		resolved, ok := doc.Components.Schemas[name]
+		if !ok {
+			return []string{fmt.Sprintf("%s: unresolved $ref %s", at, schema.Ref)}
+		}
+		return checkSchema(doc, resolved, value, at)
+	}
+
+	var problems []string
+	mismatch := func() []string {
+		return []string{fmt.Sprintf("%s: got %T, documented %s", at, value, schema.Type)}
+	}
+	switch schema.Type {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return mismatch()
+		}
+		for key, field := range object {
+			switch {
+			case schema.Properties != nil:
+				prop, ok := schema.Properties[key]
+				if !ok {
+					problems = append(problems, fmt.Sprintf("%s.%s is not documented", at, key))
+					continue
+				}
+				problems = append(problems, checkSchema(doc, prop, field, at+"."+key)...)
+			case schema.AdditionalProperties != nil:
+				problems = append(problems, checkSchema(doc, schema.AdditionalProperties, field, at+"."+key)...)
+			}
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return mismatch()
+		}
+		for i, item := range items {
+			problems = append(problems, checkSchema(doc, schema.Items, item, fmt.Sprintf("%s[%d]", at, i))...)
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			return mismatch()
+		}
+	case "integer":
+		if n, ok := value.(float64); !ok || n != float64(int64(n)) {
+			return mismatch()
+		}
+	case "number":
+		if _, ok := value.(float64); !ok {
+			return mismatch()
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return mismatch()
+		}
+	}
+	return problems
+}
+
+// TestLegacyMatchesV1 memastikan /api tanpa versi melayani route yang
+// persis sama dengan /api/v1
+func TestLegacyMatchesV1(t *testing.T) {
+	routes := map[string][]string{}
+	for _, route := range newRouter(&fakeServices{}).Routes() {
+		if route.Version == "/api" || route.Version == "/api/v1" {
+			path := strings.TrimPrefix(route.Path, route.Version)
+			routes[route.Version] = append(routes[route.Version], route.Method+" "+path)
+		}
+	}
+	sort.Strings(routes["/api"])
+	sort.Strings(routes["/api/v1"])
+	if !reflect.DeepEqual(routes["/api"], routes["/api/v1"]) {
+		t.Errorf("/api routes = %v, want the /api/v1 routes %v", routes["/api"], routes["/api/v1"])
+	}
+}
+
+// TestUnknownPathNotFound memastikan path yang tidak terdaftar dijawab 404,
+// bukan 405 karena tertangkap pattern route lain
+func TestUnknownPathNotFound(t *testing.T) {
+	mux := newRouter(&fakeServices{})
+	for _, target := range []string{
+		"POST /api/v2/products/1/unknown",
+		"DELETE /api/v2/categories/1/unknown",
+		"GET /api/v2/products/by-slug/mug",
+	} {
+		method, path, _ := strings.Cut(target, " ")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("%s = %d, want %d", target, rec.Code, http.StatusNotFound)
+		}
+	}
+}
+
+// TestDocsAssetsSelfHosted memastikan halaman docs hanya memuat aset yang
+// disajikan router sendiri
+func TestDocsAssetsSelfHosted(t *testing.T) {
+	mux := newRouter(&fakeServices{})
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /docs = %d", rec.Code)
+	}
+
+	assets := regexp.MustCompile(`(?:src|href)="([^"]+)"`).FindAllStringSubmatch(rec.Body.String(), -1)
+	if len(assets) == 0 {
+		t.Fatal("GET /docs loads no assets")
+	}
+	for _, match := range assets {
+		asset := match[1]
+		if !strings.HasPrefix(asset, "/") {
+			t.Errorf("GET /docs loads %s from another origin", asset)
+			continue
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, asset, nil))
+		if rec.Code != http.StatusOK || rec.Body.Len() == 0 {
+			t.Errorf("GET %s = %d with %d bytes", asset, rec.Code, rec.Body.Len())
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"go-boot-category-api/model"
+)
+
+type Attribute interface {
+	GetByCategory(categoryID int) ([]model.AttributeDefinition, error)
+	Create(def *model.AttributeDefinition) error
+	Delete(categoryID, id int) error
+}
+
+type attributeRepo struct {
+	db *sql.DB
+}
+
+func NewAttribute(db *sql.DB) Attribute {
+	return &attributeRepo{db: db}
+}
+
+func (repo *attributeRepo) GetByCategory(categoryID int) ([]model.AttributeDefinition, error) {
+	query := `SELECT id, category_id, name, type, required, allowed_values
+    FROM category_attributes WHERE category_id = $1 ORDER BY id`
+	rows, err := repo.db.Query(query, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	defs := make([]model.AttributeDefinition, 0)
+	for rows.Next() {
+		var d model.AttributeDefinition
+		var allowed []byte
+		err := rows.Scan(&d.ID, &d.CategoryID, &d.Name, &d.Type, &d.Required, &allowed)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(allowed, &d.AllowedValues); err != nil {
+			return nil, err
+		}
+		defs = append(defs, d)
+	}
+
+	return defs, rows.Err()
+}
+
+func (repo *attributeRepo) Create(def *model.AttributeDefinition) error {
+	allowed, err := json.Marshal(def.AllowedValues)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO category_attributes (category_id, name, type, required, allowed_values)
+    VALUES ($1, $2, $3, $4, $5) RETURNING id`
+	err = repo.db.QueryRow(query, def.CategoryID, def.Name, def.Type, def.Required, allowed).Scan(&def.ID)
+	return duplicateError(err, "atribut")
+}
+
+func (repo *attributeRepo) Delete(categoryID, id int) error {
+	query := "DELETE FROM category_attributes WHERE category_id = $1 AND id = $2"
+	result, err := repo.db.Exec(query, categoryID, id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("atribut %w", ErrNotFound)
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"encoding/json"
+	"go-boot-category-api/framework/cache"
+	"log"
+	"strconv"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// readThrough mengambil value dari cache, atau memanggil load lalu menyimpan
+// hasilnya. Request paralel untuk key yang sama digabung lewat singleflight
+// supaya cache miss tidak menghasilkan banyak query ke database sekaligus.
+//
+// Generation cache dicatat sebelum load. Hasil load hanya disimpan jika
+// belum ada invalidasi sejak itu, dan generation ikut menjadi key
+// singleflight supaya request setelah invalidasi tidak menumpang load lama.
+func readThrough[T any](c cache.Cache, group *singleflight.Group, key string, ttl time.Duration, load func() (T, error)) (T, error) {
+	var value T
+	if raw, ok := c.Get(key); ok {
+		if err := json.Unmarshal(raw, &value); err == nil {
+			return value, nil
+		}
+		c.Delete(key)
+	}
+
+	generation := c.Generation()
+	flight := key + "@" + strconv.FormatUint(generation, 10)
+	result, err, _ := group.Do(flight, func() (interface{}, error) {
+		loaded, err := load()
+		if err != nil {
+			return loaded, err
+		}
+		raw, err := json.Marshal(loaded)
+		if err != nil {
+			log.Printf("cache: marshal %s: %v", key, err)
+			return loaded, nil
+		}
+		c.SetIfGeneration(key, raw, ttl, generation)
+		return loaded, nil
+	})
+	if err != nil {
+		return value, err
+	}
+	return result.(T), nil
+}
@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"errors"
+	"go-boot-category-api/framework/cache"
+	"go-boot-category-api/model"
+	"testing"
+	"time"
+)
+
+// fakeProducts menghitung GetByID dan menjalankan onLoad di tengah load,
+// untuk meniru penulisan yang terjadi selagi cache miss dimuat.
+type fakeProducts struct {
+	Product
+	products  map[int]model.Product
+	loads     int
+	onLoad    func()
+	updateErr error
+}
+
+func (f *fakeProducts) GetByID(id int) (*model.Product, error) {
+	f.loads++
+	product, ok := f.products[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if f.onLoad != nil {
+		f.onLoad()
+	}
+	return &product, nil
+}
+
+func (f *fakeProducts) Update(product *model.Product, stock *int, actor string) error {
+	if f.updateErr != nil {
+		return f.updateErr
+	}
+	f.products[product.ID] = *product
+	return nil
+}
+
+type fakeCategories struct {
+	Category
+	categories []model.Category
+	loads      int
+}
+
+func (f *fakeCategories) GetAll() ([]model.Category, error) {
+	f.loads++
+	return f.categories, nil
+}
+
+type fakeStock struct {
+	Stock
+}
+
+func (fakeStock) Adjust(productID, warehouseID, delta int, actor string) (*model.LedgerEntry, error) {
+	return &model.LedgerEntry{ProductID: &productID, Delta: delta}, nil
+}
+
+func testProduct(name string) model.Product {
+	idr := model.Money{Currency: "IDR"}
+	return model.Product{ID: 1, Name: name, Price: model.Money{Amount: 1000, Currency: "IDR"}, CostPrice: idr}
+}
+
+func newCachedFixture() (*fakeProducts, Product, cache.Cache) {
+	c := cache.NewLRU(10)
+	next := &fakeProducts{products: map[int]model.Product{1: testProduct("Mug")}}
+	return next, NewCachedProduct(next, c, time.Minute), c
+}
+
+func TestCachedProductGetByID(t *testing.T) {
+	next, repo, _ := newCachedFixture()
+
+	for i := 0; i < 2; i++ {
+		product, err := repo.GetByID(1)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if product.Name != "Mug" {
+			t.Errorf("GetByID() name = %q, want Mug", product.Name)
+		}
+	}
+	if next.loads != 1 {
+		t.Errorf("loads = %d, want 1", next.loads)
+	}
+
+	if _, err := repo.GetByID(2); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetByID(2) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestCachedProductUpdateInvalidates(t *testing.T) {
+	next, repo, c := newCachedFixture()
+	categories := &fakeCategories{categories: []model.Category{{ID: 1, Name: "Kitchen"}}}
+	categoryRepo := NewCachedCategory(categories, c, time.Minute)
+
+	if _, err := repo.GetByID(1); err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if _, err := categoryRepo.GetAll(); err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+
+	updated := testProduct("Big Mug")
+	if err := repo.Update(&updated, nil, "test"); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	product, err := repo.GetByID(1)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if product.Name != "Big Mug" || next.loads != 2 {
+		t.Errorf("GetByID() after Update() = %q with %d loads, want Big Mug with 2", product.Name, next.loads)
+	}
+	if _, err := categoryRepo.GetAll(); err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	if categories.loads != 2 {
+		t.Errorf("category loads = %d, want category cache invalidated", categories.loads)
+	}
+}
+
+func TestCachedProductFailedUpdateInvalidates(t *testing.T) {
+	next, repo, _ := newCachedFixture()
+	next.updateErr = ErrModified
+
+	if _, err := repo.GetByID(1); err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if err := repo.Update(&model.Product{ID: 1}, nil, "test"); !errors.Is(err, ErrModified) {
+		t.Fatalf("Update() error = %v, want ErrModified", err)
+	}
+	if _, err := repo.GetByID(1); err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if next.loads != 2 {
+		t.Errorf("loads = %d, want stale product evicted", next.loads)
+	}
+}
+
+func TestCachedProductSkipsStaleLoad(t *testing.T) {
+	next, repo, c := newCachedFixture()
+	next.onLoad = func() {
+		// penulisan lain selesai setelah produk dibaca dari database
+		next.onLoad = nil
+		c.Delete(productCacheKey(1))
+	}
+
+	if _, err := repo.GetByID(1); err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if _, ok := c.Get(productCacheKey(1)); ok {
+		t.Fatal("GetByID() cached a value loaded before invalidation")
+	}
+	if _, err := repo.GetByID(1); err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if _, ok := c.Get(productCacheKey(1)); !ok {
+		t.Error("GetByID() did not cache a fresh load")
+	}
+}
+
+func TestCachedStockAdjustInvalidates(t *testing.T) {
+	next, repo, c := newCachedFixture()
+	stock := NewCachedStock(fakeStock{}, c)
+
+	if _, err := repo.GetByID(1); err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if _, err := stock.Adjust(1, 1, 5, "test"); err != nil {
+		t.Fatalf("Adjust() error = %v", err)
+	}
+	if _, err := repo.GetByID(1); err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if next.loads != 2 {
+		t.Errorf("loads = %d, want product cache invalidated", next.loads)
+	}
+}
@@ -2,7 +2,8 @@ package repository
 
 import (
 	"database/sql"
-	"errors"
+	"encoding/json"
+	"fmt"
 	"go-boot-category-api/model"
 )
 
@@ -12,6 +13,9 @@ type Category interface {
 	Create(category *model.Category) error
 	Update(category *model.Category) error
 	Delete(id int) error
+	ExistsByName(name string, excludeID int) (bool, error)
+	GetBySlug(slug string) (*model.Category, error)
+	SlugExists(slug string, excludeID int) (bool, error)
 }
 
 type categoryRepo struct {
@@ -22,9 +26,60 @@ func NewCategory(db *sql.DB) Category {
 	return &categoryRepo{db: db}
 }
 
+// categorySelect membaca kategori beserta ringkasan produknya dalam satu
+// query: produk diagregasi per kategori dan mata uang, lalu nilai stok
+// per mata uang digabung menjadi JSON array.
+const categorySelect = `SELECT
+        c.id,
+        c.name,
+        c.slug,
+        c.description,
+        COALESCE(s.product_count, 0),
+        COALESCE(s.total_stock, 0),
+        COALESCE(s.stock_value, '[]'),
+        c.updated_at
+    FROM categories c
+    LEFT JOIN (
+        SELECT
+            category_id,
+            SUM(product_count) AS product_count,
+            SUM(total_stock) AS total_stock,
+            json_agg(json_build_object('amount', stock_value, 'currency', currency) ORDER BY currency) AS stock_value
+        FROM (
+            SELECT p.category_id, p.currency, COUNT(*) AS product_count, SUM(ps.stock) AS total_stock, SUM(p.price_amount * ps.stock) AS stock_value
+            FROM products p
+            CROSS JOIN LATERAL (SELECT ` + productStockColumn + ` AS stock) ps
+            GROUP BY p.category_id, p.currency
+        ) per_currency
+        GROUP BY category_id
+    ) s ON s.category_id = c.id`
+
+// scanCategory membaca satu baris hasil categorySelect
+func scanCategory(row rowScanner) (*model.Category, error) {
+	var c model.Category
+	var stockValue []byte
+	err := row.Scan(&c.ID, &c.Name, &c.Slug, &c.Description, &c.ProductCount, &c.TotalStock, &stockValue, &c.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	// amount dari database dalam minor unit, bukan format JSON Money
+	var values []struct {
+		Amount   int64  `json:"amount"`
+		Currency string `json:"currency"`
+	}
+	if err := json.Unmarshal(stockValue, &values); err != nil {
+		return nil, err
+	}
+	c.StockValue = make([]model.Money, len(values))
+	for i, v := range values {
+		c.StockValue[i] = model.Money{Amount: v.Amount, Currency: v.Currency}
+	}
+	return &c, nil
+}
+
 func (repo *categoryRepo) GetAll() ([]model.Category, error) {
-	query := "SELECT id, name, description FROM categories"
-	rows, err := repo.db.Query(query)
+	rows, err := repo.db.Query(categorySelect + " ORDER BY c.id")
 	if err != nil {
 		return nil, err
 	}
@@ -32,56 +87,65 @@ func (repo *categoryRepo) GetAll() ([]model.Category, error) {
 
 	categories := make([]model.Category, 0)
 	for rows.Next() {
-		var p model.Category
-		err := rows.Scan(&p.ID, &p.Name, &p.Description)
+		c, err := scanCategory(rows)
 		if err != nil {
 			return nil, err
 		}
-		categories = append(categories, p)
+		categories = append(categories, *c)
 	}
 
-	return categories, nil
+	return categories, rows.Err()
 }
 
 func (repo *categoryRepo) Create(category *model.Category) error {
-	query := "INSERT INTO categories (name, description) VALUES ($1, $2) RETURNING id"
-	err := repo.db.QueryRow(query, category.Name, category.Description).Scan(&category.ID)
-	return err
+	query := "INSERT INTO categories (name, slug, description) VALUES ($1, $2, $3) RETURNING id, updated_at"
+	err := repo.db.QueryRow(query, category.Name, category.Slug, category.Description).Scan(&category.ID, &category.UpdatedAt)
+	return duplicateError(err, "kategori")
 }
 
 // GetByID - ambil kategori by ID
 func (repo *categoryRepo) GetByID(id int) (*model.Category, error) {
-	query := "SELECT id, name, description FROM categories WHERE id = $1"
-
-	var p model.Category
-	err := repo.db.QueryRow(query, id).Scan(&p.ID, &p.Name, &p.Description)
+	c, err := scanCategory(repo.db.QueryRow(categorySelect+" WHERE c.id = $1", id))
 	if err == sql.ErrNoRows {
-		return nil, errors.New("kategori tidak ditemukan")
+		return nil, fmt.Errorf("kategori %w", ErrNotFound)
 	}
 	if err != nil {
 		return nil, err
 	}
 
-	return &p, nil
+	return c, nil
 }
 
+// Update - simpan perubahan kategori. Jika slug berubah, slug lama dicatat
+// di category_slug_history supaya URL lama masih bisa di-redirect.
 func (repo *categoryRepo) Update(category *model.Category) error {
-	query := "UPDATE categories SET name = $1, description = $2 WHERE id = $3"
-	result, err := repo.db.Exec(query, category.Name, category.Description, category.ID)
+	tx, err := repo.db.Begin()
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
 
-	rows, err := result.RowsAffected()
+	var oldSlug string
+	err = tx.QueryRow("SELECT slug FROM categories WHERE id = $1 FOR UPDATE", category.ID).Scan(&oldSlug)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("kategori %w", ErrNotFound)
+	}
 	if err != nil {
 		return err
 	}
 
-	if rows == 0 {
-		return errors.New("kategori tidak ditemukan")
+	query := "UPDATE categories SET name = $1, slug = $2, description = $3, updated_at = now() WHERE id = $4 RETURNING updated_at"
+	err = tx.QueryRow(query, category.Name, category.Slug, category.Description, category.ID).Scan(&category.UpdatedAt)
+	if err != nil {
+		return duplicateError(err, "kategori")
 	}
 
-	return nil
+	if oldSlug != category.Slug {
+		if err := moveSlugToHistory(tx, "category_slug_history", "category_id", category.ID, oldSlug, category.Slug); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
 }
 
 func (repo *categoryRepo) Delete(id int) error {
@@ -96,8 +160,46 @@ func (repo *categoryRepo) Delete(id int) error {
 	}
 
 	if rows == 0 {
-		return errors.New("kategori tidak ditemukan")
+		return fmt.Errorf("kategori %w", ErrNotFound)
 	}
 
 	return err
 }
+
+// ExistsByName - cek apakah nama kategori sudah dipakai (case-insensitive),
+// selain oleh kategori dengan ID excludeID
+func (repo *categoryRepo) ExistsByName(name string, excludeID int) (bool, error) {
+	query := "SELECT EXISTS(SELECT 1 FROM categories WHERE lower(name) = lower($1) AND id <> $2)"
+	exists := false
+	err := repo.db.QueryRow(query, name, excludeID).Scan(&exists)
+	return exists, err
+}
+
+// GetBySlug - ambil kategori by slug aktif, atau by slug lama dari history.
+// Pemanggil bisa membandingkan Slug hasilnya untuk tahu perlu redirect.
+func (repo *categoryRepo) GetBySlug(slug string) (*model.Category, error) {
+	query := `SELECT c.id FROM categories c WHERE c.slug = $1
+    UNION ALL
+    SELECT h.category_id FROM category_slug_history h WHERE h.slug = $1
+    LIMIT 1`
+
+	var id int
+	err := repo.db.QueryRow(query, slug).Scan(&id)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("kategori %w", ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return repo.GetByID(id)
+}
+
+// SlugExists - cek apakah slug sudah dipakai kategori lain, baik sebagai
+// slug aktif maupun slug lama di history
+func (repo *categoryRepo) SlugExists(slug string, excludeID int) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM categories WHERE slug = $1 AND id <> $2)
+        OR EXISTS(SELECT 1 FROM category_slug_history WHERE slug = $1 AND category_id <> $2)`
+	exists := false
+	err := repo.db.QueryRow(query, slug, excludeID).Scan(&exists)
+	return exists, err
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"go-boot-category-api/framework/cache"
+	"go-boot-category-api/model"
+	"strconv"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	categoryCacheAll    = "category:all"
+	categoryCachePrefix = "category:"
+)
+
+type cachedCategoryRepo struct {
+	next  Category
+	cache cache.Cache
+	ttl   time.Duration
+	group singleflight.Group
+}
+
+// NewCachedCategory membungkus repository kategori dengan read-through cache.
+// Perubahan kategori juga menghapus cache produk karena produk menyimpan
+// category_name hasil join.
+func NewCachedCategory(next Category, c cache.Cache, ttl time.Duration) Category {
+	return &cachedCategoryRepo{next: next, cache: c, ttl: ttl}
+}
+
+func categoryCacheKey(id int) string {
+	return categoryCachePrefix + "id:" + strconv.Itoa(id)
+}
+
+func (repo *cachedCategoryRepo) GetAll() ([]model.Category, error) {
+	return readThrough(repo.cache, &repo.group, categoryCacheAll, repo.ttl, repo.next.GetAll)
+}
+
+func (repo *cachedCategoryRepo) GetByID(id int) (*model.Category, error) {
+	return readThrough(repo.cache, &repo.group, categoryCacheKey(id), repo.ttl, func() (*model.Category, error) {
+		return repo.next.GetByID(id)
+	})
+}
+
+func (repo *cachedCategoryRepo) Create(category *model.Category) error {
+	if err := repo.next.Create(category); err != nil {
+		return err
+	}
+	repo.cache.Delete(categoryCacheAll)
+	return nil
+}
+
+func (repo *cachedCategoryRepo) Update(category *model.Category) error {
+	if err := repo.next.Update(category); err != nil {
+		return err
+	}
+	repo.invalidate(category.ID)
+	return nil
+}
+
+func (repo *cachedCategoryRepo) Delete(id int) error {
+	if err := repo.next.Delete(id); err != nil {
+		return err
+	}
+	repo.invalidate(id)
+	return nil
+}
+
+func (repo *cachedCategoryRepo) ExistsByName(name string, excludeID int) (bool, error) {
+	return repo.next.ExistsByName(name, excludeID)
+}
+
+// GetBySlug tidak di-cache karena slug lama di history bisa berpindah
+// pemilik tanpa melewati key cache by ID.
+func (repo *cachedCategoryRepo) GetBySlug(slug string) (*model.Category, error) {
+	return repo.next.GetBySlug(slug)
+}
+
+func (repo *cachedCategoryRepo) SlugExists(slug string, excludeID int) (bool, error) {
+	return repo.next.SlugExists(slug, excludeID)
+}
+
+func (repo *cachedCategoryRepo) invalidate(id int) {
+	repo.cache.Delete(categoryCacheAll, categoryCacheKey(id))
+	repo.cache.DeletePrefix(productCachePrefix)
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"database/sql"
+	"go-boot-category-api/model"
+)
+
+// CostingMethod adalah metode penentuan harga pokok stok keluar,
+// model.CostingAverage atau model.CostingFIFO. Diatur sekali saat startup;
+// jika diganti, stok lama tanpa lapisan FIFO dinilai dengan harga pokok
+// produk saat itu.
+var CostingMethod = model.CostingAverage
+
+// stockCost menghitung nilai persediaan perubahan stok delta sesuai
+// CostingMethod, lalu memperbarui nilai persediaan dan harga pokok produk.
+// Stok masuk dinilai dengan unitCost jika diisi (penerimaan barang), atau
+// harga pokok produk saat ini. Dipanggil setelah stok gudang diubah,
+// dengan baris produk sudah dikunci. Lapisan FIFO untuk stok masuk dibuat
+// oleh addCostLayer setelah entri ledger-nya tersimpan.
+func stockCost(tx *sql.Tx, productID, delta int, unitCost *int64) (int64, error) {
+	var value, cost int64
+	var stock int
+	query := `SELECT p.inventory_value, p.cost_amount, ` + productStockColumn + ` FROM products p WHERE p.id = $1`
+	if err := tx.QueryRow(query, productID).Scan(&value, &cost, &stock); err != nil {
+		return 0, err
+	}
+
+	var amount int64
+	switch {
+	case delta > 0 && unitCost != nil:
+		amount = *unitCost * int64(delta)
+	case delta > 0:
+		amount = cost * int64(delta)
+	case CostingMethod == model.CostingFIFO:
+		consumed, err := consumeCostLayers(tx, productID, -delta, cost)
+		if err != nil {
+			return 0, err
+		}
+		amount = -consumed
+	default:
+		// rata-rata tertimbang: stok keluar mengambil bagian nilai
+		// persediaan sebanding jumlahnya, sehingga stok yang habis membawa
+		// seluruh sisa nilainya tanpa selisih pembulatan
+		before := stock - delta
+		if before > 0 {
+			amount = -value * int64(-delta) / int64(before)
+		}
+	}
+
+	value += amount
+	if stock > 0 {
+		cost = value / int64(stock)
+	}
+	_, err := tx.Exec("UPDATE products SET inventory_value = $1, cost_amount = $2 WHERE id = $3", value, cost, productID)
+	return amount, err
+}
+
+// consumeCostLayers memakai quantity unit dari lapisan FIFO yang paling
+// lama dan mengembalikan nilainya. Setiap lapisan melepas bagian nilai
+// sisanya sebanding jumlah yang diambil, sehingga lapisan yang habis
+// melepas seluruh sisa nilainya tanpa selisih pembulatan. Unit yang tidak
+// tercakup lapisan, misal stok sebelum costing dicatat, dinilai dengan
+// fallback per unit.
+func consumeCostLayers(tx *sql.Tx, productID, quantity int, fallback int64) (int64, error) {
+	query := `SELECT id, remaining, remaining_value FROM cost_layers
+    WHERE product_id = $1 AND remaining > 0
+    ORDER BY id FOR UPDATE`
+	rows, err := tx.Query(query, productID)
+	if err != nil {
+		return 0, err
+	}
+
+	type layer struct {
+		id        int64
+		take      int
+		remaining int
+		value     int64
+	}
+	var used []layer
+	left := quantity
+	for left > 0 && rows.Next() {
+		var l layer
+		if err := rows.Scan(&l.id, &l.remaining, &l.value); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		l.take = min(left, l.remaining)
+		left -= l.take
+		used = append(used, l)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	var amount int64
+	for _, l := range used {
+		taken := l.value * int64(l.take) / int64(l.remaining)
+		query := "UPDATE cost_layers SET remaining = remaining - $1, remaining_value = remaining_value - $2 WHERE id = $3"
+		if _, err := tx.Exec(query, l.take, taken, l.id); err != nil {
+			return 0, err
+		}
+		amount += taken
+	}
+	return amount + fallback*int64(left), nil
+}
+
+// addCostLayer mencatat stok masuk senilai amount sebagai lapisan FIFO
+// baru. Tidak dipakai pada costing rata-rata.
+func addCostLayer(tx *sql.Tx, entry *model.LedgerEntry, amount int64) error {
+	if CostingMethod != model.CostingFIFO || entry.Delta <= 0 {
+		return nil
+	}
+	query := `INSERT INTO cost_layers (product_id, ledger_id, quantity, remaining, unit_cost_amount, remaining_value)
+    VALUES ($1, $2, $3, $3, $4, $5)`
+	_, err := tx.Exec(query, *entry.ProductID, entry.ID, entry.Delta, amount/int64(entry.Delta), amount)
+	return err
+}
@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"database/sql"
+	"go-boot-category-api/model"
+	"slices"
+	"testing"
+)
+
+// useCosting mengganti CostingMethod selama test
+func useCosting(t *testing.T, method string) {
+	previous := CostingMethod
+	CostingMethod = method
+	t.Cleanup(func() { CostingMethod = previous })
+}
+
+// receiveAt menerima quantity unit produk dengan harga pokok unitCost
+// lewat purchase order
+func receiveAt(t *testing.T, db *sql.DB, productID, quantity int, unitCost int64) {
+	t.Helper()
+	order := createPurchaseOrder(t, db, productID, quantity, unitCost)
+	if err := NewPurchaseOrder(db).Receive(receipt(order, quantity)); err != nil {
+		t.Fatalf("receive: %v", err)
+	}
+}
+
+// orderCost adalah total nilai persediaan yang keluar karena pesanan
+func orderCost(t *testing.T, db *sql.DB, productID int) int64 {
+	t.Helper()
+	var amount int64
+	query := "SELECT COALESCE(SUM(cost_amount), 0) FROM stock_ledger WHERE product_id = $1 AND reason = $2"
+	if err := db.QueryRow(query, productID, model.LedgerOrder).Scan(&amount); err != nil {
+		t.Fatalf("order cost: %v", err)
+	}
+	return amount
+}
+
+func inventoryValue(t *testing.T, db *sql.DB, productID int) int64 {
+	t.Helper()
+	var value int64
+	if err := db.QueryRow("SELECT inventory_value FROM products WHERE id = $1", productID).Scan(&value); err != nil {
+		t.Fatalf("inventory value: %v", err)
+	}
+	return value
+}
+
+func layersRemaining(t *testing.T, db *sql.DB, productID int) []int {
+	t.Helper()
+	rows, err := db.Query("SELECT remaining FROM cost_layers WHERE product_id = $1 ORDER BY id", productID)
+	if err != nil {
+		t.Fatalf("cost layers: %v", err)
+	}
+	defer rows.Close()
+	var remaining []int
+	for rows.Next() {
+		var r int
+		if err := rows.Scan(&r); err != nil {
+			t.Fatalf("cost layers: %v", err)
+		}
+		remaining = append(remaining, r)
+	}
+	return remaining
+}
+
+func TestCostingFIFO(t *testing.T) {
+	useCosting(t, model.CostingFIFO)
+	db := testDB(t)
+	product := createProduct(t, db, "FIFO Shirt", 1000, 0)
+	receiveAt(t, db, product.ID, 10, 100)
+	receiveAt(t, db, product.ID, 10, 200)
+
+	if err := NewOrder(db).Create(newOrder(product.ID, 15)); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// 10 unit lapisan pertama @100 dan 5 unit lapisan kedua @200
+	if got := orderCost(t, db, product.ID); got != -2000 {
+		t.Errorf("order cost = %d, want -2000", got)
+	}
+	if got := layersRemaining(t, db, product.ID); !slices.Equal(got, []int{0, 5}) {
+		t.Errorf("layers remaining = %v, want [0 5]", got)
+	}
+	if got := inventoryValue(t, db, product.ID); got != 1000 {
+		t.Errorf("inventory value = %d, want 1000", got)
+	}
+	expectStock(t, db, product.ID, 5)
+}
+
+func TestCostingFIFOFallback(t *testing.T) {
+	db := testDB(t)
+	product := createProduct(t, db, "Switched Shirt", 1000, 0)
+
+	// stok yang diterima sebelum FIFO dipakai tidak punya lapisan
+	useCosting(t, model.CostingAverage)
+	receiveAt(t, db, product.ID, 10, 100)
+	CostingMethod = model.CostingFIFO
+	receiveAt(t, db, product.ID, 10, 200)
+
+	if err := NewOrder(db).Create(newOrder(product.ID, 15)); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// 10 unit dari satu-satunya lapisan @200, 5 sisanya dengan harga pokok
+	// produk saat itu (3000 / 20 = 150)
+	if got := orderCost(t, db, product.ID); got != -2750 {
+		t.Errorf("order cost = %d, want -2750", got)
+	}
+	if got := layersRemaining(t, db, product.ID); !slices.Equal(got, []int{0}) {
+		t.Errorf("layers remaining = %v, want [0]", got)
+	}
+	if got := inventoryValue(t, db, product.ID); got != 250 {
+		t.Errorf("inventory value = %d, want 250", got)
+	}
+}
+
+func TestCostingAverage(t *testing.T) {
+	useCosting(t, model.CostingAverage)
+	db := testDB(t)
+	product := createProduct(t, db, "Average Shirt", 1000, 0)
+	receiveAt(t, db, product.ID, 10, 100)
+	receiveAt(t, db, product.ID, 10, 200)
+
+	if err := NewOrder(db).Create(newOrder(product.ID, 15)); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if got := orderCost(t, db, product.ID); got != -2250 {
+		t.Errorf("order cost = %d, want -2250", got)
+	}
+	if got := layersRemaining(t, db, product.ID); len(got) != 0 {
+		t.Errorf("layers = %v, want none under average costing", got)
+	}
+
+	// stok habis membawa seluruh sisa nilai
+	if err := NewOrder(db).Create(newOrder(product.ID, 5)); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if got := inventoryValue(t, db, product.ID); got != 0 {
+		t.Errorf("inventory value = %d, want 0", got)
+	}
+}
+
+func TestCostingFIFOLayerRemainder(t *testing.T) {
+	useCosting(t, model.CostingFIFO)
+	db := testDB(t)
+	product := createProduct(t, db, "Odd Lot", 1000, 0)
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	defer tx.Rollback()
+
+	// 3 unit senilai 100 tidak habis dibagi per unit
+	entry, err := adjustWarehouseStock(tx, product.ID, defaultWarehouse(t, db), 3, model.LedgerAdjustment, nil, "test")
+	if err != nil {
+		t.Fatalf("adjust: %v", err)
+	}
+	if _, err := tx.Exec("DELETE FROM cost_layers WHERE ledger_id = $1", entry.ID); err != nil {
+		t.Fatalf("delete layer: %v", err)
+	}
+	if err := addCostLayer(tx, entry, 100); err != nil {
+		t.Fatalf("addCostLayer() error = %v", err)
+	}
+
+	var consumed []int64
+	for range 3 {
+		amount, err := consumeCostLayers(tx, product.ID, 1, 0)
+		if err != nil {
+			t.Fatalf("consumeCostLayers() error = %v", err)
+		}
+		consumed = append(consumed, amount)
+	}
+	if !slices.Equal(consumed, []int64{33, 33, 34}) {
+		t.Errorf("consumed = %v, want [33 33 34]", consumed)
+	}
+}
+
+func TestCostingTransferKeepsLayers(t *testing.T) {
+	useCosting(t, model.CostingFIFO)
+	db := testDB(t)
+	product := createProduct(t, db, "Moved Shirt", 1000, 0)
+	receiveAt(t, db, product.ID, 10, 100)
+
+	other := &model.Warehouse{Code: "SIDE", Name: "Side warehouse"}
+	if err := NewWarehouse(db).Create(other); err != nil {
+		t.Fatalf("create warehouse: %v", err)
+	}
+	transfer := &model.StockTransfer{ProductID: &product.ID, FromWarehouseID: defaultWarehouse(t, db), ToWarehouseID: other.ID, Quantity: 4, Actor: "test"}
+	if err := NewStock(db).Transfer(transfer); err != nil {
+		t.Fatalf("Transfer() error = %v", err)
+	}
+
+	if got := layersRemaining(t, db, product.ID); !slices.Equal(got, []int{10}) {
+		t.Errorf("layers remaining = %v, want [10]", got)
+	}
+	if got := inventoryValue(t, db, product.ID); got != 1000 {
+		t.Errorf("inventory value = %d, want 1000", got)
+	}
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"database/sql"
+	"go-boot-category-api/database"
+	"go-boot-category-api/framework/slug"
+	"go-boot-category-api/model"
+	"os"
+	"testing"
+)
+
+// testDB membuka database dari TEST_DATABASE_URL, menjalankan migrasi, dan
+// mengosongkan semua data kecuali gudang default. Test dilewati jika env
+// tidak diisi, kecuali di CI supaya test database tidak diam-diam
+// terlewat. Jangan arahkan ke database yang datanya dipakai.
+func testDB(t *testing.T) *sql.DB {
+	t.Helper()
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		if os.Getenv("CI") != "" {
+			t.Fatal("TEST_DATABASE_URL is required in CI")
+		}
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	db, err := sql.Open("pgx", url)
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if err := database.Migrate(db); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	_, err = db.Exec(`TRUNCATE categories, products, tags, scheduled_prices, price_history, stock_transfers, stock_ledger,
+        stock_reservations, low_stock_events, orders, suppliers, purchase_orders, cost_layers, stocktakes
+        RESTART IDENTITY CASCADE`)
+	if err != nil {
+		t.Fatalf("truncate: %v", err)
+	}
+	if _, err := db.Exec("DELETE FROM warehouses WHERE NOT is_default"); err != nil {
+		t.Fatalf("delete warehouses: %v", err)
+	}
+	return db
+}
+
+// createProduct membuat produk dengan stok awal di gudang default
+func createProduct(t *testing.T, db *sql.DB, name string, price int64, stock int) *model.Product {
+	t.Helper()
+	category := &model.Category{Name: "Category " + name, Slug: slug.Make("category " + name)}
+	if err := NewCategory(db).Create(category); err != nil {
+		t.Fatalf("create category: %v", err)
+	}
+
+	product := &model.Product{
+		Name:       name,
+		Slug:       slug.Make(name),
+		Price:      model.Money{Amount: price, Currency: "IDR"},
+		Stock:      stock,
+		CategoryId: category.ID,
+		Attributes: map[string]interface{}{},
+	}
+	if err := NewProduct(db).Create(product, "test"); err != nil {
+		t.Fatalf("create product: %v", err)
+	}
+	return product
+}
+
+// defaultWarehouse mengambil ID gudang default
+func defaultWarehouse(t *testing.T, db *sql.DB) int {
+	t.Helper()
+	var id int
+	if err := db.QueryRow("SELECT id FROM warehouses WHERE is_default").Scan(&id); err != nil {
+		t.Fatalf("default warehouse: %v", err)
+	}
+	return id
+}
+
+// stockOf adalah total stok on-hand produk di semua gudang
+func stockOf(t *testing.T, db *sql.DB, productID int) int {
+	t.Helper()
+	var stock int
+	err := db.QueryRow("SELECT COALESCE(SUM(quantity), 0) FROM warehouse_stock WHERE product_id = $1", productID).Scan(&stock)
+	if err != nil {
+		t.Fatalf("stock of product %d: %v", productID, err)
+	}
+	return stock
+}
+
+// ledgerSum adalah jumlah delta ledger produk untuk reason tertentu
+func ledgerSum(t *testing.T, db *sql.DB, productID int, reason string) int {
+	t.Helper()
+	var sum int
+	err := db.QueryRow("SELECT COALESCE(SUM(delta), 0) FROM stock_ledger WHERE product_id = $1 AND reason = $2", productID, reason).Scan(&sum)
+	if err != nil {
+		t.Fatalf("ledger of product %d: %v", productID, err)
+	}
+	return sum
+}
+
+// expectStock memastikan stok on-hand produk dan total ledger-nya sama
+// dengan want
+func expectStock(t *testing.T, db *sql.DB, productID, want int) {
+	t.Helper()
+	if got := stockOf(t, db, productID); got != want {
+		t.Errorf("stock of product %d = %d, want %d", productID, got, want)
+	}
+	var ledger int
+	if err := db.QueryRow("SELECT COALESCE(SUM(delta), 0) FROM stock_ledger WHERE product_id = $1", productID).Scan(&ledger); err != nil {
+		t.Fatalf("ledger of product %d: %v", productID, err)
+	}
+	if ledger != want {
+		t.Errorf("ledger total of product %d = %d, want %d", productID, ledger, want)
+	}
+}
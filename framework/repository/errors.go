@@ -0,0 +1,7 @@
+package repository
+
+import "errors"
+
+// ErrNotFound di-wrap oleh semua repository ketika data tidak ditemukan,
+// misal fmt.Errorf("produk %w", ErrNotFound) menjadi "produk tidak ditemukan".
+var ErrNotFound = errors.New("tidak ditemukan")
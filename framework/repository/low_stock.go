@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"database/sql"
+	"go-boot-category-api/model"
+	"time"
+)
+
+type LowStock interface {
+	GetAll() ([]model.LowStock, error)
+	Claim(limit int, lease time.Duration) ([]model.LowStockEvent, error)
+	Done(id int64) error
+}
+
+type lowStockRepo struct {
+	db *sql.DB
+}
+
+func NewLowStock(db *sql.DB) LowStock {
+	return &lowStockRepo{db: db}
+}
+
+// lowStockColumns adalah kolom produk untuk laporan stok rendah, dengan
+// s.stock dari subquery pemanggil
+const lowStockColumns = `p.id, p.name, p.slug, p.category_id, s.stock, ` + productAvailableColumn + ` AS available,
+        p.reorder_point, p.reorder_qty`
+
+// lowStockSelect adalah produk yang dipantau (reorder_point > 0) dengan
+// stok on-hand sama dengan atau di bawah reorder point
+const lowStockSelect = `SELECT ` + lowStockColumns + `
+    FROM products p
+    CROSS JOIN LATERAL (SELECT ` + productStockColumn + ` AS stock) s
+    WHERE p.reorder_point > 0 AND s.stock <= p.reorder_point`
+
+// GetAll - semua produk yang stoknya rendah, yang paling jauh di bawah
+// reorder point lebih dulu
+func (repo *lowStockRepo) GetAll() ([]model.LowStock, error) {
+	rows, err := repo.db.Query(lowStockSelect + " ORDER BY s.stock - p.reorder_point, p.id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]model.LowStock, 0)
+	for rows.Next() {
+		var item model.LowStock
+		if err := scanLowStock(rows, &item); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// Claim - ambil paling banyak limit event stok rendah yang belum terkirim
+// dan tunda event tersebut selama lease. Event yang tidak di-Done sebelum
+// lease habis, misal karena notifikasinya gagal atau instance mati, akan
+// diambil lagi. SKIP LOCKED membuat beberapa instance tidak mengambil
+// event yang sama. Stock adalah stok saat batas dilewati.
+func (repo *lowStockRepo) Claim(limit int, lease time.Duration) ([]model.LowStockEvent, error) {
+	query := `WITH claimed AS (
+        UPDATE low_stock_events SET available_at = now() + make_interval(secs => $2)
+        WHERE id IN (
+            SELECT id FROM low_stock_events WHERE available_at <= now()
+            ORDER BY id LIMIT $1 FOR UPDATE SKIP LOCKED
+        )
+        RETURNING id, product_id, stock
+    )
+    SELECT claimed.id, ` + lowStockColumns + `
+    FROM claimed
+    JOIN products p ON p.id = claimed.product_id
+    CROSS JOIN LATERAL (SELECT claimed.stock) s
+    ORDER BY claimed.id`
+	rows, err := repo.db.Query(query, limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]model.LowStockEvent, 0)
+	for rows.Next() {
+		var event model.LowStockEvent
+		if err := rows.Scan(&event.ID, &event.ProductID, &event.ProductName, &event.Slug, &event.CategoryID, &event.Stock, &event.Available,
+			&event.ReorderPoint, &event.ReorderQty); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+// Done - hapus event yang notifikasinya sudah terkirim
+func (repo *lowStockRepo) Done(id int64) error {
+	_, err := repo.db.Exec("DELETE FROM low_stock_events WHERE id = $1", id)
+	return err
+}
+
+func scanLowStock(rows *sql.Rows, item *model.LowStock) error {
+	return rows.Scan(&item.ProductID, &item.ProductName, &item.Slug, &item.CategoryID, &item.Stock, &item.Available, &item.ReorderPoint, &item.ReorderQty)
+}
+
+// lowStockCrossed melaporkan apakah produk baru masuk ke kondisi stok
+// rendah: sebelumnya tidak dipantau atau stoknya di atas reorder point,
+// dan sekarang dipantau dengan stok sama dengan atau di bawahnya.
+func lowStockCrossed(stockBefore, pointBefore, stockAfter, pointAfter int) bool {
+	wasLow := pointBefore > 0 && stockBefore <= pointBefore
+	isLow := pointAfter > 0 && stockAfter <= pointAfter
+	return isLow && !wasLow
+}
+
+// recordLowStock mencatat event stok rendah di transaksi tx. Pemanggil
+// harus memegang lock baris produk supaya dua perubahan stok bersamaan
+// tidak sama-sama melihat stok sebelum batas dilewati.
+func recordLowStock(tx *sql.Tx, productID, stock int) error {
+	_, err := tx.Exec("INSERT INTO low_stock_events (product_id, stock) VALUES ($1, $2)", productID, stock)
+	return err
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLowStockCrossed(t *testing.T) {
+	tests := []struct {
+		name                                             string
+		stockBefore, pointBefore, stockAfter, pointAfter int
+		want                                             bool
+	}{
+		{name: "falls to reorder point", stockBefore: 4, pointBefore: 3, stockAfter: 3, pointAfter: 3, want: true},
+		{name: "falls below reorder point", stockBefore: 10, pointBefore: 3, stockAfter: 0, pointAfter: 3, want: true},
+		{name: "stays above", stockBefore: 10, pointBefore: 3, stockAfter: 4, pointAfter: 3},
+		{name: "already low", stockBefore: 3, pointBefore: 3, stockAfter: 1, pointAfter: 3},
+		{name: "not monitored", stockBefore: 4, stockAfter: 0},
+		{name: "reorder point raised", stockBefore: 5, pointBefore: 3, stockAfter: 5, pointAfter: 5, want: true},
+		{name: "monitoring enabled while low", stockBefore: 2, stockAfter: 2, pointAfter: 3, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lowStockCrossed(tt.stockBefore, tt.pointBefore, tt.stockAfter, tt.pointAfter); got != tt.want {
+				t.Errorf("lowStockCrossed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLowStockEvents(t *testing.T) {
+	db := testDB(t)
+	repo := NewLowStock(db)
+	stock := NewStock(db)
+	product := createProduct(t, db, "Watched Mug", 1000, 10)
+	warehouseID := defaultWarehouse(t, db)
+
+	product.ReorderPoint = 3
+	if err := NewProduct(db).Update(product, nil, "test"); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	// stok turun melewati batas lalu pulih sebelum monitor berjalan
+	for _, delta := range []int{-8, 8, -1} {
+		if _, err := stock.Adjust(product.ID, warehouseID, delta, "test"); err != nil {
+			t.Fatalf("Adjust(%d) error = %v", delta, err)
+		}
+	}
+
+	events, err := repo.Claim(10, time.Minute)
+	if err != nil {
+		t.Fatalf("Claim() error = %v", err)
+	}
+	if len(events) != 1 || events[0].ProductID != product.ID || events[0].Stock != 2 {
+		t.Fatalf("Claim() = %+v, want one event with stock 2", events)
+	}
+	if events[0].ProductName != product.Name || events[0].ReorderPoint != 3 {
+		t.Errorf("Claim() product = %q reorder point %d", events[0].ProductName, events[0].ReorderPoint)
+	}
+
+	// event yang sedang dikirim tidak diambil lagi sebelum lease habis
+	again, err := repo.Claim(10, time.Minute)
+	if err != nil {
+		t.Fatalf("Claim() error = %v", err)
+	}
+	if len(again) != 0 {
+		t.Errorf("Claim() during lease = %d events, want 0", len(again))
+	}
+
+	if err := repo.Done(events[0].ID); err != nil {
+		t.Fatalf("Done() error = %v", err)
+	}
+	var pending int
+	if err := db.QueryRow("SELECT COUNT(*) FROM low_stock_events").Scan(&pending); err != nil {
+		t.Fatalf("count events: %v", err)
+	}
+	if pending != 0 {
+		t.Errorf("pending events = %d, want 0", pending)
+	}
+}
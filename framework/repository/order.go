@@ -0,0 +1,331 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-boot-category-api/model"
+	"slices"
+	"strings"
+)
+
+var (
+	// ErrMixedCurrency dikembalikan jika produk dalam satu pesanan memakai
+	// mata uang yang berbeda.
+	ErrMixedCurrency = errors.New("mata uang produk dalam satu pesanan harus sama")
+	// ErrInvalidTransition di-wrap jika perubahan status tidak diizinkan
+	// dari status saat ini.
+	ErrInvalidTransition = errors.New("perubahan status tidak valid")
+)
+
+type Order interface {
+	GetAll(status string, page model.Pagination) (*model.OrderPage, error)
+	GetByID(id int64) (*model.Order, error)
+	Create(order *model.Order) error
+	Transition(id int64, status string, actor string) (*model.Order, error)
+}
+
+type orderRepo struct {
+	db *sql.DB
+}
+
+func NewOrder(db *sql.DB) Order {
+	return &orderRepo{db: db}
+}
+
+const orderColumns = "id, status, customer, note, total_amount, currency, actor, created_at, updated_at"
+
+func scanOrder(row rowScanner) (*model.Order, error) {
+	var o model.Order
+	err := row.Scan(&o.ID, &o.Status, &o.Customer, &o.Note, &o.Total.Amount, &o.Total.Currency, &o.Actor, &o.CreatedAt, &o.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("pesanan %w", ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+// queryer adalah *sql.DB atau *sql.Tx
+type queryer interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// attachOrderLines mengisi Lines semua pesanan dengan satu query
+func attachOrderLines(db queryer, orders []model.Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+
+	index := make(map[int64]int, len(orders))
+	placeholders := make([]string, len(orders))
+	args := make([]interface{}, len(orders))
+	for i := range orders {
+		orders[i].Lines = make([]model.OrderLine, 0)
+		index[orders[i].ID] = i
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = orders[i].ID
+	}
+
+	query := `SELECT order_id, id, product_id, product_name, warehouse_id, quantity, unit_price_amount, currency
+    FROM order_lines WHERE order_id IN (` + strings.Join(placeholders, ", ") + `) ORDER BY order_id, id`
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var orderID int64
+		var line model.OrderLine
+		var productID sql.NullInt32
+		err := rows.Scan(&orderID, &line.ID, &productID, &line.ProductName, &line.WarehouseID, &line.Quantity, &line.UnitPrice.Amount, &line.UnitPrice.Currency)
+		if err != nil {
+			return err
+		}
+		if productID.Valid {
+			id := int(productID.Int32)
+			line.ProductID = &id
+		}
+		line.Total = model.Money{Amount: line.UnitPrice.Amount * int64(line.Quantity), Currency: line.UnitPrice.Currency}
+		i := index[orderID]
+		orders[i].Lines = append(orders[i].Lines, line)
+	}
+
+	return rows.Err()
+}
+
+// GetAll - satu halaman pesanan terbaru lebih dulu, difilter status jika
+// diisi
+func (repo *orderRepo) GetAll(status string, page model.Pagination) (*model.OrderPage, error) {
+	where := ""
+	var args []interface{}
+	if status != "" {
+		where = " WHERE status = $1"
+		args = append(args, status)
+	}
+
+	result := &model.OrderPage{Page: page.Page, PerPage: page.PerPage, Items: make([]model.Order, 0)}
+	if err := repo.db.QueryRow("SELECT COUNT(*) FROM orders"+where, args...).Scan(&result.Total); err != nil {
+		return nil, err
+	}
+
+	args = append(args, page.PerPage, page.Offset())
+	query := "SELECT " + orderColumns + " FROM orders" + where + fmt.Sprintf(" ORDER BY id DESC LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		o, err := scanOrder(rows)
+		if err != nil {
+			return nil, err
+		}
+		result.Items = append(result.Items, *o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := attachOrderLines(repo.db, result.Items); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (repo *orderRepo) GetByID(id int64) (*model.Order, error) {
+	order, err := scanOrder(repo.db.QueryRow("SELECT "+orderColumns+" FROM orders WHERE id = $1", id))
+	if err != nil {
+		return nil, err
+	}
+	orders := []model.Order{*order}
+	if err := attachOrderLines(repo.db, orders); err != nil {
+		return nil, err
+	}
+	return &orders[0], nil
+}
+
+// Create - simpan pesanan dan kurangi stok setiap baris dalam satu
+// transaksi. Baris produk dikunci berurutan berdasarkan ID sebelum stok
+// diperiksa, sehingga pesanan yang berjalan bersamaan tidak bisa menjual
+// unit yang sama dan tidak saling deadlock. Jika ada satu baris yang
+// stoknya kurang, seluruh pesanan dibatalkan. Nama dan harga produk
+// disalin dari baris yang sudah dikunci. Baris dengan WarehouseID 0
+// mengambil stok dari gudang default.
+func (repo *orderRepo) Create(order *model.Order) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var ids []int
+	for _, line := range order.Lines {
+		if !slices.Contains(ids, *line.ProductID) {
+			ids = append(ids, *line.ProductID)
+		}
+	}
+	slices.Sort(ids)
+	products, err := lockOrderProducts(tx, ids)
+	if err != nil {
+		return err
+	}
+
+	var defaultWarehouse int
+	order.Total = model.Money{}
+	for i := range order.Lines {
+		line := &order.Lines[i]
+		product := products[*line.ProductID]
+		if order.Total.Currency == "" {
+			order.Total.Currency = product.Price.Currency
+		}
+		if product.Price.Currency != order.Total.Currency {
+			return ErrMixedCurrency
+		}
+		if line.WarehouseID == 0 {
+			if defaultWarehouse == 0 {
+				if defaultWarehouse, err = defaultWarehouseID(tx); err != nil {
+					return err
+				}
+			}
+			line.WarehouseID = defaultWarehouse
+		}
+		line.ProductName = product.Name
+		line.UnitPrice = product.Price
+		line.Total = model.Money{Amount: product.Price.Amount * int64(line.Quantity), Currency: product.Price.Currency}
+		order.Total.Amount += line.Total.Amount
+	}
+
+	query := `INSERT INTO orders (customer, note, total_amount, currency, actor)
+    VALUES ($1, $2, $3, $4, $5) RETURNING ` + orderColumns
+	created, err := scanOrder(tx.QueryRow(query, order.Customer, order.Note, order.Total.Amount, order.Total.Currency, order.Actor))
+	if err != nil {
+		return err
+	}
+	created.Lines = order.Lines
+	*order = *created
+
+	for i := range order.Lines {
+		line := &order.Lines[i]
+		// stok tersedia dihitung ulang per baris, sehingga produk yang muncul
+		// di beberapa baris ikut dihitung dari pengurangan baris sebelumnya
+		available, err := availableStock(tx, *line.ProductID, line.WarehouseID)
+		if err != nil {
+			return err
+		}
+		if available < line.Quantity {
+			return fmt.Errorf("produk %d: %w", *line.ProductID, ErrInsufficientStock)
+		}
+		if _, err := adjustWarehouseStock(tx, *line.ProductID, line.WarehouseID, -line.Quantity, model.LedgerOrder, &order.ID, order.Actor); err != nil {
+			return fmt.Errorf("produk %d: %w", *line.ProductID, err)
+		}
+
+		query := `INSERT INTO order_lines (order_id, product_id, product_name, warehouse_id, quantity, unit_price_amount, currency)
+        VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+		err = tx.QueryRow(query, order.ID, *line.ProductID, line.ProductName, line.WarehouseID, line.Quantity, line.UnitPrice.Amount, line.UnitPrice.Currency).
+			Scan(&line.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// lockOrderProducts mengunci baris produk ids (sudah urut) dan
+// mengembalikan nama serta harganya
+func lockOrderProducts(tx *sql.Tx, ids []int) (map[int]model.Product, error) {
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = id
+	}
+
+	query := `SELECT id, name, price_amount, currency FROM products
+    WHERE id IN (` + strings.Join(placeholders, ", ") + `) ORDER BY id FOR UPDATE`
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products := make(map[int]model.Product, len(ids))
+	for rows.Next() {
+		var p model.Product
+		if err := rows.Scan(&p.ID, &p.Name, &p.Price.Amount, &p.Price.Currency); err != nil {
+			return nil, err
+		}
+		products[p.ID] = p
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		if _, ok := products[id]; !ok {
+			return nil, fmt.Errorf("produk %d %w", id, ErrNotFound)
+		}
+	}
+	return products, nil
+}
+
+// Transition - ubah status pesanan sesuai model.CanTransitionOrder. Baris
+// pesanan dikunci supaya dua perubahan status yang bersamaan tidak sama-sama
+// lolos. Pembatalan mengembalikan stok setiap baris ke gudang asalnya,
+// kecuali baris yang produknya sudah dihapus (product_id null).
+func (repo *orderRepo) Transition(id int64, status string, actor string) (*model.Order, error) {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	order, err := scanOrder(tx.QueryRow("SELECT "+orderColumns+" FROM orders WHERE id = $1 FOR UPDATE", id))
+	if err != nil {
+		return nil, err
+	}
+	if !model.CanTransitionOrder(order.Status, status) {
+		return nil, fmt.Errorf("pesanan berstatus %s tidak bisa diubah menjadi %s: %w", order.Status, status, ErrInvalidTransition)
+	}
+
+	orders := []model.Order{*order}
+	if err := attachOrderLines(tx, orders); err != nil {
+		return nil, err
+	}
+	lines := orders[0].Lines
+
+	if status == model.OrderCancelled {
+		var ids []int
+		for _, line := range lines {
+			if line.ProductID != nil && !slices.Contains(ids, *line.ProductID) {
+				ids = append(ids, *line.ProductID)
+			}
+		}
+		slices.Sort(ids)
+		for _, productID := range ids {
+			if err := lockProduct(tx, productID); err != nil {
+				return nil, err
+			}
+			for _, line := range lines {
+				if line.ProductID == nil || *line.ProductID != productID {
+					continue
+				}
+				if _, err := adjustWarehouseStock(tx, productID, line.WarehouseID, line.Quantity, model.LedgerOrderCancel, &order.ID, actor); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	query := "UPDATE orders SET status = $1, updated_at = now() WHERE id = $2 RETURNING " + orderColumns
+	order, err = scanOrder(tx.QueryRow(query, status, id))
+	if err != nil {
+		return nil, err
+	}
+	order.Lines = lines
+	return order, tx.Commit()
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"go-boot-category-api/framework/cache"
+	"go-boot-category-api/model"
+)
+
+type cachedOrderRepo struct {
+	next  Order
+	cache cache.Cache
+}
+
+// NewCachedOrder membungkus repository pesanan supaya pembuatan dan
+// pembatalan pesanan menghapus cache produk dan kategori yang stoknya
+// berubah. Pesanan sendiri tidak di-cache.
+func NewCachedOrder(next Order, c cache.Cache) Order {
+	return &cachedOrderRepo{next: next, cache: c}
+}
+
+func (repo *cachedOrderRepo) GetAll(status string, page model.Pagination) (*model.OrderPage, error) {
+	return repo.next.GetAll(status, page)
+}
+
+func (repo *cachedOrderRepo) GetByID(id int64) (*model.Order, error) {
+	return repo.next.GetByID(id)
+}
+
+func (repo *cachedOrderRepo) Create(order *model.Order) error {
+	if err := repo.next.Create(order); err != nil {
+		return err
+	}
+	repo.invalidate(order)
+	return nil
+}
+
+func (repo *cachedOrderRepo) Transition(id int64, status string, actor string) (*model.Order, error) {
+	order, err := repo.next.Transition(id, status, actor)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status == model.OrderCancelled {
+		repo.invalidate(order)
+	}
+	return order, nil
+}
+
+func (repo *cachedOrderRepo) invalidate(order *model.Order) {
+	for _, line := range order.Lines {
+		if line.ProductID != nil {
+			repo.cache.Delete(productCacheKey(*line.ProductID))
+		}
+	}
+	repo.cache.DeletePrefix(categoryCachePrefix)
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"errors"
+	"go-boot-category-api/model"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newOrder(productID, quantity int) *model.Order {
+	return &model.Order{
+		Actor: "test",
+		Lines: []model.OrderLine{{ProductID: &productID, Quantity: quantity}},
+	}
+}
+
+func TestOrderCreateConcurrent(t *testing.T) {
+	db := testDB(t)
+	repo := NewOrder(db)
+	product := createProduct(t, db, "Limited Shirt", 1000, 5)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 10)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = repo.Create(newOrder(product.ID, 1))
+		}(i)
+	}
+	wg.Wait()
+
+	created := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			created++
+		case !errors.Is(err, ErrInsufficientStock):
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	if created != 5 {
+		t.Errorf("%d orders succeeded, want 5", created)
+	}
+	expectStock(t, db, product.ID, 0)
+	if got := ledgerSum(t, db, product.ID, model.LedgerOrder); got != -5 {
+		t.Errorf("order ledger = %d, want -5", got)
+	}
+}
+
+func TestOrderCreateRespectsReservations(t *testing.T) {
+	db := testDB(t)
+	repo := NewOrder(db)
+	product := createProduct(t, db, "Held Shirt", 1000, 5)
+
+	if err := NewReservation(db).Create(&model.Reservation{ProductID: &product.ID, Quantity: 3, Actor: "test"}, time.Hour); err != nil {
+		t.Fatalf("reserve: %v", err)
+	}
+
+	if err := repo.Create(newOrder(product.ID, 3)); !errors.Is(err, ErrInsufficientStock) {
+		t.Fatalf("Create() over available error = %v, want ErrInsufficientStock", err)
+	}
+	expectStock(t, db, product.ID, 5)
+
+	// produk yang sama di dua baris dihitung bersama
+	order := newOrder(product.ID, 1)
+	order.Lines = append(order.Lines, order.Lines[0], order.Lines[0])
+	if err := repo.Create(order); !errors.Is(err, ErrInsufficientStock) {
+		t.Fatalf("Create() with repeated lines error = %v, want ErrInsufficientStock", err)
+	}
+
+	order = newOrder(product.ID, 2)
+	if err := repo.Create(order); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if order.Total.Amount != 2000 || order.Status != model.OrderPending {
+		t.Errorf("Create() = total %d status %s, want 2000 pending", order.Total.Amount, order.Status)
+	}
+	expectStock(t, db, product.ID, 3)
+}
+
+func TestOrderTransitionCancelRestocks(t *testing.T) {
+	db := testDB(t)
+	repo := NewOrder(db)
+	product := createProduct(t, db, "Returned Shirt", 1000, 5)
+
+	order := newOrder(product.ID, 4)
+	if err := repo.Create(order); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	expectStock(t, db, product.ID, 1)
+
+	if _, err := repo.Transition(order.ID, model.OrderPaid, "test"); err != nil {
+		t.Fatalf("Transition(paid) error = %v", err)
+	}
+	cancelled, err := repo.Transition(order.ID, model.OrderCancelled, "test")
+	if err != nil {
+		t.Fatalf("Transition(cancelled) error = %v", err)
+	}
+	if cancelled.Status != model.OrderCancelled {
+		t.Errorf("status = %s, want %s", cancelled.Status, model.OrderCancelled)
+	}
+	expectStock(t, db, product.ID, 5)
+	if got := ledgerSum(t, db, product.ID, model.LedgerOrderCancel); got != 4 {
+		t.Errorf("order_cancel ledger = %d, want 4", got)
+	}
+
+	// pembatalan kedua ditolak dan tidak mengembalikan stok lagi
+	if _, err := repo.Transition(order.ID, model.OrderCancelled, "test"); !errors.Is(err, ErrInvalidTransition) {
+		t.Fatalf("second cancel error = %v, want ErrInvalidTransition", err)
+	}
+	expectStock(t, db, product.ID, 5)
+}
+
+func TestOrderTransitionShippedCannotCancel(t *testing.T) {
+	db := testDB(t)
+	repo := NewOrder(db)
+	product := createProduct(t, db, "Shipped Shirt", 1000, 2)
+
+	order := newOrder(product.ID, 2)
+	if err := repo.Create(order); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	for _, status := range []string{model.OrderPaid, model.OrderShipped} {
+		if _, err := repo.Transition(order.ID, status, "test"); err != nil {
+			t.Fatalf("Transition(%s) error = %v", status, err)
+		}
+	}
+	if _, err := repo.Transition(order.ID, model.OrderCancelled, "test"); !errors.Is(err, ErrInvalidTransition) {
+		t.Fatalf("cancel shipped error = %v, want ErrInvalidTransition", err)
+	}
+	expectStock(t, db, product.ID, 0)
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"go-boot-category-api/model"
+	"time"
+)
+
+type Price interface {
+	History(productID int) ([]model.PriceChange, error)
+	At(productID int, at time.Time) (*model.PriceChange, error)
+	Scheduled(productID int) ([]model.ScheduledPrice, error)
+	GetScheduled(productID int, id int64) (*model.ScheduledPrice, error)
+	Schedule(price *model.ScheduledPrice) error
+	Cancel(productID int, id int64) error
+}
+
+type priceRepo struct {
+	db *sql.DB
+}
+
+func NewPrice(db *sql.DB) Price {
+	return &priceRepo{db: db}
+}
+
+const priceChangeColumns = `id, product_id, price_amount, currency, effective_at, actor, created_at`
+
+func scanPriceChange(row rowScanner) (*model.PriceChange, error) {
+	var c model.PriceChange
+	err := row.Scan(&c.ID, &c.ProductID, &c.Price.Amount, &c.Price.Currency, &c.EffectiveAt, &c.Actor, &c.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+const scheduledPriceColumns = `id, product_id, price_amount, currency, effective_at, actor, status, applied_at, created_at`
+
+func scanScheduledPrice(row rowScanner) (*model.ScheduledPrice, error) {
+	var sp model.ScheduledPrice
+	var appliedAt sql.NullTime
+	err := row.Scan(&sp.ID, &sp.ProductID, &sp.Price.Amount, &sp.Price.Currency, &sp.EffectiveAt, &sp.Actor, &sp.Status, &appliedAt, &sp.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if appliedAt.Valid {
+		sp.AppliedAt = &appliedAt.Time
+	}
+	return &sp, nil
+}
+
+// History - riwayat harga produk, terbaru lebih dulu
+func (repo *priceRepo) History(productID int) ([]model.PriceChange, error) {
+	query := `SELECT ` + priceChangeColumns + ` FROM price_history
+    WHERE product_id = $1 ORDER BY effective_at DESC, id DESC`
+	rows, err := repo.db.Query(query, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := make([]model.PriceChange, 0)
+	for rows.Next() {
+		c, err := scanPriceChange(rows)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, *c)
+	}
+
+	return history, rows.Err()
+}
+
+// At - harga yang berlaku untuk produk pada waktu at
+func (repo *priceRepo) At(productID int, at time.Time) (*model.PriceChange, error) {
+	query := `SELECT ` + priceChangeColumns + ` FROM price_history
+    WHERE product_id = $1 AND effective_at <= $2
+    ORDER BY effective_at DESC, id DESC LIMIT 1`
+	c, err := scanPriceChange(repo.db.QueryRow(query, productID, at))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("harga produk pada %s %w", at.UTC().Format(time.RFC3339), ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Scheduled - harga terjadwal produk yang belum diterapkan, urut waktu berlaku
+func (repo *priceRepo) Scheduled(productID int) ([]model.ScheduledPrice, error) {
+	query := `SELECT ` + scheduledPriceColumns + ` FROM scheduled_prices
+    WHERE product_id = $1 AND status = 'pending' ORDER BY effective_at, id`
+	rows, err := repo.db.Query(query, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	prices := make([]model.ScheduledPrice, 0)
+	for rows.Next() {
+		sp, err := scanScheduledPrice(rows)
+		if err != nil {
+			return nil, err
+		}
+		prices = append(prices, *sp)
+	}
+
+	return prices, rows.Err()
+}
+
+func (repo *priceRepo) GetScheduled(productID int, id int64) (*model.ScheduledPrice, error) {
+	query := `SELECT ` + scheduledPriceColumns + ` FROM scheduled_prices WHERE product_id = $1 AND id = $2`
+	sp, err := scanScheduledPrice(repo.db.QueryRow(query, productID, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("harga terjadwal %w", ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return sp, nil
+}
+
+func (repo *priceRepo) Schedule(price *model.ScheduledPrice) error {
+	query := `INSERT INTO scheduled_prices (product_id, price_amount, currency, effective_at, actor)
+    VALUES ($1, $2, $3, $4, $5) RETURNING id, status, created_at`
+	return repo.db.QueryRow(query, price.ProductID, price.Price.Amount, price.Price.Currency, price.EffectiveAt, price.Actor).
+		Scan(&price.ID, &price.Status, &price.CreatedAt)
+}
+
+// Cancel - batalkan harga terjadwal yang belum diterapkan
+func (repo *priceRepo) Cancel(productID int, id int64) error {
+	query := "UPDATE scheduled_prices SET status = 'cancelled' WHERE product_id = $1 AND id = $2 AND status = 'pending'"
+	result, err := repo.db.Exec(query, productID, id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("harga terjadwal %w", ErrNotFound)
+	}
+
+	return nil
+}
+
+// recordPrice mencatat harga produk yang berlaku sejak effectiveAt
+func recordPrice(tx *sql.Tx, productID int, price model.Money, effectiveAt time.Time, actor string) error {
+	query := `INSERT INTO price_history (product_id, price_amount, currency, effective_at, actor)
+    VALUES ($1, $2, $3, $4, $5)`
+	_, err := tx.Exec(query, productID, price.Amount, price.Currency, effectiveAt, actor)
+	return err
+}
@@ -2,18 +2,46 @@ package repository
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"go-boot-category-api/model"
+	"slices"
+	"time"
 )
 
 type Product interface {
-	GetAll() ([]model.Product, error)
+	GetAll(filter model.ProductFilter) ([]model.Product, error)
+	GetPage(filter model.ProductFilter, page model.Pagination) (*model.ProductPage, error)
 	GetByID(id int) (*model.Product, error)
-	Create(product *model.Product) error
-	Update(product *model.Product) error
+	GetByIDWithVariants(id int) (*model.Product, error)
+	Create(product *model.Product, actor string) error
+	Update(product *model.Product, stock *int, actor string) error
 	Delete(id int) error
+	CountByCategory(categoryID int) (int, error)
+	ApplyScheduledPrices(now time.Time) ([]int, error)
+	AddTags(productID int, names []string) error
+	RemoveTags(productID int, names []string) error
+	GetBySlug(slug string) (*model.Product, error)
+	SlugExists(slug string, excludeID int) (bool, error)
 }
 
+// ErrStockMismatch di-wrap jika stok yang dikirim saat update produk
+// berbeda dengan stok saat ini.
+var ErrStockMismatch = errors.New("stok tidak sama dengan stok saat ini")
+
+// ErrModified di-wrap jika produk sudah berubah setelah versi yang
+// dibaca pemanggil.
+var ErrModified = errors.New("produk sudah berubah sejak dibaca")
+
+// ErrCurrencyInUse di-wrap jika mata uang produk diganti padahal masih
+// ada nilai yang tersimpan dalam mata uang lama.
+var ErrCurrencyInUse = errors.New("mata uang produk masih dipakai")
+
+// ErrStockOnHand dikembalikan jika produk yang akan dihapus masih punya
+// stok on-hand atau reservasi active.
+var ErrStockOnHand = errors.New("produk masih punya stok atau reservasi active")
+
 type productRepo struct {
 	db *sql.DB
 }
@@ -22,17 +50,75 @@ func NewProduct(db *sql.DB) *productRepo {
 	return &productRepo{db: db}
 }
 
-func (repo *productRepo) GetAll() ([]model.Product, error) {
-	query := `SELECT
+// productSelect adalah SELECT produk standar yang dibaca oleh scanProduct
+const productSelect = `SELECT
         p.id,
         p.name,
-        p.price,
-        p.stock,
+        p.slug,
+        p.price_amount,
+        p.currency,
+        ` + productStockColumn + ` AS stock,
+        ` + productAvailableColumn + ` AS available,
+        p.reorder_point,
+        p.reorder_qty,
+        p.cost_amount,
         c.id AS category_id,
-        c.name AS category_name
+        c.name AS category_name,
+        p.attributes,
+        ` + productTagsColumn + `,
+        GREATEST(p.updated_at, c.updated_at) AS updated_at
     FROM products p
     JOIN categories c ON p.category_id = c.id`
-	rows, err := repo.db.Query(query)
+
+func (repo *productRepo) GetAll(filter model.ProductFilter) ([]model.Product, error) {
+	selectSQL, scan, err := repo.projection(filter)
+	if err != nil {
+		return nil, err
+	}
+	where, args, err := productFilterSQL(filter)
+	if err != nil {
+		return nil, err
+	}
+	return repo.query(scan, selectSQL+where+" ORDER BY p.id", args...)
+}
+
+// GetPage - satu halaman produk yang cocok dengan filter, beserta jumlah
+// seluruh produk yang cocok
+func (repo *productRepo) GetPage(filter model.ProductFilter, page model.Pagination) (*model.ProductPage, error) {
+	selectSQL, scan, err := repo.projection(filter)
+	if err != nil {
+		return nil, err
+	}
+	where, args, err := productFilterSQL(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.ProductPage{Page: page.Page, PerPage: page.PerPage}
+	if err := repo.db.QueryRow("SELECT COUNT(*) FROM products p"+where, args...).Scan(&result.Total); err != nil {
+		return nil, err
+	}
+
+	args = append(args, page.PerPage, page.Offset())
+	query := selectSQL + where + fmt.Sprintf(" ORDER BY p.id LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+	result.Items, err = repo.query(scan, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// projection memilih SELECT standar, atau SELECT yang hanya membaca
+// filter.Fields jika diisi
+func (repo *productRepo) projection(filter model.ProductFilter) (string, func(row rowScanner) (*model.Product, error), error) {
+	if len(filter.Fields) == 0 {
+		return productSelect, scanProduct, nil
+	}
+	return productProjection(filter.Fields)
+}
+
+func (repo *productRepo) query(scan func(row rowScanner) (*model.Product, error), query string, args ...interface{}) ([]model.Product, error) {
+	rows, err := repo.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -40,102 +126,428 @@ func (repo *productRepo) GetAll() ([]model.Product, error) {
 
 	products := make([]model.Product, 0)
 	for rows.Next() {
-		var p model.Product
-		err := rows.Scan(&p.ID, &p.Name, &p.Price, &p.Stock, &p.CategoryId, &p.CategoryName)
+		p, err := scan(rows)
 		if err != nil {
 			return nil, err
 		}
-		products = append(products, p)
+		products = append(products, *p)
 	}
 
-	return products, nil
+	return products, rows.Err()
 }
 
-func (repo *productRepo) Create(product *model.Product) error {
-	err := repo.checkCategoryExists(product.CategoryId)
+// productTagsColumn adalah nama-nama tag produk sebagai JSON array, urut
+// berdasarkan nama.
+const productTagsColumn = `COALESCE((
+            SELECT json_agg(t.name ORDER BY t.name)
+            FROM product_tags pt JOIN tags t ON t.id = pt.tag_id
+            WHERE pt.product_id = p.id
+        ), '[]') AS tags`
+
+// scanProduct membaca satu baris hasil query SELECT produk standar
+func scanProduct(row rowScanner) (*model.Product, error) {
+	var p model.Product
+	var attributes, tags []byte
+	err := row.Scan(&p.ID, &p.Name, &p.Slug, &p.Price.Amount, &p.Price.Currency, &p.Stock, &p.Available, &p.ReorderPoint, &p.ReorderQty, &p.CostPrice.Amount, &p.CategoryId, &p.CategoryName, &attributes, &tags, &p.UpdatedAt)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	p.CostPrice.Currency = p.Price.Currency
+	if err := json.Unmarshal(attributes, &p.Attributes); err != nil {
+		return nil, err
 	}
-	query := "INSERT INTO products (name, price, stock,category_id) VALUES ($1, $2, $3, $4) RETURNING id"
-	err = repo.db.QueryRow(query, product.Name, product.Price, product.Stock, product.CategoryId).Scan(&product.ID)
-	return err
+	if err := json.Unmarshal(tags, &p.Tags); err != nil {
+		return nil, err
+	}
+	return &p, nil
 }
 
-func (repo *productRepo) checkCategoryExists(id int) error {
-	query := `SELECT EXISTS(SELECT 1 FROM categories WHERE id = $1) AS category_exists`
-	result := false
-	err := repo.db.QueryRow(query, id).Scan(&result)
-	if err == sql.ErrNoRows || !result {
-		return errors.New("category tidak ditemukan")
+// Create - simpan produk baru, catat harga awalnya di price_history atas
+// nama actor, dan simpan stok awalnya di gudang default
+func (repo *productRepo) Create(product *model.Product, actor string) error {
+	attributes, err := json.Marshal(product.Attributes)
+	if err != nil {
+		return err
 	}
+
+	tx, err := repo.db.Begin()
 	if err != nil {
 		return err
 	}
-	return nil
+	defer tx.Rollback()
+
+	query := `INSERT INTO products (name, slug, price_amount, currency, category_id, attributes, reorder_point, reorder_qty)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id, updated_at`
+	err = tx.QueryRow(query, product.Name, product.Slug, product.Price.Amount, product.Price.Currency, product.CategoryId, attributes,
+		product.ReorderPoint, product.ReorderQty).Scan(&product.ID, &product.UpdatedAt)
+	if err != nil {
+		return duplicateError(err, "produk")
+	}
+
+	if err := recordPrice(tx, product.ID, product.Price, product.UpdatedAt, actor); err != nil {
+		return err
+	}
+	if product.Stock > 0 {
+		warehouseID, err := defaultWarehouseID(tx)
+		if err != nil {
+			return err
+		}
+		if _, err := adjustWarehouseStock(tx, product.ID, warehouseID, product.Stock, model.LedgerAdjustment, nil, actor); err != nil {
+			return err
+		}
+	}
+	// produk baru yang stok awalnya sudah rendah langsung dinotifikasi
+	if lowStockCrossed(0, 0, product.Stock, product.ReorderPoint) {
+		if err := recordLowStock(tx, product.ID, product.Stock); err != nil {
+			return err
+		}
+	}
+	// produk baru belum punya reservasi dan harga pokok
+	product.Available = product.Stock
+	product.CostPrice = model.Money{Currency: product.Price.Currency}
+	return tx.Commit()
 }
 
 // GetByID - ambil produk by ID
 func (repo *productRepo) GetByID(id int) (*model.Product, error) {
-	query := `SELECT
-        p.id,
-        p.name,
-        p.price,
-        p.stock,
-        c.id AS category_id,
-        c.name AS category_name
-    FROM products p
-    JOIN categories c ON p.category_id = c.id WHERE p.id = $1`
+	query := productSelect + " WHERE p.id = $1"
 
-	var p model.Product
-	err := repo.db.QueryRow(query, id).Scan(&p.ID, &p.Name, &p.Price, &p.Stock, &p.CategoryId, &p.CategoryName)
+	p, err := scanProduct(repo.db.QueryRow(query, id))
 	if err == sql.ErrNoRows {
-		return nil, errors.New("produk tidak ditemukan")
+		return nil, fmt.Errorf("produk %w", ErrNotFound)
 	}
 	if err != nil {
 		return nil, err
 	}
 
-	return &p, nil
+	return p, nil
 }
 
-func (repo *productRepo) Update(product *model.Product) error {
-	err := repo.checkCategoryExists(product.CategoryId)
+// GetByIDWithVariants - ambil produk by ID beserta semua variannya
+func (repo *productRepo) GetByIDWithVariants(id int) (*model.Product, error) {
+	product, err := repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	product.Variants, err = NewVariant(repo.db).GetByProduct(id)
+	if err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+// Update - simpan perubahan produk. Jika slug berubah, slug lama dicatat
+// di product_slug_history supaya URL lama masih bisa di-redirect. Jika
+// harga berubah, harga baru dicatat di price_history atas nama actor.
+// Stok tidak diubah; jika stock tidak nil, nilainya dibandingkan dengan
+// stok saat ini setelah baris produk dikunci. Jika product.UpdatedAt
+// diisi, update ditolak dengan ErrModified kalau produk sudah diubah
+// setelah waktu itu, supaya perubahan yang dihitung dari data lama tidak
+// menimpa perubahan yang lebih baru. Mata uang hanya bisa diganti jika
+// lolos checkCurrencyChange.
+func (repo *productRepo) Update(product *model.Product, stock *int, actor string) error {
+	attributes, err := json.Marshal(product.Attributes)
 	if err != nil {
 		return err
 	}
 
-	query := "UPDATE products SET name = $1, price = $2, stock = $3, category_id = $4 WHERE id = $5"
-	result, err := repo.db.Exec(query, product.Name, product.Price, product.Stock, product.CategoryId, product.ID)
+	tx, err := repo.db.Begin()
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
 
-	rows, err := result.RowsAffected()
+	var oldSlug string
+	var oldPrice model.Money
+	var oldPoint int
+	var updatedAt time.Time
+	err = tx.QueryRow("SELECT slug, price_amount, currency, reorder_point, updated_at FROM products WHERE id = $1 FOR UPDATE", product.ID).
+		Scan(&oldSlug, &oldPrice.Amount, &oldPrice.Currency, &oldPoint, &updatedAt)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("produk %w", ErrNotFound)
+	}
 	if err != nil {
 		return err
 	}
+	if !product.UpdatedAt.IsZero() && updatedAt.After(product.UpdatedAt) {
+		return ErrModified
+	}
+	if oldPrice.Currency != product.Price.Currency {
+		if err := checkCurrencyChange(tx, product.ID); err != nil {
+			return err
+		}
+	}
 
-	if rows == 0 {
-		return errors.New("produk tidak ditemukan")
+	err = tx.QueryRow("SELECT "+productStockColumn+" FROM products p WHERE p.id = $1", product.ID).Scan(&product.Stock)
+	if err != nil {
+		return err
+	}
+	if stock != nil && *stock != product.Stock {
+		return fmt.Errorf("%w (%d)", ErrStockMismatch, product.Stock)
+	}
+
+	// harga pokok dalam mata uang lama tidak berlaku lagi jika mata uang
+	// diganti
+	query := `UPDATE products SET name = $1, slug = $2, price_amount = $3, currency = $4, category_id = $5, attributes = $6,
+        reorder_point = $7, reorder_qty = $8, updated_at = now(),
+        cost_amount = CASE WHEN currency = $4 THEN cost_amount ELSE 0 END
+    WHERE id = $9 RETURNING updated_at`
+	err = tx.QueryRow(query, product.Name, product.Slug, product.Price.Amount, product.Price.Currency, product.CategoryId, attributes,
+		product.ReorderPoint, product.ReorderQty, product.ID).Scan(&product.UpdatedAt)
+	if err != nil {
+		return duplicateError(err, "produk")
 	}
 
+	if oldSlug != product.Slug {
+		if err := moveSlugToHistory(tx, "product_slug_history", "product_id", product.ID, oldSlug, product.Slug); err != nil {
+			return err
+		}
+	}
+	if oldPrice != product.Price {
+		if err := recordPrice(tx, product.ID, product.Price, product.UpdatedAt, actor); err != nil {
+			return err
+		}
+	}
+	// menaikkan reorder point sampai stok saat ini sudah rendah juga
+	// dinotifikasi
+	if lowStockCrossed(product.Stock, oldPoint, product.Stock, product.ReorderPoint) {
+		if err := recordLowStock(tx, product.ID, product.Stock); err != nil {
+			return err
+		}
+	}
+	err = tx.QueryRow("SELECT "+productAvailableColumn+", p.cost_amount FROM products p WHERE p.id = $1", product.ID).
+		Scan(&product.Available, &product.CostPrice.Amount)
+	if err != nil {
+		return err
+	}
+	product.CostPrice.Currency = product.Price.Currency
+	return tx.Commit()
+}
+
+// checkCurrencyChange mengembalikan ErrCurrencyInUse jika produk masih
+// punya nilai dalam mata uang lamanya, yaitu stok on-hand, nilai
+// persediaan, lapisan FIFO yang belum habis, atau harga override varian,
+// sehingga mata uangnya tidak bisa diganti tanpa mengonversi nilai
+// tersebut. Pemanggil harus sudah mengunci baris produk.
+func checkCurrencyChange(tx *sql.Tx, productID int) error {
+	var stock int
+	var value int64
+	var layers, priced bool
+	query := `SELECT ` + productStockColumn + `, p.inventory_value,
+        EXISTS (SELECT 1 FROM cost_layers WHERE product_id = p.id AND remaining > 0),
+        EXISTS (SELECT 1 FROM product_variants WHERE product_id = p.id AND price_amount IS NOT NULL)
+    FROM products p WHERE p.id = $1`
+	if err := tx.QueryRow(query, productID).Scan(&stock, &value, &layers, &priced); err != nil {
+		return err
+	}
+	switch {
+	case stock > 0 || value != 0 || layers:
+		return fmt.Errorf("%w: produk masih punya stok atau nilai persediaan", ErrCurrencyInUse)
+	case priced:
+		return fmt.Errorf("%w: varian punya harga override", ErrCurrencyInUse)
+	}
 	return nil
 }
 
+// Delete - hapus produk. Riwayat stoknya tetap disimpan tanpa product_id,
+// sehingga produk yang masih punya stok on-hand atau reservasi active
+// ditolak dengan ErrStockOnHand; stok harus disesuaikan ke 0 dulu supaya
+// nilai persediaannya keluar lewat ledger. Reservasi active yang sudah
+// lewat expires_at ditandai expired.
 func (repo *productRepo) Delete(id int) error {
-	query := "DELETE FROM products WHERE id = $1"
-	result, err := repo.db.Exec(query, id)
+	tx, err := repo.db.Begin()
 	if err != nil {
 		return err
 	}
-	rows, err := result.RowsAffected()
+	defer tx.Rollback()
+
+	if err := lockProduct(tx, id); err != nil {
+		return err
+	}
+	_, err = tx.Exec(`UPDATE stock_reservations SET status = 'expired', updated_at = now()
+    WHERE product_id = $1 AND status = 'active' AND expires_at <= now()`, id)
 	if err != nil {
 		return err
 	}
+	var inUse bool
+	query := `SELECT EXISTS (SELECT 1 FROM warehouse_stock WHERE product_id = $1 AND quantity > 0)
+        OR EXISTS (SELECT 1 FROM stock_reservations WHERE product_id = $1 AND status = 'active')`
+	if err := tx.QueryRow(query, id).Scan(&inUse); err != nil {
+		return err
+	}
+	if inUse {
+		return ErrStockOnHand
+	}
+
+	if _, err := tx.Exec("DELETE FROM products WHERE id = $1", id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// CountByCategory - jumlah produk dalam satu kategori
+func (repo *productRepo) CountByCategory(categoryID int) (int, error) {
+	query := "SELECT COUNT(*) FROM products WHERE category_id = $1"
+	count := 0
+	err := repo.db.QueryRow(query, categoryID).Scan(&count)
+	return count, err
+}
+
+// ApplyScheduledPrices - terapkan harga terjadwal yang sudah jatuh tempo
+// pada waktu now, dan kembalikan ID produk yang harganya berubah. Baris
+// dikunci dengan SKIP LOCKED sehingga beberapa instance scheduler bisa
+// berjalan bersamaan. Harga produk tidak ditimpa jika sudah ada perubahan
+// manual, atau jadwal dengan effective_at lebih baru, yang berlaku setelah
+// effective_at jadwal ini; jadwal seperti itu ditandai applied tanpa masuk
+// price_history. Harga yang diterapkan dicatat dengan waktu penerapannya.
+func (repo *productRepo) ApplyScheduledPrices(now time.Time) ([]int, error) {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `SELECT id, product_id, price_amount, currency, effective_at, actor
+    FROM scheduled_prices
+    WHERE status = 'pending' AND effective_at <= $1
+    ORDER BY effective_at, id
+    LIMIT 100
+    FOR UPDATE SKIP LOCKED`
+	rows, err := tx.Query(query, now)
+	if err != nil {
+		return nil, err
+	}
+	var due []model.ScheduledPrice
+	for rows.Next() {
+		var sp model.ScheduledPrice
+		if err := rows.Scan(&sp.ID, &sp.ProductID, &sp.Price.Amount, &sp.Price.Currency, &sp.EffectiveAt, &sp.Actor); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		due = append(due, sp)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
+	var updated []int
+	for _, sp := range due {
+		var appliedAt time.Time
+		err := tx.QueryRow(`UPDATE products SET price_amount = $1, currency = $2, updated_at = now()
+        WHERE id = $3 AND NOT EXISTS (
+            SELECT 1 FROM price_history h
+            LEFT JOIN scheduled_prices s ON s.id = h.scheduled_price_id
+            WHERE h.product_id = $3 AND h.effective_at > $4 AND (s.id IS NULL OR s.effective_at > $4)
+        )
+        RETURNING updated_at`, sp.Price.Amount, sp.Price.Currency, sp.ProductID, sp.EffectiveAt).Scan(&appliedAt)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			// tidak ada baris yang berubah: sudah didahului perubahan lain
+		case err != nil:
+			return nil, err
+		default:
+			if _, err := tx.Exec(`INSERT INTO price_history (product_id, price_amount, currency, effective_at, actor, scheduled_price_id)
+            VALUES ($1, $2, $3, $4, $5, $6)`, sp.ProductID, sp.Price.Amount, sp.Price.Currency, appliedAt, sp.Actor, sp.ID); err != nil {
+				return nil, err
+			}
+			if !slices.Contains(updated, sp.ProductID) {
+				updated = append(updated, sp.ProductID)
+			}
+		}
+
+		if _, err := tx.Exec("UPDATE scheduled_prices SET status = 'applied', applied_at = now() WHERE id = $1", sp.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// AddTags - pasang tag ke produk. Tag yang belum ada dibuat, tag yang sudah
+// terpasang diabaikan.
+func (repo *productRepo) AddTags(productID int, names []string) error {
+	return repo.changeTags(productID, names, func(tx *sql.Tx, name string) error {
+		var tagID int
+		query := `INSERT INTO tags (name) VALUES ($1)
+        ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+        RETURNING id`
+		if err := tx.QueryRow(query, name).Scan(&tagID); err != nil {
+			return err
+		}
+		_, err := tx.Exec("INSERT INTO product_tags (product_id, tag_id) VALUES ($1, $2) ON CONFLICT DO NOTHING", productID, tagID)
+		return err
+	})
+}
+
+// RemoveTags - lepas tag dari produk. Tag yang tidak terpasang diabaikan.
+func (repo *productRepo) RemoveTags(productID int, names []string) error {
+	return repo.changeTags(productID, names, func(tx *sql.Tx, name string) error {
+		query := `DELETE FROM product_tags pt USING tags t
+        WHERE pt.tag_id = t.id AND pt.product_id = $1 AND t.name = $2`
+		_, err := tx.Exec(query, productID, name)
+		return err
+	})
+}
+
+// changeTags menjalankan apply untuk setiap tag dalam satu transaksi dan
+// memperbarui updated_at produk supaya ETag/Last-Modified ikut berubah.
+func (repo *productRepo) changeTags(productID int, names []string, apply func(tx *sql.Tx, name string) error) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec("UPDATE products SET updated_at = now() WHERE id = $1", productID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
 	if rows == 0 {
-		return errors.New("produk tidak ditemukan")
+		return fmt.Errorf("produk %w", ErrNotFound)
+	}
+
+	for _, name := range names {
+		if err := apply(tx, name); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// GetBySlug - ambil produk by slug aktif, atau by slug lama dari history.
+// Pemanggil bisa membandingkan Slug hasilnya untuk tahu perlu redirect.
+func (repo *productRepo) GetBySlug(slug string) (*model.Product, error) {
+	query := `SELECT p.id FROM products p WHERE p.slug = $1
+    UNION ALL
+    SELECT h.product_id FROM product_slug_history h WHERE h.slug = $1
+    LIMIT 1`
+
+	var id int
+	err := repo.db.QueryRow(query, slug).Scan(&id)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("produk %w", ErrNotFound)
 	}
+	if err != nil {
+		return nil, err
+	}
+	return repo.GetByID(id)
+}
 
-	return err
+// SlugExists - cek apakah slug sudah dipakai produk lain, baik sebagai
+// slug aktif maupun slug lama di history
+func (repo *productRepo) SlugExists(slug string, excludeID int) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM products WHERE slug = $1 AND id <> $2)
+        OR EXISTS(SELECT 1 FROM product_slug_history WHERE slug = $1 AND product_id <> $2)`
+	exists := false
+	err := repo.db.QueryRow(query, slug, excludeID).Scan(&exists)
+	return exists, err
 }
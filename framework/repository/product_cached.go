@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"go-boot-category-api/framework/cache"
+	"go-boot-category-api/model"
+	"strconv"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const productCachePrefix = "product:"
+
+type cachedProductRepo struct {
+	next  Product
+	cache cache.Cache
+	ttl   time.Duration
+	group singleflight.Group
+}
+
+// NewCachedProduct membungkus repository produk dengan read-through cache
+// untuk GetByID. Perubahan produk juga menghapus cache kategori karena
+// kategori menyimpan ringkasan jumlah dan stok produknya.
+func NewCachedProduct(next Product, c cache.Cache, ttl time.Duration) Product {
+	return &cachedProductRepo{next: next, cache: c, ttl: ttl}
+}
+
+func productCacheKey(id int) string {
+	return productCachePrefix + "id:" + strconv.Itoa(id)
+}
+
+func (repo *cachedProductRepo) GetAll(filter model.ProductFilter) ([]model.Product, error) {
+	return repo.next.GetAll(filter)
+}
+
+func (repo *cachedProductRepo) GetPage(filter model.ProductFilter, page model.Pagination) (*model.ProductPage, error) {
+	return repo.next.GetPage(filter, page)
+}
+
+func (repo *cachedProductRepo) GetByID(id int) (*model.Product, error) {
+	return readThrough(repo.cache, &repo.group, productCacheKey(id), repo.ttl, func() (*model.Product, error) {
+		return repo.next.GetByID(id)
+	})
+}
+
+// GetByIDWithVariants tidak di-cache karena perubahan varian tidak
+// melewati decorator ini.
+func (repo *cachedProductRepo) GetByIDWithVariants(id int) (*model.Product, error) {
+	return repo.next.GetByIDWithVariants(id)
+}
+
+func (repo *cachedProductRepo) Create(product *model.Product, actor string) error {
+	if err := repo.next.Create(product, actor); err != nil {
+		return err
+	}
+	repo.cache.DeletePrefix(categoryCachePrefix)
+	return nil
+}
+
+// Update juga menghapus cache produk jika gagal, karena kegagalan seperti
+// ErrModified bisa berasal dari produk di cache yang sudah basi.
+func (repo *cachedProductRepo) Update(product *model.Product, stock *int, actor string) error {
+	if err := repo.next.Update(product, stock, actor); err != nil {
+		repo.cache.Delete(productCacheKey(product.ID))
+		return err
+	}
+	repo.cache.Delete(productCacheKey(product.ID))
+	repo.cache.DeletePrefix(categoryCachePrefix)
+	return nil
+}
+
+func (repo *cachedProductRepo) Delete(id int) error {
+	if err := repo.next.Delete(id); err != nil {
+		return err
+	}
+	repo.cache.Delete(productCacheKey(id))
+	repo.cache.DeletePrefix(categoryCachePrefix)
+	return nil
+}
+
+func (repo *cachedProductRepo) CountByCategory(categoryID int) (int, error) {
+	return repo.next.CountByCategory(categoryID)
+}
+
+func (repo *cachedProductRepo) ApplyScheduledPrices(now time.Time) ([]int, error) {
+	ids, err := repo.next.ApplyScheduledPrices(now)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		repo.cache.Delete(productCacheKey(id))
+	}
+	if len(ids) > 0 {
+		repo.cache.DeletePrefix(categoryCachePrefix)
+	}
+	return ids, nil
+}
+
+func (repo *cachedProductRepo) AddTags(productID int, names []string) error {
+	if err := repo.next.AddTags(productID, names); err != nil {
+		return err
+	}
+	repo.cache.Delete(productCacheKey(productID))
+	return nil
+}
+
+func (repo *cachedProductRepo) RemoveTags(productID int, names []string) error {
+	if err := repo.next.RemoveTags(productID, names); err != nil {
+		return err
+	}
+	repo.cache.Delete(productCacheKey(productID))
+	return nil
+}
+
+// GetBySlug tidak di-cache karena slug lama di history bisa berpindah
+// pemilik tanpa melewati key cache by ID.
+func (repo *cachedProductRepo) GetBySlug(slug string) (*model.Product, error) {
+	return repo.next.GetBySlug(slug)
+}
+
+func (repo *cachedProductRepo) SlugExists(slug string, excludeID int) (bool, error) {
+	return repo.next.SlugExists(slug, excludeID)
+}
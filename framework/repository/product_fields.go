@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-boot-category-api/model"
+	"strings"
+)
+
+// productColumn adalah ekspresi SQL untuk satu field di model.ProductFields
+// beserta tujuan scan-nya. join berarti ekspresi butuh JOIN categories.
+type productColumn struct {
+	expr string
+	join bool
+	dest func(p *model.Product) []interface{}
+}
+
+// productColumns adalah whitelist field ?fields=. Hanya ekspresi di sini
+// yang bisa masuk ke SELECT, sehingga client tidak bisa membaca kolom lain.
+var productColumns = map[string]productColumn{
+	"id":   {expr: "p.id", dest: func(p *model.Product) []interface{} { return []interface{}{&p.ID} }},
+	"name": {expr: "p.name", dest: func(p *model.Product) []interface{} { return []interface{}{&p.Name} }},
+	"slug": {expr: "p.slug", dest: func(p *model.Product) []interface{} { return []interface{}{&p.Slug} }},
+	"price": {expr: "p.price_amount, p.currency", dest: func(p *model.Product) []interface{} {
+		return []interface{}{&p.Price.Amount, &p.Price.Currency}
+	}},
+	"stock":         {expr: productStockColumn, dest: func(p *model.Product) []interface{} { return []interface{}{&p.Stock} }},
+	"available":     {expr: productAvailableColumn, dest: func(p *model.Product) []interface{} { return []interface{}{&p.Available} }},
+	"reorder_point": {expr: "p.reorder_point", dest: func(p *model.Product) []interface{} { return []interface{}{&p.ReorderPoint} }},
+	"reorder_qty":   {expr: "p.reorder_qty", dest: func(p *model.Product) []interface{} { return []interface{}{&p.ReorderQty} }},
+	"cost_price": {expr: "p.cost_amount, p.currency", dest: func(p *model.Product) []interface{} {
+		return []interface{}{&p.CostPrice.Amount, &p.CostPrice.Currency}
+	}},
+	"category_id":   {expr: "p.category_id", dest: func(p *model.Product) []interface{} { return []interface{}{&p.CategoryId} }},
+	"category_name": {expr: "c.name", join: true, dest: func(p *model.Product) []interface{} { return []interface{}{&p.CategoryName} }},
+	"attributes":    {expr: "p.attributes", dest: func(p *model.Product) []interface{} { return []interface{}{jsonColumn{&p.Attributes}} }},
+	"tags":          {expr: productTagsColumn, dest: func(p *model.Product) []interface{} { return []interface{}{jsonColumn{&p.Tags}} }},
+	"updated_at": {expr: "GREATEST(p.updated_at, c.updated_at)", join: true, dest: func(p *model.Product) []interface{} {
+		return []interface{}{&p.UpdatedAt}
+	}},
+}
+
+// productProjection membuat SELECT yang hanya membaca fields, beserta
+// fungsi scan untuk baris hasilnya. JOIN categories hanya ditambahkan jika
+// ada field yang membutuhkannya.
+func productProjection(fields []string) (string, func(row rowScanner) (*model.Product, error), error) {
+	var exprs []string
+	var columns []productColumn
+	join := false
+	for _, field := range fields {
+		column, ok := productColumns[field]
+		if !ok {
+			return "", nil, fmt.Errorf("field %q %w", field, ErrInvalidFilter)
+		}
+		exprs = append(exprs, column.expr)
+		columns = append(columns, column)
+		join = join || column.join
+	}
+
+	query := "SELECT " + strings.Join(exprs, ", ") + " FROM products p"
+	if join {
+		query += " JOIN categories c ON p.category_id = c.id"
+	}
+
+	scan := func(row rowScanner) (*model.Product, error) {
+		var p model.Product
+		var dest []interface{}
+		for _, column := range columns {
+			dest = append(dest, column.dest(&p)...)
+		}
+		if err := row.Scan(dest...); err != nil {
+			return nil, err
+		}
+		return &p, nil
+	}
+	return query, scan, nil
+}
+
+// jsonColumn men-decode kolom JSON/JSONB langsung ke dest saat Scan
+type jsonColumn struct {
+	dest interface{}
+}
+
+func (c jsonColumn) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case []byte:
+		return json.Unmarshal(value, c.dest)
+	case string:
+		return json.Unmarshal([]byte(value), c.dest)
+	case nil:
+		return nil
+	default:
+		return fmt.Errorf("jsonColumn: tipe %T tidak didukung", src)
+	}
+}
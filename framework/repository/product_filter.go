@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-boot-category-api/model"
+	"regexp"
+	"strings"
+)
+
+// ErrInvalidFilter di-wrap ketika filter dari client tidak bisa diterjemahkan.
+var ErrInvalidFilter = errors.New("filter tidak valid")
+
+var attributeKeyPattern = regexp.MustCompile(`^[a-zA-Z0-9_]{1,64}$`)
+
+// numberPattern adalah angka desimal yang sah sebagai literal jsonpath.
+// strconv.ParseFloat tidak dipakai karena menerima NaN, Inf, hex, dan
+// angka dengan underscore yang membuat jsonpath gagal di-parse Postgres.
+var numberPattern = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+var jsonPathOperators = map[string]string{
+	"eq":  "==",
+	"ne":  "!=",
+	"gt":  ">",
+	"gte": ">=",
+	"lt":  "<",
+	"lte": "<=",
+}
+
+// productFilterSQL menerjemahkan filter menjadi klausa WHERE beserta
+// argumennya. Setiap filter atribut menjadi predicate jsonpath
+// `p.attributes @@ $n::jsonpath`. Hanya eq yang bisa memakai GIN index
+// products_attributes_idx, karena jsonb_path_ops hanya mengindeks
+// kesamaan; ne, gt, gte, lt, dan lte memeriksa atribut setiap produk
+// yang lolos filter lain. Atribut berbeda per kategori sehingga tidak ada
+// expression index per key. Setiap grup tag menjadi satu EXISTS terhadap
+// product_tags, sehingga antar grup berlaku AND.
+func productFilterSQL(filter model.ProductFilter) (string, []interface{}, error) {
+	var conditions []string
+	var args []interface{}
+
+	for _, f := range filter.Attributes {
+		path, err := attributeJSONPath(f)
+		if err != nil {
+			return "", nil, err
+		}
+		args = append(args, path)
+		conditions = append(conditions, fmt.Sprintf("p.attributes @@ $%d::jsonpath", len(args)))
+	}
+
+	if len(filter.CategoryIDs) > 0 {
+		placeholders := make([]string, len(filter.CategoryIDs))
+		for i, id := range filter.CategoryIDs {
+			args = append(args, id)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		conditions = append(conditions, "p.category_id IN ("+strings.Join(placeholders, ", ")+")")
+	}
+
+	for _, group := range filter.Tags {
+		if len(group) == 0 {
+			return "", nil, fmt.Errorf("tag %w", ErrInvalidFilter)
+		}
+		placeholders := make([]string, len(group))
+		for i, name := range group {
+			args = append(args, name)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		conditions = append(conditions, `EXISTS (
+        SELECT 1 FROM product_tags pt JOIN tags t ON t.id = pt.tag_id
+        WHERE pt.product_id = p.id AND t.name IN (`+strings.Join(placeholders, ", ")+`))`)
+	}
+
+	if len(conditions) == 0 {
+		return "", nil, nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args, nil
+}
+
+// attributeJSONPath membuat predicate jsonpath, misal
+// {voltage gte 110} menjadi `$."voltage" >= 110`. Untuk eq/ne, nilai yang
+// berbentuk angka atau boolean juga dicocokkan dengan versi string-nya;
+// untuk perbandingan range, angka hanya dibandingkan secara numerik.
+func attributeJSONPath(f model.AttributeFilter) (string, error) {
+	if !attributeKeyPattern.MatchString(f.Key) {
+		return "", fmt.Errorf("atribut %q %w", f.Key, ErrInvalidFilter)
+	}
+	op, ok := jsonPathOperators[f.Op]
+	if !ok {
+		return "", fmt.Errorf("operator %q %w", f.Op, ErrInvalidFilter)
+	}
+
+	key, _ := json.Marshal(f.Key)
+	str, _ := json.Marshal(f.Value)
+	field := "$." + string(key)
+
+	var literal string
+	if numberPattern.MatchString(f.Value) {
+		literal = f.Value
+	} else if f.Value == "true" || f.Value == "false" {
+		literal = f.Value
+	}
+
+	switch {
+	case literal == "":
+		return fmt.Sprintf("%s %s %s", field, op, str), nil
+	case op != "==" && op != "!=":
+		return fmt.Sprintf("%s %s %s", field, op, literal), nil
+	case op == "!=":
+		return fmt.Sprintf("(%s != %s && %s != %s)", field, literal, field, str), nil
+	default:
+		return fmt.Sprintf("(%s %s %s || %s %s %s)", field, op, literal, field, op, str), nil
+	}
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"go-boot-category-api/model"
+	"testing"
+)
+
+func TestAttributeJSONPath(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter model.AttributeFilter
+		want   string
+	}{
+		{"integer", model.AttributeFilter{Key: "size", Op: "gt", Value: "42"}, `$."size" > 42`},
+		{"decimal", model.AttributeFilter{Key: "weight", Op: "lte", Value: "-0.5"}, `$."weight" <= -0.5`},
+		{"exponent", model.AttributeFilter{Key: "weight", Op: "lt", Value: "1e3"}, `$."weight" < 1e3`},
+		{"eq number", model.AttributeFilter{Key: "size", Op: "eq", Value: "42"}, `($."size" == 42 || $."size" == "42")`},
+		{"ne bool", model.AttributeFilter{Key: "organic", Op: "ne", Value: "true"}, `($."organic" != true && $."organic" != "true")`},
+		{"NaN", model.AttributeFilter{Key: "size", Op: "gt", Value: "NaN"}, `$."size" > "NaN"`},
+		{"Inf", model.AttributeFilter{Key: "size", Op: "gt", Value: "+Inf"}, `$."size" > "+Inf"`},
+		{"hex", model.AttributeFilter{Key: "size", Op: "gt", Value: "0x1p4"}, `$."size" > "0x1p4"`},
+		{"underscore", model.AttributeFilter{Key: "size", Op: "gt", Value: "1_000"}, `$."size" > "1_000"`},
+		{"leading zero", model.AttributeFilter{Key: "size", Op: "eq", Value: "007"}, `$."size" == "007"`},
+		{"string", model.AttributeFilter{Key: "color", Op: "eq", Value: `red "dark"`}, `$."color" == "red \"dark\""`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := attributeJSONPath(tt.filter)
+			if err != nil {
+				t.Fatalf("attributeJSONPath() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("attributeJSONPath() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"errors"
+	"go-boot-category-api/model"
+	"testing"
+	"time"
+)
+
+func TestProductUpdateKeepsStock(t *testing.T) {
+	db := testDB(t)
+	repo := NewProduct(db)
+	product := createProduct(t, db, "Steady Shirt", 1000, 5)
+
+	// stok yang dibaca dari cache lama tidak boleh menimpa stok saat ini
+	if err := NewOrder(db).Create(newOrder(product.ID, 2)); err != nil {
+		t.Fatalf("Create() order error = %v", err)
+	}
+	stale := 5
+	product.Name = "Steady Shirt Renamed"
+	if err := repo.Update(product, &stale, "test"); !errors.Is(err, ErrStockMismatch) {
+		t.Fatalf("Update() with stale stock error = %v, want ErrStockMismatch", err)
+	}
+	expectStock(t, db, product.ID, 3)
+
+	if err := repo.Update(product, nil, "test"); err != nil {
+		t.Fatalf("Update() without stock error = %v", err)
+	}
+	if product.Stock != 3 {
+		t.Errorf("Update() stock = %d, want 3", product.Stock)
+	}
+	current := 3
+	if err := repo.Update(product, &current, "test"); err != nil {
+		t.Fatalf("Update() with current stock error = %v", err)
+	}
+	expectStock(t, db, product.ID, 3)
+}
+
+func TestStockAdjust(t *testing.T) {
+	db := testDB(t)
+	repo := NewStock(db)
+	product := createProduct(t, db, "Adjusted Shirt", 1000, 5)
+	warehouseID := defaultWarehouse(t, db)
+
+	entry, err := repo.Adjust(product.ID, warehouseID, 3, "test")
+	if err != nil {
+		t.Fatalf("Adjust() error = %v", err)
+	}
+	if entry.Reason != model.LedgerAdjustment || entry.Delta != 3 {
+		t.Errorf("Adjust() entry = %+v, want an adjustment of 3", entry)
+	}
+	expectStock(t, db, product.ID, 8)
+
+	// stok yang ditahan reservasi tidak bisa dikurangi
+	reservation := &model.Reservation{ProductID: &product.ID, Quantity: 6, Actor: "test"}
+	if err := NewReservation(db).Create(reservation, time.Hour); err != nil {
+		t.Fatalf("Create() reservation error = %v", err)
+	}
+	if _, err := repo.Adjust(product.ID, warehouseID, -3, "test"); !errors.Is(err, ErrInsufficientStock) {
+		t.Fatalf("Adjust() below reserved error = %v, want ErrInsufficientStock", err)
+	}
+	if _, err := repo.Adjust(product.ID, warehouseID, -2, "test"); err != nil {
+		t.Fatalf("Adjust() error = %v", err)
+	}
+	expectStock(t, db, product.ID, 6)
+}
+
+func TestProductDeleteKeepsStockHistory(t *testing.T) {
+	db := testDB(t)
+	repo := NewProduct(db)
+	product := createProduct(t, db, "Retired Shirt", 1000, 5)
+
+	if err := repo.Delete(product.ID); !errors.Is(err, ErrStockOnHand) {
+		t.Fatalf("Delete() with stock error = %v, want ErrStockOnHand", err)
+	}
+	if _, err := NewStock(db).Adjust(product.ID, defaultWarehouse(t, db), -5, "test"); err != nil {
+		t.Fatalf("Adjust() error = %v", err)
+	}
+	if err := repo.Delete(product.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	var orphaned int
+	if err := db.QueryRow("SELECT COUNT(*) FROM stock_ledger WHERE product_id IS NULL").Scan(&orphaned); err != nil {
+		t.Fatalf("count ledger: %v", err)
+	}
+	if orphaned != 2 {
+		t.Errorf("ledger entries without product = %d, want 2", orphaned)
+	}
+}
+
+func TestProductUpdateRejectsStaleVersion(t *testing.T) {
+	db := testDB(t)
+	repo := NewProduct(db)
+	product := createProduct(t, db, "Versioned Shirt", 1000, 0)
+
+	read := *product
+	product.Price = model.Money{Amount: 1500, Currency: "IDR"}
+	if err := repo.Update(product, nil, "test"); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	// read dibuat dari versi sebelum update di atas
+	read.Name = "Versioned Shirt Renamed"
+	if err := repo.Update(&read, nil, "test"); !errors.Is(err, ErrModified) {
+		t.Fatalf("Update() from stale read error = %v, want ErrModified", err)
+	}
+	current, err := repo.GetByID(product.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if current.Price.Amount != 1500 || current.Name != "Versioned Shirt" {
+		t.Errorf("product = %s %+v, want the first update kept", current.Name, current.Price)
+	}
+}
+
+func TestProductUpdateCurrencyWithVariantPrices(t *testing.T) {
+	db := testDB(t)
+	repo := NewProduct(db)
+	product := createProduct(t, db, "Priced Variants", 1000, 0)
+
+	variant := &model.Variant{ProductID: product.ID, SKU: "PV-M", Options: map[string]string{"size": "M"}, Price: &model.Money{Amount: 1200, Currency: "IDR"}}
+	if err := NewVariant(db).Create(variant); err != nil {
+		t.Fatalf("Create() variant error = %v", err)
+	}
+
+	product.Price = model.Money{Amount: 10, Currency: "USD"}
+	if err := repo.Update(product, nil, "test"); !errors.Is(err, ErrCurrencyInUse) {
+		t.Fatalf("Update() currency error = %v, want ErrCurrencyInUse", err)
+	}
+
+	if err := NewVariant(db).Delete(product.ID, variant.ID); err != nil {
+		t.Fatalf("Delete() variant error = %v", err)
+	}
+	if err := repo.Update(product, nil, "test"); err != nil {
+		t.Fatalf("Update() currency without variant prices error = %v", err)
+	}
+}
+
+func TestProductUpdateCurrencyWithStock(t *testing.T) {
+	db := testDB(t)
+	repo := NewProduct(db)
+	product := createProduct(t, db, "Stocked Import", 1000, 0)
+	receiveAt(t, db, product.ID, 5, 100)
+
+	product.Price = model.Money{Amount: 10, Currency: "USD"}
+	if err := repo.Update(product, nil, "test"); !errors.Is(err, ErrCurrencyInUse) {
+		t.Fatalf("Update() currency with stock error = %v, want ErrCurrencyInUse", err)
+	}
+
+	if err := NewOrder(db).Create(newOrder(product.ID, 5)); err != nil {
+		t.Fatalf("Create() order error = %v", err)
+	}
+	if err := repo.Update(product, nil, "test"); err != nil {
+		t.Fatalf("Update() currency without stock error = %v", err)
+	}
+	// harga pokok dalam IDR tidak dibawa ke USD
+	if product.CostPrice != (model.Money{Currency: "USD"}) {
+		t.Errorf("cost price = %+v, want 0 USD", product.CostPrice)
+	}
+}
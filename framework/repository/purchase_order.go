@@ -0,0 +1,332 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-boot-category-api/model"
+	"slices"
+	"strings"
+)
+
+// ErrOverReceipt di-wrap jika penerimaan melebihi sisa jumlah yang belum
+// diterima di baris purchase order.
+var ErrOverReceipt = errors.New("jumlah diterima melebihi sisa pesanan")
+
+type PurchaseOrder interface {
+	GetAll(status string, page model.Pagination) (*model.PurchaseOrderPage, error)
+	GetByID(id int64) (*model.PurchaseOrder, error)
+	Create(order *model.PurchaseOrder) error
+	Receive(receipt *model.PurchaseReceipt) error
+	Receipts(orderID int64) ([]model.PurchaseReceipt, error)
+}
+
+type purchaseOrderRepo struct {
+	db *sql.DB
+}
+
+func NewPurchaseOrder(db *sql.DB) PurchaseOrder {
+	return &purchaseOrderRepo{db: db}
+}
+
+const purchaseOrderSelect = `SELECT po.id, po.supplier_id, s.name, po.status, po.note, po.currency, po.actor, po.created_at, po.updated_at
+    FROM purchase_orders po
+    JOIN suppliers s ON s.id = po.supplier_id`
+
+func scanPurchaseOrder(row rowScanner) (*model.PurchaseOrder, error) {
+	var o model.PurchaseOrder
+	err := row.Scan(&o.ID, &o.SupplierID, &o.SupplierName, &o.Status, &o.Note, &o.Total.Currency, &o.Actor, &o.CreatedAt, &o.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("purchase order %w", ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+// attachPurchaseOrderLines mengisi Lines dan Total semua purchase order
+// dengan satu query
+func attachPurchaseOrderLines(db queryer, orders []model.PurchaseOrder) error {
+	if len(orders) == 0 {
+		return nil
+	}
+
+	index := make(map[int64]int, len(orders))
+	placeholders := make([]string, len(orders))
+	args := make([]interface{}, len(orders))
+	for i := range orders {
+		orders[i].Lines = make([]model.PurchaseOrderLine, 0)
+		index[orders[i].ID] = i
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = orders[i].ID
+	}
+
+	query := `SELECT purchase_order_id, id, product_id, product_name, quantity, quantity_received, unit_cost_amount
+    FROM purchase_order_lines WHERE purchase_order_id IN (` + strings.Join(placeholders, ", ") + `) ORDER BY purchase_order_id, id`
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var orderID int64
+		var line model.PurchaseOrderLine
+		var productID sql.NullInt32
+		if err := rows.Scan(&orderID, &line.ID, &productID, &line.ProductName, &line.Quantity, &line.Received, &line.UnitCost.Amount); err != nil {
+			return err
+		}
+		if productID.Valid {
+			id := int(productID.Int32)
+			line.ProductID = &id
+		}
+		order := &orders[index[orderID]]
+		line.UnitCost.Currency = order.Total.Currency
+		order.Total.Amount += line.UnitCost.Amount * int64(line.Quantity)
+		order.Lines = append(order.Lines, line)
+	}
+
+	return rows.Err()
+}
+
+// GetAll - satu halaman purchase order terbaru lebih dulu, difilter status
+// jika diisi
+func (repo *purchaseOrderRepo) GetAll(status string, page model.Pagination) (*model.PurchaseOrderPage, error) {
+	where := ""
+	var args []interface{}
+	if status != "" {
+		where = " WHERE po.status = $1"
+		args = append(args, status)
+	}
+
+	result := &model.PurchaseOrderPage{Page: page.Page, PerPage: page.PerPage, Items: make([]model.PurchaseOrder, 0)}
+	if err := repo.db.QueryRow("SELECT COUNT(*) FROM purchase_orders po"+where, args...).Scan(&result.Total); err != nil {
+		return nil, err
+	}
+
+	args = append(args, page.PerPage, page.Offset())
+	query := purchaseOrderSelect + where + fmt.Sprintf(" ORDER BY po.id DESC LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		o, err := scanPurchaseOrder(rows)
+		if err != nil {
+			return nil, err
+		}
+		result.Items = append(result.Items, *o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := attachPurchaseOrderLines(repo.db, result.Items); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (repo *purchaseOrderRepo) GetByID(id int64) (*model.PurchaseOrder, error) {
+	order, err := scanPurchaseOrder(repo.db.QueryRow(purchaseOrderSelect+" WHERE po.id = $1", id))
+	if err != nil {
+		return nil, err
+	}
+	orders := []model.PurchaseOrder{*order}
+	if err := attachPurchaseOrderLines(repo.db, orders); err != nil {
+		return nil, err
+	}
+	return &orders[0], nil
+}
+
+// Create - simpan purchase order beserta barisnya. Nama produk disalin
+// saat purchase order dibuat; mata uang purchase order diambil dari
+// Total.Currency.
+func (repo *purchaseOrderRepo) Create(order *model.PurchaseOrder) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO purchase_orders (supplier_id, note, currency, actor)
+    VALUES ($1, $2, $3, $4) RETURNING id`
+	err = tx.QueryRow(query, order.SupplierID, order.Note, order.Total.Currency, order.Actor).Scan(&order.ID)
+	if err != nil {
+		return err
+	}
+
+	for i := range order.Lines {
+		line := &order.Lines[i]
+		query := `INSERT INTO purchase_order_lines (purchase_order_id, product_id, product_name, quantity, unit_cost_amount)
+        SELECT $1, p.id, p.name, $3, $4 FROM products p WHERE p.id = $2
+        RETURNING id, product_name`
+		err := tx.QueryRow(query, order.ID, *line.ProductID, line.Quantity, line.UnitCost.Amount).Scan(&line.ID, &line.ProductName)
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("produk %d %w", *line.ProductID, ErrNotFound)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	created, err := scanPurchaseOrder(tx.QueryRow(purchaseOrderSelect+" WHERE po.id = $1", order.ID))
+	if err != nil {
+		return err
+	}
+	orders := []model.PurchaseOrder{*created}
+	if err := attachPurchaseOrderLines(tx, orders); err != nil {
+		return err
+	}
+	*order = orders[0]
+
+	return tx.Commit()
+}
+
+// Receive - terima barang untuk purchase order ke satu gudang: stok
+// bertambah dan dicatat di ledger dengan harga pokok per unit dari baris
+// purchase order, yang juga dicatat di baris penerimaan, dan jumlah
+// diterima di baris purchase order bertambah.
+// Purchase order dikunci lebih dulu supaya penerimaan yang bersamaan tidak
+// sama-sama lolos pengecekan over-receipt, lalu baris produk dikunci
+// berurutan berdasarkan ID. WarehouseID 0 berarti gudang default.
+func (repo *purchaseOrderRepo) Receive(receipt *model.PurchaseReceipt) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	order, err := scanPurchaseOrder(tx.QueryRow(purchaseOrderSelect+" WHERE po.id = $1 FOR UPDATE OF po", receipt.PurchaseOrderID))
+	if err != nil {
+		return err
+	}
+	orders := []model.PurchaseOrder{*order}
+	if err := attachPurchaseOrderLines(tx, orders); err != nil {
+		return err
+	}
+	lines := make(map[int64]*model.PurchaseOrderLine, len(orders[0].Lines))
+	for i := range orders[0].Lines {
+		lines[orders[0].Lines[i].ID] = &orders[0].Lines[i]
+	}
+
+	// jumlah baris penerimaan untuk baris purchase order yang sama
+	// dijumlahkan sebelum dibandingkan dengan sisanya
+	received := make(map[int64]int)
+	var productIDs []int
+	for i := range receipt.Lines {
+		in := &receipt.Lines[i]
+		line, ok := lines[in.PurchaseOrderLineID]
+		if !ok {
+			return fmt.Errorf("baris purchase order %d %w", in.PurchaseOrderLineID, ErrNotFound)
+		}
+		if line.ProductID == nil {
+			return fmt.Errorf("produk baris purchase order %d %w", line.ID, ErrNotFound)
+		}
+		received[line.ID] += in.Quantity
+		if remaining := line.Quantity - line.Received; received[line.ID] > remaining {
+			return fmt.Errorf("baris purchase order %d, sisa %d: %w", line.ID, remaining, ErrOverReceipt)
+		}
+		in.ProductID = line.ProductID
+		in.UnitCost = line.UnitCost
+		if !slices.Contains(productIDs, *line.ProductID) {
+			productIDs = append(productIDs, *line.ProductID)
+		}
+	}
+
+	slices.Sort(productIDs)
+	for _, id := range productIDs {
+		if err := lockProduct(tx, id); err != nil {
+			return err
+		}
+	}
+	if receipt.WarehouseID == 0 {
+		if receipt.WarehouseID, err = defaultWarehouseID(tx); err != nil {
+			return err
+		}
+	}
+
+	query := `INSERT INTO purchase_receipts (purchase_order_id, warehouse_id, note, actor)
+    VALUES ($1, $2, $3, $4) RETURNING id, created_at`
+	err = tx.QueryRow(query, receipt.PurchaseOrderID, receipt.WarehouseID, receipt.Note, receipt.Actor).Scan(&receipt.ID, &receipt.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	for i := range receipt.Lines {
+		line := &receipt.Lines[i]
+		_, err := receiveWarehouseStock(tx, *line.ProductID, receipt.WarehouseID, line.Quantity, line.UnitCost.Amount, model.LedgerPurchase, &receipt.ID, receipt.Actor)
+		if err != nil {
+			return err
+		}
+
+		query := `INSERT INTO purchase_receipt_lines (receipt_id, purchase_order_line_id, product_id, quantity, unit_cost_amount, currency)
+        VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+		err = tx.QueryRow(query, receipt.ID, line.PurchaseOrderLineID, *line.ProductID, line.Quantity, line.UnitCost.Amount, line.UnitCost.Currency).
+			Scan(&line.ID)
+		if err != nil {
+			return err
+		}
+
+		query = "UPDATE purchase_order_lines SET quantity_received = quantity_received + $1 WHERE id = $2"
+		if _, err := tx.Exec(query, line.Quantity, line.PurchaseOrderLineID); err != nil {
+			return err
+		}
+	}
+
+	query = `UPDATE purchase_orders po SET updated_at = now(), status = CASE
+            WHEN NOT EXISTS (
+                SELECT 1 FROM purchase_order_lines l
+                WHERE l.purchase_order_id = po.id AND l.quantity_received < l.quantity
+            ) THEN 'received'
+            ELSE 'partially_received'
+        END
+    WHERE po.id = $1`
+	if _, err := tx.Exec(query, receipt.PurchaseOrderID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Receipts - semua penerimaan barang untuk purchase order, urut dari yang
+// paling awal
+func (repo *purchaseOrderRepo) Receipts(orderID int64) ([]model.PurchaseReceipt, error) {
+	query := `SELECT r.id, r.purchase_order_id, r.warehouse_id, r.note, r.actor, r.created_at,
+        l.id, l.purchase_order_line_id, l.product_id, l.quantity, l.unit_cost_amount, l.currency
+    FROM purchase_receipts r
+    JOIN purchase_receipt_lines l ON l.receipt_id = r.id
+    WHERE r.purchase_order_id = $1
+    ORDER BY r.id, l.id`
+	rows, err := repo.db.Query(query, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	receipts := make([]model.PurchaseReceipt, 0)
+	for rows.Next() {
+		var r model.PurchaseReceipt
+		var line model.PurchaseReceiptLine
+		var productID sql.NullInt32
+		err := rows.Scan(&r.ID, &r.PurchaseOrderID, &r.WarehouseID, &r.Note, &r.Actor, &r.CreatedAt,
+			&line.ID, &line.PurchaseOrderLineID, &productID, &line.Quantity, &line.UnitCost.Amount, &line.UnitCost.Currency)
+		if err != nil {
+			return nil, err
+		}
+		if productID.Valid {
+			id := int(productID.Int32)
+			line.ProductID = &id
+		}
+		if n := len(receipts); n == 0 || receipts[n-1].ID != r.ID {
+			receipts = append(receipts, r)
+		}
+		last := &receipts[len(receipts)-1]
+		last.Lines = append(last.Lines, line)
+	}
+
+	return receipts, rows.Err()
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"go-boot-category-api/framework/cache"
+	"go-boot-category-api/model"
+)
+
+type cachedPurchaseOrderRepo struct {
+	next  PurchaseOrder
+	cache cache.Cache
+}
+
+// NewCachedPurchaseOrder membungkus repository purchase order supaya
+// penerimaan barang menghapus cache produk dan kategori yang stoknya
+// bertambah. Purchase order sendiri tidak di-cache.
+func NewCachedPurchaseOrder(next PurchaseOrder, c cache.Cache) PurchaseOrder {
+	return &cachedPurchaseOrderRepo{next: next, cache: c}
+}
+
+func (repo *cachedPurchaseOrderRepo) GetAll(status string, page model.Pagination) (*model.PurchaseOrderPage, error) {
+	return repo.next.GetAll(status, page)
+}
+
+func (repo *cachedPurchaseOrderRepo) GetByID(id int64) (*model.PurchaseOrder, error) {
+	return repo.next.GetByID(id)
+}
+
+func (repo *cachedPurchaseOrderRepo) Create(order *model.PurchaseOrder) error {
+	return repo.next.Create(order)
+}
+
+func (repo *cachedPurchaseOrderRepo) Receive(receipt *model.PurchaseReceipt) error {
+	if err := repo.next.Receive(receipt); err != nil {
+		return err
+	}
+	for _, line := range receipt.Lines {
+		repo.cache.Delete(productCacheKey(*line.ProductID))
+	}
+	repo.cache.DeletePrefix(categoryCachePrefix)
+	return nil
+}
+
+func (repo *cachedPurchaseOrderRepo) Receipts(orderID int64) ([]model.PurchaseReceipt, error) {
+	return repo.next.Receipts(orderID)
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"go-boot-category-api/model"
+	"testing"
+)
+
+// createPurchaseOrder membuat purchase order satu baris untuk produk
+func createPurchaseOrder(t *testing.T, db *sql.DB, productID, quantity int, unitCost int64) *model.PurchaseOrder {
+	t.Helper()
+	supplier := &model.Supplier{Name: "Supplier"}
+	if err := NewSupplier(db).Create(supplier); err != nil {
+		t.Fatalf("create supplier: %v", err)
+	}
+	order := &model.PurchaseOrder{
+		SupplierID: supplier.ID,
+		Total:      model.Money{Currency: "IDR"},
+		Actor:      "test",
+		Lines:      []model.PurchaseOrderLine{{ProductID: &productID, Quantity: quantity, UnitCost: model.Money{Amount: unitCost, Currency: "IDR"}}},
+	}
+	if err := NewPurchaseOrder(db).Create(order); err != nil {
+		t.Fatalf("create purchase order: %v", err)
+	}
+	return order
+}
+
+func receipt(order *model.PurchaseOrder, quantities ...int) *model.PurchaseReceipt {
+	r := &model.PurchaseReceipt{PurchaseOrderID: order.ID, Actor: "test"}
+	for _, quantity := range quantities {
+		r.Lines = append(r.Lines, model.PurchaseReceiptLine{PurchaseOrderLineID: order.Lines[0].ID, Quantity: quantity})
+	}
+	return r
+}
+
+func TestPurchaseOrderReceive(t *testing.T) {
+	db := testDB(t)
+	repo := NewPurchaseOrder(db)
+	product := createProduct(t, db, "Restocked Shirt", 1000, 0)
+	order := createPurchaseOrder(t, db, product.ID, 10, 600)
+
+	if err := repo.Receive(receipt(order, 4)); err != nil {
+		t.Fatalf("Receive() error = %v", err)
+	}
+	got, err := repo.GetByID(order.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Status != model.PurchaseOrderPartiallyReceived || got.Lines[0].Received != 4 {
+		t.Errorf("after partial receipt = %s, received %d, want partially_received, 4", got.Status, got.Lines[0].Received)
+	}
+	expectStock(t, db, product.ID, 4)
+	if sum := ledgerSum(t, db, product.ID, model.LedgerPurchase); sum != 4 {
+		t.Errorf("purchase_receipt ledger = %d, want 4", sum)
+	}
+
+	// sisa 6: satu baris 7 ditolak, dua baris 3 + 4 untuk baris yang sama
+	// juga ditolak, dan stok tidak berubah
+	for _, quantities := range [][]int{{7}, {3, 4}} {
+		if err := repo.Receive(receipt(order, quantities...)); !errors.Is(err, ErrOverReceipt) {
+			t.Fatalf("Receive(%v) error = %v, want ErrOverReceipt", quantities, err)
+		}
+	}
+	expectStock(t, db, product.ID, 4)
+
+	if err := repo.Receive(receipt(order, 2, 4)); err != nil {
+		t.Fatalf("Receive() remaining error = %v", err)
+	}
+	got, err = repo.GetByID(order.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Status != model.PurchaseOrderReceived || got.Lines[0].Received != 10 {
+		t.Errorf("after final receipt = %s, received %d, want received, 10", got.Status, got.Lines[0].Received)
+	}
+	expectStock(t, db, product.ID, 10)
+
+	receipts, err := repo.Receipts(order.ID)
+	if err != nil {
+		t.Fatalf("Receipts() error = %v", err)
+	}
+	if len(receipts) != 2 || len(receipts[1].Lines) != 2 || receipts[0].Lines[0].UnitCost.Amount != 600 {
+		t.Errorf("Receipts() = %+v, want 2 receipts at unit cost 600", receipts)
+	}
+
+	if err := repo.Receive(receipt(order, 1)); !errors.Is(err, ErrOverReceipt) {
+		t.Errorf("Receive() after fully received error = %v, want ErrOverReceipt", err)
+	}
+}
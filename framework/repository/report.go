@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"go-boot-category-api/model"
+	"time"
+)
+
+type Report interface {
+	ProductMargins(from, to time.Time) ([]model.ProductMargin, error)
+	CategoryMargins(from, to time.Time) ([]model.CategoryMargin, error)
+	InventoryValues(at time.Time) ([]model.InventoryValue, error)
+	InventoryTotals(at time.Time) ([]model.Money, error)
+}
+
+type reportRepo struct {
+	db *sql.DB
+}
+
+func NewReport(db *sql.DB) Report {
+	return &reportRepo{db: db}
+}
+
+// productMarginsQuery adalah pendapatan dan harga pokok per produk dan
+// mata uang dari pesanan yang tidak dibatalkan dalam rentang [$1, $2).
+// Harga pokok adalah nilai persediaan yang keluar di ledger untuk pesanan
+// tersebut.
+const productMarginsQuery = `WITH sales AS (
+        SELECT l.product_id, o.currency, SUM(l.quantity) AS quantity, SUM(l.quantity * l.unit_price_amount) AS revenue
+        FROM order_lines l
+        JOIN orders o ON o.id = l.order_id
+        WHERE o.status <> 'cancelled' AND o.created_at >= $1 AND o.created_at < $2 AND l.product_id IS NOT NULL
+        GROUP BY l.product_id, o.currency
+    ),
+    cogs AS (
+        SELECT sl.product_id, o.currency, -SUM(sl.cost_amount) AS cost
+        FROM stock_ledger sl
+        JOIN orders o ON o.id = sl.reference_id
+        WHERE sl.reason = 'order' AND o.status <> 'cancelled' AND o.created_at >= $1 AND o.created_at < $2
+        GROUP BY sl.product_id, o.currency
+    )
+    SELECT p.id, p.name, c.id AS category_id, c.name AS category_name, s.currency, s.quantity, s.revenue, COALESCE(g.cost, 0) AS cost
+    FROM sales s
+    JOIN products p ON p.id = s.product_id
+    JOIN categories c ON c.id = p.category_id
+    LEFT JOIN cogs g ON g.product_id = s.product_id AND g.currency = s.currency`
+
+// marginPercent adalah ekspresi persentase margin terhadap pendapatan
+const marginPercent = `COALESCE(ROUND((%[1]s - %[2]s) * 100.0 / NULLIF(%[1]s, 0), 2), 0)::float8`
+
+func (repo *reportRepo) ProductMargins(from, to time.Time) ([]model.ProductMargin, error) {
+	query := `SELECT id, name, category_id, category_name, currency, quantity::bigint, revenue::bigint, cost::bigint, (revenue - cost)::bigint,
+        ` + fmt.Sprintf(marginPercent, "revenue", "cost") + `
+    FROM (` + productMarginsQuery + `) m
+    ORDER BY revenue - cost DESC, id`
+	rows, err := repo.db.Query(query, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]model.ProductMargin, 0)
+	for rows.Next() {
+		var m model.ProductMargin
+		var currency string
+		err := rows.Scan(&m.ProductID, &m.ProductName, &m.CategoryID, &m.CategoryName, &currency, &m.QuantitySold,
+			&m.Revenue.Amount, &m.Cost.Amount, &m.Margin.Amount, &m.MarginPercent)
+		if err != nil {
+			return nil, err
+		}
+		m.Revenue.Currency, m.Cost.Currency, m.Margin.Currency = currency, currency, currency
+		items = append(items, m)
+	}
+
+	return items, rows.Err()
+}
+
+func (repo *reportRepo) CategoryMargins(from, to time.Time) ([]model.CategoryMargin, error) {
+	query := `SELECT category_id, category_name, currency, SUM(quantity)::bigint, SUM(revenue)::bigint, SUM(cost)::bigint, SUM(revenue - cost)::bigint,
+        ` + fmt.Sprintf(marginPercent, "SUM(revenue)", "SUM(cost)") + `
+    FROM (` + productMarginsQuery + `) m
+    GROUP BY category_id, category_name, currency
+    ORDER BY SUM(revenue - cost) DESC, category_id`
+	rows, err := repo.db.Query(query, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]model.CategoryMargin, 0)
+	for rows.Next() {
+		var m model.CategoryMargin
+		var currency string
+		err := rows.Scan(&m.CategoryID, &m.CategoryName, &currency, &m.QuantitySold,
+			&m.Revenue.Amount, &m.Cost.Amount, &m.Margin.Amount, &m.MarginPercent)
+		if err != nil {
+			return nil, err
+		}
+		m.Revenue.Currency, m.Cost.Currency, m.Margin.Currency = currency, currency, currency
+		items = append(items, m)
+	}
+
+	return items, rows.Err()
+}
+
+// inventoryValuesQuery adalah stok dan nilai persediaan per produk dari
+// semua entri ledger sebelum $1
+const inventoryValuesQuery = `SELECT p.id, p.name, c.id AS category_id, c.name AS category_name, p.currency,
+        SUM(sl.delta)::bigint AS quantity, SUM(sl.cost_amount)::bigint AS value
+    FROM stock_ledger sl
+    JOIN products p ON p.id = sl.product_id
+    JOIN categories c ON c.id = p.category_id
+    WHERE sl.created_at < $1
+    GROUP BY p.id, c.id`
+
+// InventoryValues - stok dan nilai persediaan setiap produk yang masih
+// punya stok atau nilai sebelum at
+func (repo *reportRepo) InventoryValues(at time.Time) ([]model.InventoryValue, error) {
+	query := `SELECT * FROM (` + inventoryValuesQuery + `) v WHERE quantity <> 0 OR value <> 0 ORDER BY id`
+	rows, err := repo.db.Query(query, at)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]model.InventoryValue, 0)
+	for rows.Next() {
+		var v model.InventoryValue
+		err := rows.Scan(&v.ProductID, &v.ProductName, &v.CategoryID, &v.CategoryName, &v.Value.Currency, &v.Quantity, &v.Value.Amount)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, v)
+	}
+
+	return items, rows.Err()
+}
+
+// InventoryTotals - total nilai persediaan per mata uang sebelum at
+func (repo *reportRepo) InventoryTotals(at time.Time) ([]model.Money, error) {
+	query := `SELECT currency, SUM(value)::bigint FROM (` + inventoryValuesQuery + `) v GROUP BY currency ORDER BY currency`
+	rows, err := repo.db.Query(query, at)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	totals := make([]model.Money, 0)
+	for rows.Next() {
+		var m model.Money
+		if err := rows.Scan(&m.Currency, &m.Amount); err != nil {
+			return nil, err
+		}
+		totals = append(totals, m)
+	}
+
+	return totals, rows.Err()
+}
@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-boot-category-api/model"
+	"time"
+)
+
+type Reservation interface {
+	GetByID(id int64) (*model.Reservation, error)
+	Create(reservation *model.Reservation, ttl time.Duration) error
+	Confirm(id int64, actor string) (*model.Reservation, error)
+	Release(id int64) (*model.Reservation, error)
+	Expire() ([]int, error)
+}
+
+type reservationRepo struct {
+	db *sql.DB
+}
+
+func NewReservation(db *sql.DB) Reservation {
+	return &reservationRepo{db: db}
+}
+
+// productReservedColumn adalah total stok produk yang ditahan reservasi
+// aktif di semua gudang
+const productReservedColumn = `COALESCE((
+            SELECT SUM(r.quantity) FROM stock_reservations r
+            WHERE r.product_id = p.id AND r.status = 'active' AND r.expires_at > now()
+        ), 0)`
+
+// productAvailableColumn adalah stok produk yang masih bisa dijual
+const productAvailableColumn = productStockColumn + ` - ` + productReservedColumn
+
+const reservationColumns = "id, product_id, warehouse_id, quantity, status, reference, actor, expires_at, created_at, updated_at"
+
+func scanReservation(row rowScanner) (*model.Reservation, error) {
+	var r model.Reservation
+	err := row.Scan(&r.ID, &r.ProductID, &r.WarehouseID, &r.Quantity, &r.Status, &r.Reference, &r.Actor, &r.ExpiresAt, &r.CreatedAt, &r.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("reservasi %w", ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+func (repo *reservationRepo) GetByID(id int64) (*model.Reservation, error) {
+	return scanReservation(repo.db.QueryRow("SELECT "+reservationColumns+" FROM stock_reservations WHERE id = $1", id))
+}
+
+// Create - tahan stok selama ttl. Baris produk dikunci dulu supaya
+// reservasi dan pengurangan stok lain untuk produk yang sama berjalan
+// bergantian, sehingga dua reservasi tidak bisa memakai unit terakhir yang
+// sama. WarehouseID 0 berarti gudang default.
+func (repo *reservationRepo) Create(reservation *model.Reservation, ttl time.Duration) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	productID := *reservation.ProductID
+	if err := lockProduct(tx, productID); err != nil {
+		return err
+	}
+	if reservation.WarehouseID == 0 {
+		reservation.WarehouseID, err = defaultWarehouseID(tx)
+		if err != nil {
+			return err
+		}
+	}
+
+	available, err := availableStock(tx, productID, reservation.WarehouseID)
+	if err != nil {
+		return err
+	}
+	if available < reservation.Quantity {
+		return ErrInsufficientStock
+	}
+
+	query := `INSERT INTO stock_reservations (product_id, warehouse_id, quantity, reference, actor, expires_at)
+    VALUES ($1, $2, $3, $4, $5, now() + make_interval(secs => $6))
+    RETURNING ` + reservationColumns
+	created, err := scanReservation(tx.QueryRow(query, productID, reservation.WarehouseID, reservation.Quantity,
+		reservation.Reference, reservation.Actor, ttl.Seconds()))
+	if err != nil {
+		return err
+	}
+	*reservation = *created
+
+	return tx.Commit()
+}
+
+// Confirm - ubah reservasi active menjadi pengurangan stok on-hand yang
+// dicatat di ledger. Reservasi yang sudah lewat expires_at ditandai
+// expired. Reservasi yang tidak active dikembalikan apa adanya, sehingga
+// pemanggil bisa membedakan hasilnya dari Status.
+func (repo *reservationRepo) Confirm(id int64, actor string) (*model.Reservation, error) {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// product_id tidak pernah berubah selain menjadi null saat produknya
+	// dihapus, jadi boleh dibaca sebelum produknya dikunci; urutan kunci
+	// produk lalu reservasi sama dengan Create. Produk dengan reservasi
+	// active tidak bisa dihapus, jadi reservasi tanpa produk tidak active.
+	var productID *int
+	err = tx.QueryRow("SELECT product_id FROM stock_reservations WHERE id = $1", id).Scan(&productID)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("reservasi %w", ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if productID == nil {
+		return repo.GetByID(id)
+	}
+	if err := lockProduct(tx, *productID); err != nil {
+		return nil, err
+	}
+
+	reservation, err := scanReservation(tx.QueryRow("SELECT "+reservationColumns+" FROM stock_reservations WHERE id = $1 FOR UPDATE", id))
+	if err != nil {
+		return nil, err
+	}
+	if reservation.Status != model.ReservationActive {
+		return reservation, nil
+	}
+	var expired bool
+	if err := tx.QueryRow("SELECT expires_at <= now() FROM stock_reservations WHERE id = $1", id).Scan(&expired); err != nil {
+		return nil, err
+	}
+
+	status := model.ReservationConfirmed
+	if expired {
+		status = model.ReservationExpired
+	} else {
+		_, err := adjustWarehouseStock(tx, *productID, reservation.WarehouseID, -reservation.Quantity, model.LedgerReservation, &reservation.ID, actor)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	query := "UPDATE stock_reservations SET status = $1, updated_at = now() WHERE id = $2 RETURNING " + reservationColumns
+	reservation, err = scanReservation(tx.QueryRow(query, status, id))
+	if err != nil {
+		return nil, err
+	}
+	return reservation, tx.Commit()
+}
+
+// Release - lepas tahanan reservasi active. Seperti Confirm, reservasi
+// yang sudah lewat expires_at ditandai expired dan reservasi yang tidak
+// active dikembalikan apa adanya. Produk tidak perlu dikunci karena
+// release hanya menambah stok tersedia.
+func (repo *reservationRepo) Release(id int64) (*model.Reservation, error) {
+	query := `UPDATE stock_reservations
+    SET status = CASE WHEN expires_at > now() THEN 'released' ELSE 'expired' END, updated_at = now()
+    WHERE id = $1 AND status = 'active'
+    RETURNING ` + reservationColumns
+	reservation, err := scanReservation(repo.db.QueryRow(query, id))
+	if err == nil {
+		return reservation, nil
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+	return repo.GetByID(id)
+}
+
+// Expire - tandai semua reservasi active yang sudah lewat expires_at
+// sebagai expired dan kembalikan ID produk yang terdampak. Satu UPDATE
+// sehingga aman dijalankan bersamaan dari beberapa instance.
+func (repo *reservationRepo) Expire() ([]int, error) {
+	query := `WITH expired AS (
+        UPDATE stock_reservations SET status = 'expired', updated_at = now()
+        WHERE status = 'active' AND expires_at <= now()
+        RETURNING product_id
+    )
+    SELECT DISTINCT product_id FROM expired`
+	rows, err := repo.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// availableStock adalah stok on-hand produk di satu gudang dikurangi
+// reservasi aktif di gudang itu. Pemanggil harus sudah mengunci baris
+// produk supaya hasilnya tetap berlaku sampai transaksi selesai.
+func availableStock(tx *sql.Tx, productID, warehouseID int) (int, error) {
+	query := `SELECT
+        COALESCE((SELECT quantity FROM warehouse_stock WHERE product_id = $1 AND warehouse_id = $2), 0) -
+        COALESCE((
+            SELECT SUM(quantity) FROM stock_reservations
+            WHERE product_id = $1 AND warehouse_id = $2 AND status = 'active' AND expires_at > now()
+        ), 0)`
+	var available int
+	err := tx.QueryRow(query, productID, warehouseID).Scan(&available)
+	return available, err
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"go-boot-category-api/framework/cache"
+	"go-boot-category-api/model"
+	"time"
+)
+
+type cachedReservationRepo struct {
+	next  Reservation
+	cache cache.Cache
+}
+
+// NewCachedReservation membungkus repository reservasi supaya perubahan
+// reservasi menghapus cache produk, karena produk menyimpan stok
+// tersedianya. Confirm juga menghapus cache kategori karena stok on-hand
+// berkurang.
+func NewCachedReservation(next Reservation, c cache.Cache) Reservation {
+	return &cachedReservationRepo{next: next, cache: c}
+}
+
+func (repo *cachedReservationRepo) GetByID(id int64) (*model.Reservation, error) {
+	return repo.next.GetByID(id)
+}
+
+func (repo *cachedReservationRepo) Create(reservation *model.Reservation, ttl time.Duration) error {
+	if err := repo.next.Create(reservation, ttl); err != nil {
+		return err
+	}
+	repo.cache.Delete(productCacheKey(*reservation.ProductID))
+	return nil
+}
+
+func (repo *cachedReservationRepo) Confirm(id int64, actor string) (*model.Reservation, error) {
+	reservation, err := repo.next.Confirm(id, actor)
+	if err != nil {
+		return nil, err
+	}
+	repo.deleteProduct(reservation)
+	if reservation.Status == model.ReservationConfirmed {
+		repo.cache.DeletePrefix(categoryCachePrefix)
+	}
+	return reservation, nil
+}
+
+func (repo *cachedReservationRepo) Release(id int64) (*model.Reservation, error) {
+	reservation, err := repo.next.Release(id)
+	if err != nil {
+		return nil, err
+	}
+	repo.deleteProduct(reservation)
+	return reservation, nil
+}
+
+func (repo *cachedReservationRepo) Expire() ([]int, error) {
+	ids, err := repo.next.Expire()
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		repo.cache.Delete(productCacheKey(id))
+	}
+	return ids, nil
+}
+
+// deleteProduct menghapus cache produk reservasi jika produknya masih ada
+func (repo *cachedReservationRepo) deleteProduct(reservation *model.Reservation) {
+	if reservation.ProductID != nil {
+		repo.cache.Delete(productCacheKey(*reservation.ProductID))
+	}
+}
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"errors"
+	"go-boot-category-api/model"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestReservationHoldsAvailableStock(t *testing.T) {
+	db := testDB(t)
+	repo := NewReservation(db)
+	product := createProduct(t, db, "Reserved Shirt", 1000, 5)
+
+	first := &model.Reservation{ProductID: &product.ID, Quantity: 3, Actor: "test"}
+	if err := repo.Create(first, time.Hour); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if first.WarehouseID != defaultWarehouse(t, db) {
+		t.Errorf("WarehouseID = %d, want the default warehouse", first.WarehouseID)
+	}
+
+	// stok on-hand tetap 5, yang tersedia tinggal 2
+	second := &model.Reservation{ProductID: &product.ID, Quantity: 3, Actor: "test"}
+	if err := repo.Create(second, time.Hour); !errors.Is(err, ErrInsufficientStock) {
+		t.Fatalf("Create() over available error = %v, want ErrInsufficientStock", err)
+	}
+	expectStock(t, db, product.ID, 5)
+
+	// release mengembalikan stok tersedia tanpa mengubah on-hand
+	released, err := repo.Release(first.ID)
+	if err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+	if released.Status != model.ReservationReleased {
+		t.Errorf("Release() status = %s, want %s", released.Status, model.ReservationReleased)
+	}
+	if err := repo.Create(second, time.Hour); err != nil {
+		t.Fatalf("Create() after release error = %v", err)
+	}
+
+	confirmed, err := repo.Confirm(second.ID, "test")
+	if err != nil {
+		t.Fatalf("Confirm() error = %v", err)
+	}
+	if confirmed.Status != model.ReservationConfirmed {
+		t.Errorf("Confirm() status = %s, want %s", confirmed.Status, model.ReservationConfirmed)
+	}
+	expectStock(t, db, product.ID, 2)
+	if got := ledgerSum(t, db, product.ID, model.LedgerReservation); got != -3 {
+		t.Errorf("reservation ledger = %d, want -3", got)
+	}
+}
+
+func TestReservationCreateConcurrent(t *testing.T) {
+	db := testDB(t)
+	repo := NewReservation(db)
+	product := createProduct(t, db, "Last Units", 1000, 4)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 10)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = repo.Create(&model.Reservation{ProductID: &product.ID, Quantity: 1, Actor: "test"}, time.Hour)
+		}(i)
+	}
+	wg.Wait()
+
+	held := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			held++
+		case !errors.Is(err, ErrInsufficientStock):
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	if held != 4 {
+		t.Errorf("%d reservations succeeded, want 4", held)
+	}
+}
+
+func TestReservationExpire(t *testing.T) {
+	db := testDB(t)
+	repo := NewReservation(db)
+	product := createProduct(t, db, "Expiring Shirt", 1000, 5)
+	other := createProduct(t, db, "Fresh Shirt", 1000, 5)
+
+	expiring := &model.Reservation{ProductID: &product.ID, Quantity: 5, Actor: "test"}
+	if err := repo.Create(expiring, time.Hour); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	fresh := &model.Reservation{ProductID: &other.ID, Quantity: 2, Actor: "test"}
+	if err := repo.Create(fresh, time.Hour); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if _, err := db.Exec("UPDATE stock_reservations SET expires_at = now() - interval '1 second' WHERE id = $1", expiring.ID); err != nil {
+		t.Fatalf("backdate reservation: %v", err)
+	}
+
+	ids, err := repo.Expire()
+	if err != nil {
+		t.Fatalf("Expire() error = %v", err)
+	}
+	if !slices.Equal(ids, []int{product.ID}) {
+		t.Errorf("Expire() = %v, want [%d]", ids, product.ID)
+	}
+
+	got, err := repo.GetByID(expiring.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Status != model.ReservationExpired {
+		t.Errorf("status = %s, want %s", got.Status, model.ReservationExpired)
+	}
+	got, err = repo.GetByID(fresh.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Status != model.ReservationActive {
+		t.Errorf("fresh status = %s, want %s", got.Status, model.ReservationActive)
+	}
+
+	// reservasi yang sudah expired tidak bisa dikonfirmasi dan stoknya
+	// tersedia lagi
+	confirmed, err := repo.Confirm(expiring.ID, "test")
+	if err != nil {
+		t.Fatalf("Confirm() error = %v", err)
+	}
+	if confirmed.Status != model.ReservationExpired {
+		t.Errorf("Confirm() status = %s, want %s", confirmed.Status, model.ReservationExpired)
+	}
+	expectStock(t, db, product.ID, 5)
+	if err := repo.Create(&model.Reservation{ProductID: &product.ID, Quantity: 5, Actor: "test"}, time.Hour); err != nil {
+		t.Errorf("Create() after expiry error = %v", err)
+	}
+
+	// Expire berikutnya tidak menyentuh reservasi yang sama lagi
+	ids, err = repo.Expire()
+	if err != nil {
+		t.Fatalf("Expire() error = %v", err)
+	}
+	if len(ids) != 0 {
+		t.Errorf("second Expire() = %v, want none", ids)
+	}
+}
+
+func TestReservationConfirmAfterExpiryWithoutReaper(t *testing.T) {
+	db := testDB(t)
+	repo := NewReservation(db)
+	product := createProduct(t, db, "Late Confirm", 1000, 3)
+
+	reservation := &model.Reservation{ProductID: &product.ID, Quantity: 2, Actor: "test"}
+	if err := repo.Create(reservation, time.Hour); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if _, err := db.Exec("UPDATE stock_reservations SET expires_at = now() - interval '1 second' WHERE id = $1", reservation.ID); err != nil {
+		t.Fatalf("backdate reservation: %v", err)
+	}
+
+	confirmed, err := repo.Confirm(reservation.ID, "test")
+	if err != nil {
+		t.Fatalf("Confirm() error = %v", err)
+	}
+	if confirmed.Status != model.ReservationExpired {
+		t.Errorf("Confirm() status = %s, want %s", confirmed.Status, model.ReservationExpired)
+	}
+	expectStock(t, db, product.ID, 3)
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// moveSlugToHistory mencatat oldSlug sebagai slug lama milik entity id di
+// table history, dan menghapus entri history untuk newSlug karena slug itu
+// sekarang aktif lagi. table dan column selalu konstanta dari repository ini.
+func moveSlugToHistory(tx *sql.Tx, table, column string, id int, oldSlug, newSlug string) error {
+	insert := fmt.Sprintf(`INSERT INTO %s (slug, %s) VALUES ($1, $2)
+    ON CONFLICT (slug) DO UPDATE SET %s = EXCLUDED.%s, created_at = now()`, table, column, column, column)
+	if _, err := tx.Exec(insert, oldSlug, id); err != nil {
+		return err
+	}
+
+	remove := fmt.Sprintf("DELETE FROM %s WHERE slug = $1", table)
+	_, err := tx.Exec(remove, newSlug)
+	return err
+}
@@ -0,0 +1,322 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-boot-category-api/model"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type Stock interface {
+	Levels(productIDs []int) (map[int][]model.StockLevel, error)
+	Transfer(transfer *model.StockTransfer) error
+	GetTransfer(id int64) (*model.StockTransfer, error)
+	Adjust(productID, warehouseID, delta int, actor string) (*model.LedgerEntry, error)
+}
+
+type stockRepo struct {
+	db *sql.DB
+}
+
+func NewStock(db *sql.DB) Stock {
+	return &stockRepo{db: db}
+}
+
+// productStockColumn adalah total stok on-hand produk di semua gudang
+const productStockColumn = `COALESCE((
+            SELECT SUM(ws.quantity) FROM warehouse_stock ws WHERE ws.product_id = p.id
+        ), 0)`
+
+// Levels - stok per gudang untuk setiap produk, dengan satu query untuk
+// semua produk. Gudang tanpa stok tidak disertakan.
+func (repo *stockRepo) Levels(productIDs []int) (map[int][]model.StockLevel, error) {
+	levels := make(map[int][]model.StockLevel, len(productIDs))
+	if len(productIDs) == 0 {
+		return levels, nil
+	}
+
+	placeholders := make([]string, len(productIDs))
+	args := make([]interface{}, len(productIDs))
+	for i, id := range productIDs {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = id
+	}
+	query := `SELECT ws.product_id, w.id, w.code, ws.quantity
+    FROM warehouse_stock ws
+    JOIN warehouses w ON w.id = ws.warehouse_id
+    WHERE ws.quantity > 0 AND ws.product_id IN (` + strings.Join(placeholders, ", ") + `)
+    ORDER BY ws.product_id, w.id`
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var productID int
+		var level model.StockLevel
+		if err := rows.Scan(&productID, &level.WarehouseID, &level.WarehouseCode, &level.Quantity); err != nil {
+			return nil, err
+		}
+		levels[productID] = append(levels[productID], level)
+	}
+
+	return levels, rows.Err()
+}
+
+// Transfer - pindahkan stok antar gudang dalam satu transaksi dan catat
+// pasangan entri ledger-nya. Seperti perubahan stok lain, baris produk
+// dikunci lebih dulu, lalu baris stok kedua gudang berurutan berdasarkan ID
+// gudang, supaya tidak deadlock dengan update produk atau transfer
+// berlawanan arah.
+func (repo *stockRepo) Transfer(transfer *model.StockTransfer) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	productID := *transfer.ProductID
+	if err := lockProduct(tx, productID); err != nil {
+		return err
+	}
+
+	first, second := transfer.FromWarehouseID, transfer.ToWarehouseID
+	if first > second {
+		first, second = second, first
+	}
+	ensure := `INSERT INTO warehouse_stock (warehouse_id, product_id) VALUES ($1, $3), ($2, $3)
+    ON CONFLICT DO NOTHING`
+	if _, err := tx.Exec(ensure, first, second, productID); err != nil {
+		return err
+	}
+	lock := `SELECT quantity FROM warehouse_stock
+    WHERE product_id = $1 AND warehouse_id IN ($2, $3)
+    ORDER BY warehouse_id FOR UPDATE`
+	if _, err := tx.Exec(lock, productID, first, second); err != nil {
+		return err
+	}
+	// stok yang ditahan reservasi tidak ikut dipindahkan
+	available, err := availableStock(tx, productID, transfer.FromWarehouseID)
+	if err != nil {
+		return err
+	}
+	if available < transfer.Quantity {
+		return ErrInsufficientStock
+	}
+
+	query := `INSERT INTO stock_transfers (product_id, from_warehouse_id, to_warehouse_id, quantity, note, actor)
+    VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_at`
+	err = tx.QueryRow(query, productID, transfer.FromWarehouseID, transfer.ToWarehouseID, transfer.Quantity, transfer.Note, transfer.Actor).
+		Scan(&transfer.ID, &transfer.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	out, err := adjustWarehouseStock(tx, productID, transfer.FromWarehouseID, -transfer.Quantity, model.LedgerTransferOut, &transfer.ID, transfer.Actor)
+	if err != nil {
+		return err
+	}
+	in, err := adjustWarehouseStock(tx, productID, transfer.ToWarehouseID, transfer.Quantity, model.LedgerTransferIn, &transfer.ID, transfer.Actor)
+	if err != nil {
+		return err
+	}
+	transfer.Entries = []model.LedgerEntry{*out, *in}
+
+	return tx.Commit()
+}
+
+func (repo *stockRepo) GetTransfer(id int64) (*model.StockTransfer, error) {
+	query := `SELECT id, product_id, from_warehouse_id, to_warehouse_id, quantity, note, actor, created_at
+    FROM stock_transfers WHERE id = $1`
+	var t model.StockTransfer
+	err := repo.db.QueryRow(query, id).
+		Scan(&t.ID, &t.ProductID, &t.FromWarehouseID, &t.ToWarehouseID, &t.Quantity, &t.Note, &t.Actor, &t.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("transfer %w", ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	t.Entries, err = ledgerEntries(repo.db, "reason IN ('transfer_out', 'transfer_in') AND reference_id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// Adjust - tambah atau kurangi stok produk di satu gudang sebesar delta
+// dan catat sebagai adjustment di ledger. Stok yang ditahan reservasi
+// tidak bisa dikurangi.
+func (repo *stockRepo) Adjust(productID, warehouseID, delta int, actor string) (*model.LedgerEntry, error) {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := lockProduct(tx, productID); err != nil {
+		return nil, err
+	}
+	if delta < 0 {
+		available, err := availableStock(tx, productID, warehouseID)
+		if err != nil {
+			return nil, err
+		}
+		if available < -delta {
+			return nil, ErrInsufficientStock
+		}
+	}
+	entry, err := adjustWarehouseStock(tx, productID, warehouseID, delta, model.LedgerAdjustment, nil, actor)
+	if err != nil {
+		return nil, err
+	}
+	return entry, tx.Commit()
+}
+
+// adjustWarehouseStock menambah stok produk di satu gudang sebesar delta
+// (negatif untuk mengurangi) dan mencatatnya di ledger beserta nilai
+// persediaannya. Stok tidak boleh menjadi negatif.
+func adjustWarehouseStock(tx *sql.Tx, productID, warehouseID, delta int, reason string, referenceID *int64, actor string) (*model.LedgerEntry, error) {
+	return moveStock(tx, stockMovement{productID: productID, warehouseID: warehouseID, delta: delta, reason: reason, referenceID: referenceID, actor: actor})
+}
+
+// receiveWarehouseStock seperti adjustWarehouseStock untuk stok masuk
+// dengan harga pokok per unit yang diketahui, misal penerimaan barang.
+func receiveWarehouseStock(tx *sql.Tx, productID, warehouseID, quantity int, unitCost int64, reason string, referenceID *int64, actor string) (*model.LedgerEntry, error) {
+	return moveStock(tx, stockMovement{productID: productID, warehouseID: warehouseID, delta: quantity, unitCost: &unitCost, reason: reason, referenceID: referenceID, actor: actor})
+}
+
+type stockMovement struct {
+	productID   int
+	warehouseID int
+	delta       int
+	unitCost    *int64
+	reason      string
+	referenceID *int64
+	actor       string
+}
+
+func moveStock(tx *sql.Tx, m stockMovement) (*model.LedgerEntry, error) {
+	query := `INSERT INTO warehouse_stock (warehouse_id, product_id, quantity) VALUES ($1, $2, $3)
+    ON CONFLICT (warehouse_id, product_id) DO UPDATE
+    SET quantity = warehouse_stock.quantity + EXCLUDED.quantity, updated_at = now()
+    WHERE warehouse_stock.quantity + EXCLUDED.quantity >= 0`
+	result, err := tx.Exec(query, m.warehouseID, m.productID, m.delta)
+	if err != nil {
+		return nil, stockError(err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rows == 0 {
+		return nil, ErrInsufficientStock
+	}
+
+	// transfer hanya memindahkan stok antar gudang, total stok, nilai
+	// persediaan, dan lapisan FIFO produk tidak berubah
+	transfer := m.reason == model.LedgerTransferOut || m.reason == model.LedgerTransferIn
+	var cost int64
+	if !transfer {
+		cost, err = stockCost(tx, m.productID, m.delta, m.unitCost)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	entry := &model.LedgerEntry{ProductID: &m.productID, WarehouseID: m.warehouseID, Delta: m.delta, Reason: m.reason, ReferenceID: m.referenceID, Actor: m.actor}
+	query = `INSERT INTO stock_ledger (product_id, warehouse_id, delta, reason, reference_id, actor, cost_amount)
+    VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id, created_at`
+	err = tx.QueryRow(query, m.productID, m.warehouseID, m.delta, m.reason, m.referenceID, m.actor, cost).Scan(&entry.ID, &entry.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if !transfer {
+		if err := addCostLayer(tx, entry, cost); err != nil {
+			return nil, err
+		}
+		if err := checkLowStock(tx, m.productID, m.delta); err != nil {
+			return nil, err
+		}
+	}
+	return entry, nil
+}
+
+// checkLowStock mencatat event stok rendah jika perubahan stok sebesar
+// delta membuat total stok produk turun melewati reorder point. Semua
+// pemanggil moveStock sudah mengunci baris produk.
+func checkLowStock(tx *sql.Tx, productID, delta int) error {
+	if delta >= 0 {
+		return nil
+	}
+	var stock, point int
+	err := tx.QueryRow("SELECT "+productStockColumn+", p.reorder_point FROM products p WHERE p.id = $1", productID).Scan(&stock, &point)
+	if err != nil {
+		return err
+	}
+	if !lowStockCrossed(stock-delta, point, stock, point) {
+		return nil
+	}
+	return recordLowStock(tx, productID, stock)
+}
+
+// stockError menerjemahkan pelanggaran CHECK quantity >= 0 (23514) menjadi
+// ErrInsufficientStock.
+func stockError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23514" {
+		return ErrInsufficientStock
+	}
+	return err
+}
+
+// lockProduct mengunci baris produk sampai transaksi selesai
+func lockProduct(tx *sql.Tx, productID int) error {
+	var id int
+	err := tx.QueryRow("SELECT id FROM products WHERE id = $1 FOR UPDATE", productID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("produk %w", ErrNotFound)
+	}
+	return err
+}
+
+// defaultWarehouseID mengambil ID gudang default
+func defaultWarehouseID(tx *sql.Tx) (int, error) {
+	var id int
+	err := tx.QueryRow("SELECT id FROM warehouses WHERE is_default").Scan(&id)
+	if err == sql.ErrNoRows {
+		return 0, fmt.Errorf("gudang default %w", ErrNotFound)
+	}
+	return id, err
+}
+
+// ledgerEntries membaca entri ledger dengan kondisi WHERE where
+func ledgerEntries(db *sql.DB, where string, args ...interface{}) ([]model.LedgerEntry, error) {
+	query := `SELECT id, product_id, warehouse_id, delta, reason, reference_id, actor, created_at
+    FROM stock_ledger WHERE ` + where + ` ORDER BY id`
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]model.LedgerEntry, 0)
+	for rows.Next() {
+		var e model.LedgerEntry
+		var referenceID sql.NullInt64
+		if err := rows.Scan(&e.ID, &e.ProductID, &e.WarehouseID, &e.Delta, &e.Reason, &referenceID, &e.Actor, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		if referenceID.Valid {
+			e.ReferenceID = &referenceID.Int64
+		}
+		entries = append(entries, e)
+	}
+
+	return entries, rows.Err()
+}
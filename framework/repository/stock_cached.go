@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"go-boot-category-api/framework/cache"
+	"go-boot-category-api/model"
+)
+
+type cachedStockRepo struct {
+	next  Stock
+	cache cache.Cache
+}
+
+// NewCachedStock membungkus repository stok supaya penyesuaian stok
+// menghapus cache produk dan kategori yang stoknya berubah. Transfer tidak
+// mengubah total stok produk sehingga tidak menghapus cache.
+func NewCachedStock(next Stock, c cache.Cache) Stock {
+	return &cachedStockRepo{next: next, cache: c}
+}
+
+func (repo *cachedStockRepo) Levels(productIDs []int) (map[int][]model.StockLevel, error) {
+	return repo.next.Levels(productIDs)
+}
+
+func (repo *cachedStockRepo) Transfer(transfer *model.StockTransfer) error {
+	return repo.next.Transfer(transfer)
+}
+
+func (repo *cachedStockRepo) GetTransfer(id int64) (*model.StockTransfer, error) {
+	return repo.next.GetTransfer(id)
+}
+
+func (repo *cachedStockRepo) Adjust(productID, warehouseID, delta int, actor string) (*model.LedgerEntry, error) {
+	entry, err := repo.next.Adjust(productID, warehouseID, delta, actor)
+	if err != nil {
+		return nil, err
+	}
+	repo.cache.Delete(productCacheKey(productID))
+	repo.cache.DeletePrefix(categoryCachePrefix)
+	return entry, nil
+}
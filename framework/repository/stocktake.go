@@ -0,0 +1,398 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-boot-category-api/model"
+	"slices"
+	"strings"
+)
+
+// ErrStocktakeClosed di-wrap jika sesi stocktake sudah disetujui atau
+// dibatalkan sehingga tidak bisa dihitung atau ditutup lagi.
+var ErrStocktakeClosed = errors.New("sesi stocktake sudah ditutup")
+
+type Stocktake interface {
+	GetAll(status string, page model.Pagination) (*model.StocktakePage, error)
+	GetByID(id int64) (*model.Stocktake, error)
+	Create(stocktake *model.Stocktake) error
+	Count(id int64, counts []model.StocktakeCount, actor string) (*model.Stocktake, error)
+	Approve(id int64, actor string) (*model.Stocktake, error)
+	Cancel(id int64, actor string) (*model.Stocktake, error)
+	Variance(id int64) (*model.StocktakeVariance, error)
+}
+
+type stocktakeRepo struct {
+	db *sql.DB
+}
+
+func NewStocktake(db *sql.DB) Stocktake {
+	return &stocktakeRepo{db: db}
+}
+
+const stocktakeColumns = "id, warehouse_id, category_id, status, note, actor, closed_by, created_at, closed_at"
+
+func scanStocktake(row rowScanner) (*model.Stocktake, error) {
+	var s model.Stocktake
+	var warehouseID, categoryID sql.NullInt32
+	var closedAt sql.NullTime
+	err := row.Scan(&s.ID, &warehouseID, &categoryID, &s.Status, &s.Note, &s.Actor, &s.ClosedBy, &s.CreatedAt, &closedAt)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("stocktake %w", ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if warehouseID.Valid {
+		id := int(warehouseID.Int32)
+		s.WarehouseID = &id
+	}
+	if categoryID.Valid {
+		id := int(categoryID.Int32)
+		s.CategoryID = &id
+	}
+	if closedAt.Valid {
+		s.ClosedAt = &closedAt.Time
+	}
+	return &s, nil
+}
+
+const stocktakeLineColumns = "id, product_id, product_name, warehouse_id, expected, counted, unit_cost_amount, currency, counted_by, counted_at"
+
+func scanStocktakeLine(row rowScanner, dest ...interface{}) (*model.StocktakeLine, error) {
+	var l model.StocktakeLine
+	var productID, counted sql.NullInt32
+	var countedAt sql.NullTime
+	dest = append(dest, &l.ID, &productID, &l.ProductName, &l.WarehouseID, &l.Expected, &counted,
+		&l.UnitCost.Amount, &l.UnitCost.Currency, &l.CountedBy, &countedAt)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	if productID.Valid {
+		id := int(productID.Int32)
+		l.ProductID = &id
+	}
+	if counted.Valid {
+		n := int(counted.Int32)
+		variance := n - l.Expected
+		l.Counted, l.Variance = &n, &variance
+	}
+	if countedAt.Valid {
+		l.CountedAt = &countedAt.Time
+	}
+	return &l, nil
+}
+
+// attachStocktakeLines mengisi Lines semua sesi stocktake dengan satu
+// query
+func attachStocktakeLines(db queryer, stocktakes []model.Stocktake) error {
+	if len(stocktakes) == 0 {
+		return nil
+	}
+
+	index := make(map[int64]int, len(stocktakes))
+	placeholders := make([]string, len(stocktakes))
+	args := make([]interface{}, len(stocktakes))
+	for i := range stocktakes {
+		stocktakes[i].Lines = make([]model.StocktakeLine, 0)
+		index[stocktakes[i].ID] = i
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = stocktakes[i].ID
+	}
+
+	query := `SELECT stocktake_id, ` + stocktakeLineColumns + `
+    FROM stocktake_lines WHERE stocktake_id IN (` + strings.Join(placeholders, ", ") + `) ORDER BY stocktake_id, warehouse_id, product_name, id`
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var stocktakeID int64
+		line, err := scanStocktakeLine(rows, &stocktakeID)
+		if err != nil {
+			return err
+		}
+		i := index[stocktakeID]
+		stocktakes[i].Lines = append(stocktakes[i].Lines, *line)
+	}
+
+	return rows.Err()
+}
+
+// GetAll - satu halaman sesi stocktake terbaru lebih dulu, difilter status
+// jika diisi. Baris setiap sesi tidak diisi; ambil lewat GetByID.
+func (repo *stocktakeRepo) GetAll(status string, page model.Pagination) (*model.StocktakePage, error) {
+	where := ""
+	var args []interface{}
+	if status != "" {
+		where = " WHERE status = $1"
+		args = append(args, status)
+	}
+
+	result := &model.StocktakePage{Page: page.Page, PerPage: page.PerPage, Items: make([]model.Stocktake, 0)}
+	if err := repo.db.QueryRow("SELECT COUNT(*) FROM stocktakes"+where, args...).Scan(&result.Total); err != nil {
+		return nil, err
+	}
+
+	args = append(args, page.PerPage, page.Offset())
+	query := "SELECT " + stocktakeColumns + " FROM stocktakes" + where + fmt.Sprintf(" ORDER BY id DESC LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		s, err := scanStocktake(rows)
+		if err != nil {
+			return nil, err
+		}
+		result.Items = append(result.Items, *s)
+	}
+	return result, rows.Err()
+}
+
+func (repo *stocktakeRepo) GetByID(id int64) (*model.Stocktake, error) {
+	stocktake, err := scanStocktake(repo.db.QueryRow("SELECT "+stocktakeColumns+" FROM stocktakes WHERE id = $1", id))
+	if err != nil {
+		return nil, err
+	}
+	stocktakes := []model.Stocktake{*stocktake}
+	if err := attachStocktakeLines(repo.db, stocktakes); err != nil {
+		return nil, err
+	}
+	return &stocktakes[0], nil
+}
+
+// Create - buka sesi stocktake dan salin stok on-hand setiap produk di
+// setiap gudang dalam cakupannya sebagai stok yang diharapkan, termasuk
+// produk yang stoknya nol di gudang itu. Salinan diambil dalam satu
+// statement sehingga konsisten untuk seluruh sesi.
+func (repo *stocktakeRepo) Create(stocktake *model.Stocktake) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO stocktakes (warehouse_id, category_id, note, actor)
+    VALUES ($1, $2, $3, $4) RETURNING ` + stocktakeColumns
+	created, err := scanStocktake(tx.QueryRow(query, stocktake.WarehouseID, stocktake.CategoryID, stocktake.Note, stocktake.Actor))
+	if err != nil {
+		return err
+	}
+
+	query = `INSERT INTO stocktake_lines (stocktake_id, product_id, product_name, warehouse_id, expected, unit_cost_amount, currency)
+    SELECT $1, p.id, p.name, w.id, COALESCE(ws.quantity, 0), p.cost_amount, p.currency
+    FROM products p
+    CROSS JOIN warehouses w
+    LEFT JOIN warehouse_stock ws ON ws.product_id = p.id AND ws.warehouse_id = w.id
+    WHERE ($2::int IS NULL OR w.id = $2) AND ($3::int IS NULL OR p.category_id = $3)`
+	if _, err := tx.Exec(query, created.ID, stocktake.WarehouseID, stocktake.CategoryID); err != nil {
+		return err
+	}
+
+	stocktakes := []model.Stocktake{*created}
+	if err := attachStocktakeLines(tx, stocktakes); err != nil {
+		return err
+	}
+	*stocktake = stocktakes[0]
+	return tx.Commit()
+}
+
+// Count - simpan hasil hitungan ke baris sesi yang masih open. Sesi dikunci
+// FOR SHARE, sehingga beberapa penghitung bisa mengirim bersamaan tetapi
+// tidak bersamaan dengan Approve atau Cancel. WarehouseID setiap hitungan
+// harus sudah diisi.
+func (repo *stocktakeRepo) Count(id int64, counts []model.StocktakeCount, actor string) (*model.Stocktake, error) {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	stocktake, err := scanStocktake(tx.QueryRow("SELECT "+stocktakeColumns+" FROM stocktakes WHERE id = $1 FOR SHARE", id))
+	if err != nil {
+		return nil, err
+	}
+	if stocktake.Status != model.StocktakeOpen {
+		return nil, fmt.Errorf("stocktake %d berstatus %s: %w", id, stocktake.Status, ErrStocktakeClosed)
+	}
+
+	query := `UPDATE stocktake_lines SET counted = $1, counted_by = $2, counted_at = now()
+    WHERE stocktake_id = $3 AND product_id = $4 AND warehouse_id = $5`
+	for _, count := range counts {
+		result, err := tx.Exec(query, *count.Counted, actor, id, count.ProductID, count.WarehouseID)
+		if err != nil {
+			return nil, err
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		if rows == 0 {
+			return nil, fmt.Errorf("produk %d di gudang %d dalam stocktake %w", count.ProductID, count.WarehouseID, ErrNotFound)
+		}
+	}
+
+	stocktakes := []model.Stocktake{*stocktake}
+	if err := attachStocktakeLines(tx, stocktakes); err != nil {
+		return nil, err
+	}
+	return &stocktakes[0], tx.Commit()
+}
+
+// Approve - setujui sesi stocktake dan posting selisih setiap baris yang
+// sudah dihitung ke ledger dengan reason stocktake. Selisih dihitung
+// terhadap salinan saat sesi dibuat dan ditambahkan ke stok saat ini,
+// sehingga perubahan stok lain selama penghitungan tetap tercatat. Baris
+// yang belum dihitung tidak diubah. Sesi dikunci lebih dulu, lalu baris
+// produk dikunci berurutan berdasarkan ID.
+func (repo *stocktakeRepo) Approve(id int64, actor string) (*model.Stocktake, error) {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	stocktake, err := lockOpenStocktake(tx, id)
+	if err != nil {
+		return nil, err
+	}
+	stocktakes := []model.Stocktake{*stocktake}
+	if err := attachStocktakeLines(tx, stocktakes); err != nil {
+		return nil, err
+	}
+	lines := stocktakes[0].Lines
+
+	var ids []int
+	for _, line := range lines {
+		if line.ProductID != nil && line.Variance != nil && *line.Variance != 0 && !slices.Contains(ids, *line.ProductID) {
+			ids = append(ids, *line.ProductID)
+		}
+	}
+	slices.Sort(ids)
+	for _, productID := range ids {
+		if err := lockProduct(tx, productID); err != nil {
+			return nil, err
+		}
+		for _, line := range lines {
+			if line.ProductID == nil || *line.ProductID != productID || line.Variance == nil || *line.Variance == 0 {
+				continue
+			}
+			if _, err := adjustWarehouseStock(tx, productID, line.WarehouseID, *line.Variance, model.LedgerStocktake, &id, actor); err != nil {
+				return nil, fmt.Errorf("produk %d di gudang %d: %w", productID, line.WarehouseID, err)
+			}
+		}
+	}
+
+	stocktake, err = closeStocktake(tx, id, model.StocktakeApproved, actor)
+	if err != nil {
+		return nil, err
+	}
+	stocktake.Lines = lines
+	return stocktake, tx.Commit()
+}
+
+// Cancel - batalkan sesi stocktake tanpa mengubah stok
+func (repo *stocktakeRepo) Cancel(id int64, actor string) (*model.Stocktake, error) {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := lockOpenStocktake(tx, id); err != nil {
+		return nil, err
+	}
+	stocktake, err := closeStocktake(tx, id, model.StocktakeCancelled, actor)
+	if err != nil {
+		return nil, err
+	}
+	stocktakes := []model.Stocktake{*stocktake}
+	if err := attachStocktakeLines(tx, stocktakes); err != nil {
+		return nil, err
+	}
+	return &stocktakes[0], tx.Commit()
+}
+
+// lockOpenStocktake mengunci sesi stocktake dan memastikan statusnya open
+func lockOpenStocktake(tx *sql.Tx, id int64) (*model.Stocktake, error) {
+	stocktake, err := scanStocktake(tx.QueryRow("SELECT "+stocktakeColumns+" FROM stocktakes WHERE id = $1 FOR UPDATE", id))
+	if err != nil {
+		return nil, err
+	}
+	if stocktake.Status != model.StocktakeOpen {
+		return nil, fmt.Errorf("stocktake %d berstatus %s: %w", id, stocktake.Status, ErrStocktakeClosed)
+	}
+	return stocktake, nil
+}
+
+func closeStocktake(tx *sql.Tx, id int64, status, actor string) (*model.Stocktake, error) {
+	query := "UPDATE stocktakes SET status = $1, closed_by = $2, closed_at = now() WHERE id = $3 RETURNING " + stocktakeColumns
+	return scanStocktake(tx.QueryRow(query, status, actor, id))
+}
+
+// Variance - ringkasan selisih hitungan sesi stocktake. Jumlah baris,
+// unit lebih dan kurang, serta nilai selisih per mata uang dihitung di
+// database.
+func (repo *stocktakeRepo) Variance(id int64) (*model.StocktakeVariance, error) {
+	var status string
+	err := repo.db.QueryRow("SELECT status FROM stocktakes WHERE id = $1", id).Scan(&status)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("stocktake %w", ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	report := &model.StocktakeVariance{StocktakeID: id, Status: status, Value: make([]model.Money, 0), Items: make([]model.StocktakeLine, 0)}
+	query := `SELECT COUNT(*), COUNT(counted),
+        COUNT(*) FILTER (WHERE counted <> expected),
+        COALESCE(SUM(counted - expected) FILTER (WHERE counted > expected), 0),
+        COALESCE(SUM(expected - counted) FILTER (WHERE counted < expected), 0)
+    FROM stocktake_lines WHERE stocktake_id = $1`
+	err = repo.db.QueryRow(query, id).Scan(&report.Lines, &report.Counted, &report.WithVariance, &report.Over, &report.Short)
+	if err != nil {
+		return nil, err
+	}
+
+	query = `SELECT currency, SUM((counted - expected) * unit_cost_amount)::bigint
+    FROM stocktake_lines WHERE stocktake_id = $1 AND counted <> expected
+    GROUP BY currency ORDER BY currency`
+	rows, err := repo.db.Query(query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var m model.Money
+		if err := rows.Scan(&m.Currency, &m.Amount); err != nil {
+			return nil, err
+		}
+		report.Value = append(report.Value, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	query = `SELECT ` + stocktakeLineColumns + ` FROM stocktake_lines
+    WHERE stocktake_id = $1 AND counted <> expected
+    ORDER BY abs(counted - expected) DESC, id`
+	rows, err = repo.db.Query(query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		line, err := scanStocktakeLine(rows)
+		if err != nil {
+			return nil, err
+		}
+		report.Items = append(report.Items, *line)
+	}
+	return report, rows.Err()
+}
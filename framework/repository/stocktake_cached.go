@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"go-boot-category-api/framework/cache"
+	"go-boot-category-api/model"
+)
+
+type cachedStocktakeRepo struct {
+	next  Stocktake
+	cache cache.Cache
+}
+
+// NewCachedStocktake membungkus repository stocktake supaya persetujuan
+// sesi menghapus cache produk dan kategori yang stoknya disesuaikan. Sesi
+// stocktake sendiri tidak di-cache.
+func NewCachedStocktake(next Stocktake, c cache.Cache) Stocktake {
+	return &cachedStocktakeRepo{next: next, cache: c}
+}
+
+func (repo *cachedStocktakeRepo) GetAll(status string, page model.Pagination) (*model.StocktakePage, error) {
+	return repo.next.GetAll(status, page)
+}
+
+func (repo *cachedStocktakeRepo) GetByID(id int64) (*model.Stocktake, error) {
+	return repo.next.GetByID(id)
+}
+
+func (repo *cachedStocktakeRepo) Create(stocktake *model.Stocktake) error {
+	return repo.next.Create(stocktake)
+}
+
+func (repo *cachedStocktakeRepo) Count(id int64, counts []model.StocktakeCount, actor string) (*model.Stocktake, error) {
+	return repo.next.Count(id, counts, actor)
+}
+
+func (repo *cachedStocktakeRepo) Approve(id int64, actor string) (*model.Stocktake, error) {
+	stocktake, err := repo.next.Approve(id, actor)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range stocktake.Lines {
+		if line.ProductID != nil && line.Variance != nil && *line.Variance != 0 {
+			repo.cache.Delete(productCacheKey(*line.ProductID))
+		}
+	}
+	repo.cache.DeletePrefix(categoryCachePrefix)
+	return stocktake, nil
+}
+
+func (repo *cachedStocktakeRepo) Cancel(id int64, actor string) (*model.Stocktake, error) {
+	return repo.next.Cancel(id, actor)
+}
+
+func (repo *cachedStocktakeRepo) Variance(id int64) (*model.StocktakeVariance, error) {
+	return repo.next.Variance(id)
+}
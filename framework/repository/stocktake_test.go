@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"go-boot-category-api/model"
+	"testing"
+)
+
+// openStocktake membuka sesi stocktake untuk gudang default
+func openStocktake(t *testing.T, db *sql.DB) (*model.Stocktake, int) {
+	t.Helper()
+	warehouseID := defaultWarehouse(t, db)
+	stocktake := &model.Stocktake{WarehouseID: &warehouseID, Actor: "test"}
+	if err := NewStocktake(db).Create(stocktake); err != nil {
+		t.Fatalf("create stocktake: %v", err)
+	}
+	return stocktake, warehouseID
+}
+
+func count(productID, warehouseID, counted int) model.StocktakeCount {
+	return model.StocktakeCount{ProductID: productID, WarehouseID: warehouseID, Counted: &counted}
+}
+
+func TestStocktakeApprove(t *testing.T) {
+	db := testDB(t)
+	repo := NewStocktake(db)
+	counted := createProduct(t, db, "Counted Shirt", 1000, 10)
+	uncounted := createProduct(t, db, "Uncounted Shirt", 1000, 4)
+	stocktake, warehouseID := openStocktake(t, db)
+
+	if _, err := repo.Count(stocktake.ID, []model.StocktakeCount{count(counted.ID, warehouseID, 7)}, "counter"); err != nil {
+		t.Fatalf("Count() error = %v", err)
+	}
+	variance, err := repo.Variance(stocktake.ID)
+	if err != nil {
+		t.Fatalf("Variance() error = %v", err)
+	}
+	if variance.Lines != 2 || variance.Counted != 1 || variance.WithVariance != 1 || variance.Short != 3 || variance.Over != 0 {
+		t.Errorf("Variance() = %+v, want 2 lines, 1 counted, 3 short", variance)
+	}
+
+	approved, err := repo.Approve(stocktake.ID, "manager")
+	if err != nil {
+		t.Fatalf("Approve() error = %v", err)
+	}
+	if approved.Status != model.StocktakeApproved || approved.ClosedBy != "manager" {
+		t.Errorf("Approve() = %s by %s, want approved by manager", approved.Status, approved.ClosedBy)
+	}
+	expectStock(t, db, counted.ID, 7)
+	if got := ledgerSum(t, db, counted.ID, model.LedgerStocktake); got != -3 {
+		t.Errorf("stocktake ledger = %d, want -3", got)
+	}
+	expectStock(t, db, uncounted.ID, 4)
+	if got := ledgerSum(t, db, uncounted.ID, model.LedgerStocktake); got != 0 {
+		t.Errorf("uncounted stocktake ledger = %d, want 0", got)
+	}
+
+	// sesi yang sudah ditutup tidak bisa dihitung atau disetujui lagi
+	if _, err := repo.Approve(stocktake.ID, "manager"); !errors.Is(err, ErrStocktakeClosed) {
+		t.Errorf("second Approve() error = %v, want ErrStocktakeClosed", err)
+	}
+	if _, err := repo.Count(stocktake.ID, []model.StocktakeCount{count(counted.ID, warehouseID, 5)}, "counter"); !errors.Is(err, ErrStocktakeClosed) {
+		t.Errorf("Count() after approve error = %v, want ErrStocktakeClosed", err)
+	}
+	expectStock(t, db, counted.ID, 7)
+}
+
+func TestStocktakeApproveKeepsMovementsDuringCount(t *testing.T) {
+	db := testDB(t)
+	repo := NewStocktake(db)
+	product := createProduct(t, db, "Busy Shirt", 1000, 10)
+	stocktake, warehouseID := openStocktake(t, db)
+
+	// 2 unit terjual setelah salinan diambil; hitungan 9 berarti selisih -1
+	// terhadap salinan 10, sehingga stok akhir 8 - 1 = 7
+	if err := NewOrder(db).Create(newOrder(product.ID, 2)); err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	if _, err := repo.Count(stocktake.ID, []model.StocktakeCount{count(product.ID, warehouseID, 9)}, "counter"); err != nil {
+		t.Fatalf("Count() error = %v", err)
+	}
+	if _, err := repo.Approve(stocktake.ID, "manager"); err != nil {
+		t.Fatalf("Approve() error = %v", err)
+	}
+	expectStock(t, db, product.ID, 7)
+}
+
+func TestStocktakeApproveInsufficientStock(t *testing.T) {
+	db := testDB(t)
+	repo := NewStocktake(db)
+	product := createProduct(t, db, "Oversold Shirt", 1000, 10)
+	stocktake, warehouseID := openStocktake(t, db)
+
+	if err := NewOrder(db).Create(newOrder(product.ID, 8)); err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	if _, err := repo.Count(stocktake.ID, []model.StocktakeCount{count(product.ID, warehouseID, 5)}, "counter"); err != nil {
+		t.Fatalf("Count() error = %v", err)
+	}
+
+	// selisih -5 dari stok saat ini 2 akan membuat stok negatif
+	if _, err := repo.Approve(stocktake.ID, "manager"); !errors.Is(err, ErrInsufficientStock) {
+		t.Fatalf("Approve() error = %v, want ErrInsufficientStock", err)
+	}
+	expectStock(t, db, product.ID, 2)
+	got, err := repo.GetByID(stocktake.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Status != model.StocktakeOpen {
+		t.Errorf("status = %s, want %s", got.Status, model.StocktakeOpen)
+	}
+}
+
+func TestStocktakeCountUnknownLine(t *testing.T) {
+	db := testDB(t)
+	repo := NewStocktake(db)
+	product := createProduct(t, db, "Listed Shirt", 1000, 1)
+	stocktake, warehouseID := openStocktake(t, db)
+
+	_, err := repo.Count(stocktake.ID, []model.StocktakeCount{count(product.ID, warehouseID, 1), count(product.ID+100, warehouseID, 1)}, "counter")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Count() error = %v, want ErrNotFound", err)
+	}
+	got, err := repo.GetByID(stocktake.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Lines[0].Counted != nil {
+		t.Errorf("Count() with an unknown line saved %d", *got.Lines[0].Counted)
+	}
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"go-boot-category-api/model"
+)
+
+type Supplier interface {
+	GetAll() ([]model.Supplier, error)
+	GetByID(id int) (*model.Supplier, error)
+	Create(supplier *model.Supplier) error
+}
+
+type supplierRepo struct {
+	db *sql.DB
+}
+
+func NewSupplier(db *sql.DB) Supplier {
+	return &supplierRepo{db: db}
+}
+
+func (repo *supplierRepo) GetAll() ([]model.Supplier, error) {
+	query := "SELECT id, name, email, phone, created_at, updated_at FROM suppliers ORDER BY name"
+	rows, err := repo.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	suppliers := make([]model.Supplier, 0)
+	for rows.Next() {
+		var s model.Supplier
+		if err := rows.Scan(&s.ID, &s.Name, &s.Email, &s.Phone, &s.CreatedAt, &s.UpdatedAt); err != nil {
+			return nil, err
+		}
+		suppliers = append(suppliers, s)
+	}
+
+	return suppliers, rows.Err()
+}
+
+func (repo *supplierRepo) GetByID(id int) (*model.Supplier, error) {
+	query := "SELECT id, name, email, phone, created_at, updated_at FROM suppliers WHERE id = $1"
+
+	var s model.Supplier
+	err := repo.db.QueryRow(query, id).Scan(&s.ID, &s.Name, &s.Email, &s.Phone, &s.CreatedAt, &s.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("supplier %w", ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+func (repo *supplierRepo) Create(supplier *model.Supplier) error {
+	query := "INSERT INTO suppliers (name, email, phone) VALUES ($1, $2, $3) RETURNING id, created_at, updated_at"
+	err := repo.db.QueryRow(query, supplier.Name, supplier.Email, supplier.Phone).Scan(&supplier.ID, &supplier.CreatedAt, &supplier.UpdatedAt)
+	return duplicateError(err, "supplier")
+}
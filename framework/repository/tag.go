@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"go-boot-category-api/model"
+)
+
+type Tag interface {
+	GetAll() ([]model.Tag, error)
+	Create(tag *model.Tag) error
+	Delete(id int) error
+}
+
+type tagRepo struct {
+	db *sql.DB
+}
+
+func NewTag(db *sql.DB) Tag {
+	return &tagRepo{db: db}
+}
+
+// GetAll - semua tag beserta jumlah produk yang memakainya, tag paling
+// populer lebih dulu
+func (repo *tagRepo) GetAll() ([]model.Tag, error) {
+	query := `SELECT t.id, t.name, COUNT(pt.product_id) AS product_count, t.created_at
+    FROM tags t
+    LEFT JOIN product_tags pt ON pt.tag_id = t.id
+    GROUP BY t.id
+    ORDER BY product_count DESC, t.name`
+	rows, err := repo.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := make([]model.Tag, 0)
+	for rows.Next() {
+		var t model.Tag
+		if err := rows.Scan(&t.ID, &t.Name, &t.ProductCount, &t.CreatedAt); err != nil {
+			return nil, err
+		}
+		tags = append(tags, t)
+	}
+
+	return tags, rows.Err()
+}
+
+func (repo *tagRepo) Create(tag *model.Tag) error {
+	query := "INSERT INTO tags (name) VALUES ($1) RETURNING id, created_at"
+	err := repo.db.QueryRow(query, tag.Name).Scan(&tag.ID, &tag.CreatedAt)
+	return duplicateError(err, "tag")
+}
+
+func (repo *tagRepo) Delete(id int) error {
+	query := "DELETE FROM tags WHERE id = $1"
+	result, err := repo.db.Exec(query, id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("tag %w", ErrNotFound)
+	}
+
+	return nil
+}
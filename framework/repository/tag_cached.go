@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"go-boot-category-api/framework/cache"
+	"go-boot-category-api/model"
+)
+
+type cachedTagRepo struct {
+	next  Tag
+	cache cache.Cache
+}
+
+// NewCachedTag membungkus repository tag supaya penghapusan tag juga
+// menghapus cache produk, karena produk menyimpan daftar nama tagnya.
+// Daftar tag sendiri tidak di-cache karena jumlah pemakaiannya berubah
+// setiap kali tag dipasang ke produk.
+func NewCachedTag(next Tag, c cache.Cache) Tag {
+	return &cachedTagRepo{next: next, cache: c}
+}
+
+func (repo *cachedTagRepo) GetAll() ([]model.Tag, error) {
+	return repo.next.GetAll()
+}
+
+func (repo *cachedTagRepo) Create(tag *model.Tag) error {
+	return repo.next.Create(tag)
+}
+
+func (repo *cachedTagRepo) Delete(id int) error {
+	if err := repo.next.Delete(id); err != nil {
+		return err
+	}
+	repo.cache.DeletePrefix(productCachePrefix)
+	return nil
+}
@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-boot-category-api/model"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type Variant interface {
+	GetByProduct(productID int) ([]model.Variant, error)
+	GetByID(productID, id int) (*model.Variant, error)
+	Create(variant *model.Variant) error
+	Update(variant *model.Variant) error
+	Delete(productID, id int) error
+	ExistsBySKU(sku string, excludeID int) (bool, error)
+}
+
+// ErrDuplicate di-wrap ketika insert/update melanggar unique constraint.
+var ErrDuplicate = errors.New("sudah ada")
+
+// ErrInsufficientStock dikembalikan ketika pengurangan stok membuat stok
+// menjadi negatif.
+var ErrInsufficientStock = errors.New("stok tidak mencukupi")
+
+type variantRepo struct {
+	db *sql.DB
+}
+
+func NewVariant(db *sql.DB) Variant {
+	return &variantRepo{db: db}
+}
+
+const variantColumns = `v.id, v.product_id, v.sku, v.options, v.price_amount, v.price_currency, v.updated_at`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanVariant(row rowScanner) (*model.Variant, error) {
+	var v model.Variant
+	var options []byte
+	var priceAmount sql.NullInt64
+	var currency sql.NullString
+	err := row.Scan(&v.ID, &v.ProductID, &v.SKU, &options, &priceAmount, &currency, &v.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(options, &v.Options); err != nil {
+		return nil, err
+	}
+	if priceAmount.Valid {
+		v.Price = &model.Money{Amount: priceAmount.Int64, Currency: currency.String}
+	}
+	return &v, nil
+}
+
+func (repo *variantRepo) GetByProduct(productID int) ([]model.Variant, error) {
+	query := `SELECT ` + variantColumns + `
+    FROM product_variants v
+    WHERE v.product_id = $1
+    ORDER BY v.id`
+	rows, err := repo.db.Query(query, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	variants := make([]model.Variant, 0)
+	for rows.Next() {
+		v, err := scanVariant(rows)
+		if err != nil {
+			return nil, err
+		}
+		variants = append(variants, *v)
+	}
+
+	return variants, rows.Err()
+}
+
+// GetByID - ambil varian milik produk tertentu
+func (repo *variantRepo) GetByID(productID, id int) (*model.Variant, error) {
+	query := `SELECT ` + variantColumns + `
+    FROM product_variants v
+    WHERE v.product_id = $1 AND v.id = $2`
+
+	v, err := scanVariant(repo.db.QueryRow(query, productID, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("varian %w", ErrNotFound)
+	}
+	return v, err
+}
+
+func (repo *variantRepo) Create(variant *model.Variant) error {
+	options, err := json.Marshal(variant.Options)
+	if err != nil {
+		return err
+	}
+
+	amount, currency := variantPrice(variant.Price)
+	query := `INSERT INTO product_variants (product_id, sku, options, price_amount, price_currency)
+    VALUES ($1, $2, $3, $4, $5) RETURNING id, updated_at`
+	err = repo.db.QueryRow(query, variant.ProductID, variant.SKU, options, amount, currency).
+		Scan(&variant.ID, &variant.UpdatedAt)
+	return duplicateError(err, "varian")
+}
+
+func (repo *variantRepo) Update(variant *model.Variant) error {
+	options, err := json.Marshal(variant.Options)
+	if err != nil {
+		return err
+	}
+
+	amount, currency := variantPrice(variant.Price)
+	query := `UPDATE product_variants
+    SET sku = $1, options = $2, price_amount = $3, price_currency = $4, updated_at = now()
+    WHERE product_id = $5 AND id = $6
+    RETURNING updated_at`
+	err = repo.db.QueryRow(query, variant.SKU, options, amount, currency, variant.ProductID, variant.ID).
+		Scan(&variant.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("varian %w", ErrNotFound)
+	}
+	return duplicateError(err, "varian")
+}
+
+func (repo *variantRepo) Delete(productID, id int) error {
+	query := "DELETE FROM product_variants WHERE product_id = $1 AND id = $2"
+	result, err := repo.db.Exec(query, productID, id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("varian %w", ErrNotFound)
+	}
+
+	return nil
+}
+
+func (repo *variantRepo) ExistsBySKU(sku string, excludeID int) (bool, error) {
+	query := "SELECT EXISTS(SELECT 1 FROM product_variants WHERE sku = $1 AND id <> $2)"
+	exists := false
+	err := repo.db.QueryRow(query, sku, excludeID).Scan(&exists)
+	return exists, err
+}
+
+// variantPrice mengembalikan kolom price_amount dan price_currency untuk
+// harga override varian; keduanya null jika varian memakai harga produk.
+func variantPrice(price *model.Money) (interface{}, interface{}) {
+	if price == nil {
+		return nil, nil
+	}
+	return price.Amount, price.Currency
+}
+
+// duplicateError menerjemahkan unique violation Postgres (23505) menjadi
+// ErrDuplicate, misal "varian sudah ada".
+func duplicateError(err error, entity string) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return fmt.Errorf("%s %w", entity, ErrDuplicate)
+	}
+	return err
+}
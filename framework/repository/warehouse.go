@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"go-boot-category-api/model"
+)
+
+type Warehouse interface {
+	GetAll() ([]model.Warehouse, error)
+	GetByID(id int) (*model.Warehouse, error)
+	Create(warehouse *model.Warehouse) error
+}
+
+type warehouseRepo struct {
+	db *sql.DB
+}
+
+func NewWarehouse(db *sql.DB) Warehouse {
+	return &warehouseRepo{db: db}
+}
+
+func (repo *warehouseRepo) GetAll() ([]model.Warehouse, error) {
+	query := "SELECT id, code, name, is_default, created_at FROM warehouses ORDER BY id"
+	rows, err := repo.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	warehouses := make([]model.Warehouse, 0)
+	for rows.Next() {
+		var w model.Warehouse
+		if err := rows.Scan(&w.ID, &w.Code, &w.Name, &w.IsDefault, &w.CreatedAt); err != nil {
+			return nil, err
+		}
+		warehouses = append(warehouses, w)
+	}
+
+	return warehouses, rows.Err()
+}
+
+func (repo *warehouseRepo) GetByID(id int) (*model.Warehouse, error) {
+	query := "SELECT id, code, name, is_default, created_at FROM warehouses WHERE id = $1"
+
+	var w model.Warehouse
+	err := repo.db.QueryRow(query, id).Scan(&w.ID, &w.Code, &w.Name, &w.IsDefault, &w.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("gudang %w", ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &w, nil
+}
+
+func (repo *warehouseRepo) Create(warehouse *model.Warehouse) error {
+	query := "INSERT INTO warehouses (code, name) VALUES ($1, $2) RETURNING id, is_default, created_at"
+	err := repo.db.QueryRow(query, warehouse.Code, warehouse.Name).Scan(&warehouse.ID, &warehouse.IsDefault, &warehouse.CreatedAt)
+	return duplicateError(err, "gudang")
+}
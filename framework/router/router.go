@@ -0,0 +1,168 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Route adalah satu endpoint: method + path pattern ServeMux (Go 1.22+),
+// misal GET /api/products/{id}. Name dipakai sebagai identitas route,
+// misal untuk konfigurasi per route. Version dan Deprecated diisi oleh
+// MountVersion.
+type Route struct {
+	Name       string
+	Method     string
+	Path       string
+	Version    string
+	Deprecated bool
+	Handler    http.HandlerFunc
+	Doc        Doc
+}
+
+// Doc adalah metadata dokumentasi route, dipakai untuk generate OpenAPI.
+// Request dan nilai Responses berisi contoh value dengan tipe body-nya,
+// misal model.Product{}; nil berarti tanpa body, dan string berarti
+// body text/plain.
+type Doc struct {
+	Summary   string
+	Tags      []string
+	Query     []Param
+	Request   interface{}
+	Responses map[int]interface{}
+}
+
+// Param adalah query parameter sebuah route.
+type Param struct {
+	Name        string
+	Description string
+	Type        string // integer, string, boolean; default string
+}
+
+// ErrorResponse adalah bentuk body error JSON dari WriteError.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// Resource adalah handler yang mendaftarkan route-nya sendiri.
+type Resource interface {
+	Routes() []Route
+}
+
+// Without mengembalikan res tanpa route bernama names, untuk versi API yang
+// hanya menyediakan sebagian endpoint sebuah resource.
+func Without(res Resource, names ...string) Resource {
+	return resourceFunc(func() []Route {
+		return slices.DeleteFunc(res.Routes(), func(route Route) bool {
+			return slices.Contains(names, route.Name)
+		})
+	})
+}
+
+type resourceFunc func() []Route
+
+func (f resourceFunc) Routes() []Route {
+	return f()
+}
+
+// Middleware membungkus handler sebuah route dan bisa membaca info route-nya.
+type Middleware func(route Route, next http.HandlerFunc) http.HandlerFunc
+
+// Router adalah registry route di atas http.ServeMux yang mengembalikan
+// JSON untuk 404 dan 405 (lengkap dengan header Allow).
+type Router struct {
+	mux         *http.ServeMux
+	paths       *http.ServeMux
+	allowed     map[string][]string
+	routes      []Route
+	middlewares []Middleware
+}
+
+func New() *Router {
+	return &Router{
+		mux:     http.NewServeMux(),
+		paths:   http.NewServeMux(),
+		allowed: make(map[string][]string),
+	}
+}
+
+// Use menambahkan middleware untuk route yang di-mount setelahnya.
+func (rt *Router) Use(mw Middleware) {
+	rt.middlewares = append(rt.middlewares, mw)
+}
+
+// Mount mendaftarkan semua route milik resources.
+func (rt *Router) Mount(resources ...Resource) {
+	for _, res := range resources {
+		for _, route := range res.Routes() {
+			rt.Handle(route)
+		}
+	}
+}
+
+// Handle mendaftarkan satu route.
+func (rt *Router) Handle(route Route) {
+	handler := route.Handler
+	for i := len(rt.middlewares) - 1; i >= 0; i-- {
+		handler = rt.middlewares[i](route, handler)
+	}
+	path := route.Path
+	rt.mux.HandleFunc(route.Method+" "+path, handler)
+
+	if _, ok := rt.allowed[path]; !ok {
+		rt.paths.HandleFunc(path, func(http.ResponseWriter, *http.Request) {})
+	}
+	rt.allowed[path] = append(rt.allowed[path], route.Method)
+	rt.routes = append(rt.routes, route)
+}
+
+// HandleFunc adalah shortcut Handle untuk route tanpa nama.
+func (rt *Router) HandleFunc(method, path string, handler http.HandlerFunc) {
+	rt.Handle(Route{Method: method, Path: path, Handler: handler})
+}
+
+// Routes mengembalikan semua route yang terdaftar, sesuai urutan daftar.
+func (rt *Router) Routes() []Route {
+	return append([]Route(nil), rt.routes...)
+}
+
+func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if _, pattern := rt.mux.Handler(r); pattern != "" {
+		rt.mux.ServeHTTP(w, r)
+		return
+	}
+
+	if _, path := rt.paths.Handler(r); path != "" {
+		w.Header().Set("Allow", allowHeader(rt.allowed[path]))
+		WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	WriteError(w, http.StatusNotFound, "Resource not found")
+}
+
+func allowHeader(methods []string) string {
+	set := make(map[string]bool)
+	for _, m := range methods {
+		set[m] = true
+		if m == http.MethodGet {
+			set[http.MethodHead] = true
+		}
+	}
+	list := make([]string, 0, len(set))
+	for m := range set {
+		list = append(list, m)
+	}
+	sort.Strings(list)
+	return strings.Join(list, ", ")
+}
+
+// WriteError menulis response error dalam format JSON {"error": "..."}.
+func WriteError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(ErrorResponse{Error: message})
+}
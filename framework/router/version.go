@@ -0,0 +1,50 @@
+package router
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// Version adalah satu versi API yang di-mount di bawah Prefix, misal /api/v1.
+// Jika Deprecated diisi, setiap response diberi header Deprecation, Sunset
+// dan Link ke versi penggantinya.
+type Version struct {
+	Prefix     string
+	Deprecated *Deprecation
+}
+
+// Deprecation berisi info deprecation sebuah versi (RFC 9745 dan RFC 8594).
+type Deprecation struct {
+	Since     int64  // unix timestamp mulai deprecated
+	Sunset    string // HTTP-date kapan versi ini dimatikan, boleh kosong
+	Successor string // prefix versi pengganti, misal /api/v1
+}
+
+// MountVersion mendaftarkan route milik resources di bawah prefix versi.
+// Path route pada resource ditulis relatif, misal /products/{id}.
+func (rt *Router) MountVersion(v Version, resources ...Resource) {
+	for _, res := range resources {
+		for _, route := range res.Routes() {
+			route.Version = v.Prefix
+			route.Path = v.Prefix + route.Path
+			if v.Deprecated != nil {
+				route.Deprecated = true
+				route.Handler = deprecated(*v.Deprecated, route.Handler)
+			}
+			rt.Handle(route)
+		}
+	}
+}
+
+func deprecated(d Deprecation, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "@"+strconv.FormatInt(d.Since, 10))
+		if d.Sunset != "" {
+			w.Header().Set("Sunset", d.Sunset)
+		}
+		if d.Successor != "" {
+			w.Header().Add("Link", "<"+d.Successor+">; rel=\"successor-version\"")
+		}
+		next(w, r)
+	}
+}
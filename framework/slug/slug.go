@@ -0,0 +1,83 @@
+package slug
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxLength adalah panjang maksimum slug, tanpa suffix angka.
+const MaxLength = 80
+
+// huruf Latin yang tidak terurai oleh normalisasi NFKD
+var transliterations = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'Æ': "ae", 'œ': "oe", 'Œ': "oe",
+	'ø': "o", 'Ø': "o", 'đ': "d", 'Đ': "d", 'ð': "d", 'Ð': "d",
+	'ł': "l", 'Ł': "l", 'þ': "th", 'Þ': "th", 'ı': "i",
+}
+
+// Make membuat slug URL-safe dari s: huruf beraksen ditransliterasi ke
+// ASCII ("Café Crème" menjadi "cafe-creme"), karakter lain selain huruf
+// dan angka ASCII menjadi pemisah "-". Hasilnya bisa kosong jika s tidak
+// mengandung huruf Latin atau angka sama sekali, misal hanya emoji.
+func Make(s string) string {
+	var b strings.Builder
+	pendingDash := false
+	write := func(token string) {
+		if pendingDash && b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		b.WriteString(token)
+		pendingDash = false
+	}
+
+	for _, r := range norm.NFKD.String(s) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		if r == '&' {
+			pendingDash = true
+			write("and")
+			pendingDash = true
+			continue
+		}
+		if t, ok := transliterations[r]; ok {
+			write(t)
+			continue
+		}
+
+		r = unicode.ToLower(r)
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			write(string(r))
+			continue
+		}
+		pendingDash = true
+	}
+
+	result := b.String()
+	if len(result) > MaxLength {
+		result = strings.TrimRight(result[:MaxLength], "-")
+	}
+	return result
+}
+
+// Unique mencari slug yang belum dipakai dengan menambahkan suffix angka:
+// base, base-2, base-3, dan seterusnya. Jika base kosong, fallback dipakai.
+func Unique(base, fallback string, taken func(string) (bool, error)) (string, error) {
+	if base == "" {
+		base = fallback
+	}
+	candidate := base
+	for i := 2; ; i++ {
+		exists, err := taken(candidate)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return candidate, nil
+		}
+		candidate = base + "-" + strconv.Itoa(i)
+	}
+}
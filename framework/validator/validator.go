@@ -0,0 +1,312 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// FieldError adalah satu pelanggaran rule pada satu field.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// Errors adalah kumpulan FieldError dan memenuhi interface error.
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, fe := range e {
+		messages[i] = fe.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Struct memvalidasi struct berdasarkan tag `validate`, misal
+// `validate:"required,min=3,max=255"`. Semua field dicek dan semua error
+// dikumpulkan; hasilnya nil, Errors, atau error biasa jika tag-nya sendiri
+// tidak valid.
+//
+// Rule yang didukung:
+//   - required: string tidak kosong, angka tidak nol, slice/map tidak kosong,
+//     pointer tidak nil
+//   - min=N, max=N: jumlah karakter (rune) untuk string, nilai untuk angka,
+//     jumlah item untuk slice/map; pointer nil dilewati
+//   - oneof=a b c: nilai harus salah satu dari daftar
+//
+// Nama field di pesan error memakai nama dari tag json. Struct di dalam
+// slice dan map ikut divalidasi dengan nama seperti "lines[2].quantity".
+// Tag setiap tipe di-parse sekali lalu disimpan; pakai Check saat startup
+// supaya tag yang salah ketahuan sebelum ada request.
+func Struct(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var errs Errors
+	if err := validateStruct(rv, "", &errs); err != nil {
+		return err
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// Check memeriksa tag `validate` tipe v beserta struct di dalamnya, termasuk
+// elemen slice dan map, tanpa memvalidasi nilainya.
+func Check(v interface{}) error {
+	return checkType(reflect.TypeOf(v), make(map[reflect.Type]bool))
+}
+
+func checkType(t reflect.Type, seen map[reflect.Type]bool) error {
+	if t == nil {
+		return nil
+	}
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t.PkgPath() == "time" || seen[t] {
+		return nil
+	}
+	seen[t] = true
+
+	fields, err := fieldsOf(t)
+	if err != nil {
+		return err
+	}
+	for _, f := range fields {
+		if err := checkType(t.Field(f.index).Type, seen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rule adalah satu rule dari tag yang sudah di-parse
+type rule struct {
+	name    string
+	param   string
+	limit   float64
+	options []string
+}
+
+// field adalah field struct yang ikut divalidasi
+type field struct {
+	index int
+	name  string
+	rules []rule
+}
+
+// fields menyimpan hasil parse tag per tipe struct
+var fields sync.Map // reflect.Type -> []field
+
+// fieldsOf mem-parse tag semua field t sekali dan mengembalikan error jika
+// ada rule yang tidak dikenal atau parameternya salah.
+func fieldsOf(t reflect.Type) ([]field, error) {
+	if cached, ok := fields.Load(t); ok {
+		return cached.([]field), nil
+	}
+
+	var result []field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name := fieldName(sf)
+		if name == "-" {
+			continue
+		}
+
+		f := field{index: i, name: name}
+		if tag := sf.Tag.Get("validate"); tag != "" && tag != "-" {
+			for _, item := range strings.Split(tag, ",") {
+				key, param, _ := strings.Cut(strings.TrimSpace(item), "=")
+				r := rule{name: key, param: param}
+				switch key {
+				case "required":
+				case "min", "max":
+					limit, err := strconv.ParseFloat(param, 64)
+					if err != nil {
+						return nil, fmt.Errorf("validator: invalid %s=%q on %s.%s", key, param, t, sf.Name)
+					}
+					r.limit = limit
+				case "oneof":
+					r.options = strings.Fields(param)
+					if len(r.options) == 0 {
+						return nil, fmt.Errorf("validator: oneof without options on %s.%s", t, sf.Name)
+					}
+				default:
+					return nil, fmt.Errorf("validator: unknown rule %q on %s.%s", key, t, sf.Name)
+				}
+				f.rules = append(f.rules, r)
+			}
+		}
+		result = append(result, f)
+	}
+
+	fields.Store(t, result)
+	return result, nil
+}
+
+func validateStruct(rv reflect.Value, prefix string, errs *Errors) error {
+	fields, err := fieldsOf(rv.Type())
+	if err != nil {
+		return err
+	}
+
+	for _, f := range fields {
+		name := prefix + f.name
+		value := rv.Field(f.index)
+
+		for _, r := range f.rules {
+			if fe, ok := check(name, r, value); !ok {
+				*errs = append(*errs, fe)
+				if r.name == "required" {
+					// field kosong: rule lain tidak relevan
+					break
+				}
+			}
+		}
+
+		if err := validateNested(value, name, errs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateNested memvalidasi struct di dalam value, termasuk setiap elemen
+// slice, array, dan map. Nama elemen memakai indeks atau key, misal
+// "lines[2].quantity" atau "prices[IDR].amount".
+func validateNested(value reflect.Value, name string, errs *Errors) error {
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		if value.Type().PkgPath() == "time" {
+			return nil
+		}
+		return validateStruct(value, name+".", errs)
+	case reflect.Slice, reflect.Array:
+		if !hasStruct(value.Type().Elem()) {
+			return nil
+		}
+		for i := 0; i < value.Len(); i++ {
+			if err := validateNested(value.Index(i), fmt.Sprintf("%s[%d]", name, i), errs); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if !hasStruct(value.Type().Elem()) {
+			return nil
+		}
+		keys := value.MapKeys()
+		// urutan map acak; key diurutkan supaya urutan error stabil
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		for _, key := range keys {
+			if err := validateNested(value.MapIndex(key), fmt.Sprintf("%s[%v]", name, key.Interface()), errs); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// hasStruct melaporkan apakah t berisi struct yang perlu divalidasi,
+// supaya slice dan map berisi nilai biasa tidak ditelusuri satu per satu.
+func hasStruct(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && t.PkgPath() != "time"
+}
+
+func fieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+func check(name string, r rule, value reflect.Value) (FieldError, bool) {
+	fe := FieldError{Field: name, Rule: r.name}
+
+	switch r.name {
+	case "required":
+		if value.IsZero() {
+			fe.Message = fmt.Sprintf("%s is required", name)
+			return fe, false
+		}
+	case "min", "max":
+		size, unit, ok := measure(value)
+		if !ok {
+			return fe, true
+		}
+		if r.name == "min" && size < r.limit {
+			fe.Message = fmt.Sprintf("%s must be at least %s%s", name, r.param, unit)
+			return fe, false
+		}
+		if r.name == "max" && size > r.limit {
+			fe.Message = fmt.Sprintf("%s must not exceed %s%s", name, r.param, unit)
+			return fe, false
+		}
+	case "oneof":
+		if value.Kind() != reflect.String || value.String() == "" {
+			return fe, true
+		}
+		for _, option := range r.options {
+			if value.String() == option {
+				return fe, true
+			}
+		}
+		fe.Message = fmt.Sprintf("%s must be one of: %s", name, strings.Join(r.options, ", "))
+		return fe, false
+	}
+	return fe, true
+}
+
+// measure mengembalikan ukuran value yang dibandingkan oleh min/max.
+// Pointer diukur dari nilai yang ditunjuk; pointer nil dilewati.
+func measure(value reflect.Value) (float64, string, bool) {
+	switch value.Kind() {
+	case reflect.Pointer:
+		if value.IsNil() {
+			return 0, "", false
+		}
+		return measure(value.Elem())
+	case reflect.String:
+		return float64(utf8.RuneCountInString(value.String())), " characters", true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return float64(value.Len()), " items", true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), "", true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint()), "", true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), "", true
+	}
+	return 0, "", false
+}
@@ -0,0 +1,138 @@
+package validator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type address struct {
+	City string `json:"city" validate:"required,max=5"`
+}
+
+type sample struct {
+	Name    string              `json:"name" validate:"required,min=2,max=4"`
+	Status  string              `json:"status" validate:"oneof=draft active"`
+	Qty     int                 `json:"qty" validate:"min=1,max=10"`
+	Tags    []string            `json:"tags" validate:"max=2"`
+	Limit   *int                `json:"limit" validate:"min=0"`
+	Home    address             `json:"home"`
+	Office  *address            `json:"office"`
+	Stops   []address           `json:"stops"`
+	Offices map[string]*address `json:"offices"`
+	Skipped string              `json:"-" validate:"required"`
+}
+
+func valid() sample {
+	return sample{Name: "abc", Status: "draft", Qty: 1, Home: address{City: "Bali"}, Skipped: "x"}
+}
+
+func intPtr(v int) *int { return &v }
+
+func TestStruct(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*sample)
+		want   []string // "field:rule"
+	}{
+		{name: "valid", modify: func(s *sample) {}},
+		{name: "multibyte within max", modify: func(s *sample) { s.Name = "ñáéí" }},
+		{name: "emoji within max", modify: func(s *sample) { s.Name = "😀😀😀😀" }},
+		{name: "emoji over max", modify: func(s *sample) { s.Name = "😀😀😀😀😀" }, want: []string{"name:max"}},
+		{name: "single multibyte under min", modify: func(s *sample) { s.Name = "é" }, want: []string{"name:min"}},
+		{name: "required stops other rules", modify: func(s *sample) { s.Name = "" }, want: []string{"name:required"}},
+		{name: "required with max", modify: func(s *sample) { s.Name = "abcde" }, want: []string{"name:max"}},
+		{name: "oneof accepted", modify: func(s *sample) { s.Status = "active" }},
+		{name: "oneof empty skipped", modify: func(s *sample) { s.Status = "" }},
+		{name: "oneof rejected", modify: func(s *sample) { s.Status = "archived" }, want: []string{"status:oneof"}},
+		{name: "number bounds", modify: func(s *sample) { s.Qty = 11 }, want: []string{"qty:max"}},
+		{name: "slice length", modify: func(s *sample) { s.Tags = []string{"a", "b", "c"} }, want: []string{"tags:max"}},
+		{name: "nil pointer skipped", modify: func(s *sample) { s.Limit = nil }},
+		{name: "pointer zero allowed", modify: func(s *sample) { s.Limit = intPtr(0) }},
+		{name: "pointer below min", modify: func(s *sample) { s.Limit = intPtr(-1) }, want: []string{"limit:min"}},
+		{name: "nested struct", modify: func(s *sample) { s.Home.City = "" }, want: []string{"home.city:required"}},
+		{name: "nested pointer", modify: func(s *sample) { s.Office = &address{City: "Jakarta"} }, want: []string{"office.city:max"}},
+		{name: "slice elements", modify: func(s *sample) { s.Stops = []address{{City: "Bali"}, {}, {City: "Jakarta"}} }, want: []string{"stops[1].city:required", "stops[2].city:max"}},
+		{name: "map elements", modify: func(s *sample) {
+			s.Offices = map[string]*address{"west": {}, "east": {City: "Jakarta"}, "north": nil}
+		}, want: []string{"offices[east].city:max", "offices[west].city:required"}},
+		{
+			name: "all errors collected",
+			modify: func(s *sample) {
+				s.Name = ""
+				s.Status = "x"
+				s.Qty = 0
+				s.Office = &address{}
+			},
+			want: []string{"name:required", "status:oneof", "qty:min", "office.city:required"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := valid()
+			tt.modify(&s)
+
+			err := Struct(&s)
+			var got []string
+			if err != nil {
+				var errs Errors
+				if !errors.As(err, &errs) {
+					t.Fatalf("Struct() error = %v, want Errors", err)
+				}
+				for _, fe := range errs {
+					got = append(got, fe.Field+":"+fe.Rule)
+				}
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Struct() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStructInvalidTag(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+	}{
+		{name: "unknown rule", value: &struct {
+			Email string `json:"email" validate:"email"`
+		}{}},
+		{name: "unparsable min", value: &struct {
+			Name string `json:"name" validate:"min=abc"`
+		}{}},
+		{name: "oneof without options", value: &struct {
+			Status string `json:"status" validate:"oneof="`
+		}{}},
+		{name: "nested slice element", value: &struct {
+			Items []struct {
+				Qty int `json:"qty" validate:"max="`
+			} `json:"items"`
+		}{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Check(tt.value); err == nil {
+				t.Error("Check() = nil, want error")
+			}
+		})
+	}
+
+	// Struct mengembalikan error biasa, bukan panic dan bukan Errors
+	err := Struct(tests[1].value)
+	var errs Errors
+	if err == nil || errors.As(err, &errs) {
+		t.Errorf("Struct() error = %v, want a non-validation error", err)
+	}
+}
+
+func TestCheckValid(t *testing.T) {
+	if err := Check(sample{}); err != nil {
+		t.Errorf("Check() = %v", err)
+	}
+	if err := Check(nil); err != nil {
+		t.Errorf("Check(nil) = %v", err)
+	}
+}
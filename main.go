@@ -1,14 +1,25 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"go-boot-category-api/database"
+	"go-boot-category-api/framework/cache"
 	"go-boot-category-api/framework/handler"
+	"go-boot-category-api/framework/middleware"
+	"go-boot-category-api/framework/notify"
+	"go-boot-category-api/framework/openapi"
 	"go-boot-category-api/framework/repository"
+	"go-boot-category-api/framework/router"
+	"go-boot-category-api/framework/validator"
+	"go-boot-category-api/model"
 	"go-boot-category-api/service"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -27,43 +38,175 @@ func main() {
 	}
 	defer db.Close()
 
-	// Setup repositories, services, handlers
-	productRepo := repository.NewProduct(db)
-	productService := service.NewProductService(productRepo)
-	productHandler := handler.NewProductHandler(productService)
+	if err := database.Migrate(db); err != nil {
+		log.Fatal("Failed to migrate database:", err)
+	}
+
+	// Setup cache untuk repository. LRU ini hanya ada di memori proses:
+	// invalidasi dari satu instance tidak sampai ke instance lain, sehingga
+	// deployment multi-instance melihat data basi sampai CACHE_TTL habis.
+	// Pakai cache.NewRedis sebagai cache bersama jika itu tidak bisa diterima.
+	appCache := cache.NewLRU(envInt("CACHE_SIZE", 1000))
+	cacheTTL := envDuration("CACHE_TTL", 5*time.Minute)
+
+	// Batas ukuran body request JSON
+	handler.MaxBodyBytes = envInt64("MAX_BODY_BYTES", 1<<20)
+
+	// Metode costing untuk harga pokok: average (default) atau fifo
+	switch method := envString("COSTING_METHOD", model.CostingAverage); method {
+	case model.CostingAverage, model.CostingFIFO:
+		repository.CostingMethod = method
+	default:
+		log.Fatalf("Invalid COSTING_METHOD %q: must be %s or %s", method, model.CostingAverage, model.CostingFIFO)
+	}
+
+	// Setup repositories dan services; handler dibuat oleh handler.Mount
+	productRepo := repository.NewCachedProduct(repository.NewProduct(db), appCache, cacheTTL)
+	categoryRepo := repository.NewCachedCategory(repository.NewCategory(db), appCache, cacheTTL)
+
+	attributeRepo := repository.NewAttribute(db)
+	stockRepo := repository.NewCachedStock(repository.NewStock(db), appCache)
+	warehouseRepo := repository.NewWarehouse(db)
+
+	productService := service.NewProductService(productRepo, categoryRepo, attributeRepo, stockRepo)
+	categoryService := service.NewCategoryService(categoryRepo, productRepo)
+	attributeService := service.NewAttributeService(attributeRepo, categoryRepo)
+	variantService := service.NewVariantService(repository.NewVariant(db), productRepo)
 
-	categoryRepo := repository.NewCategory(db)
-	categoryService := service.NewCategoryService(categoryRepo)
-	categoryHandler := handler.NewCategoryHandler(categoryService)
+	tagRepo := repository.NewCachedTag(repository.NewTag(db), appCache)
+	tagService := service.NewTagService(tagRepo, productRepo)
+
+	priceService := service.NewPriceService(repository.NewPrice(db), productRepo)
+
+	warehouseService := service.NewWarehouseService(warehouseRepo)
+	stockService := service.NewStockService(stockRepo, productRepo, warehouseRepo)
+
+	reservationRepo := repository.NewCachedReservation(repository.NewReservation(db), appCache)
+	reservationService := service.NewReservationService(reservationRepo, productRepo, warehouseRepo, envDuration("RESERVATION_TTL", 15*time.Minute))
+
+	orderRepo := repository.NewCachedOrder(repository.NewOrder(db), appCache)
+	orderService := service.NewOrderService(orderRepo, productRepo, warehouseRepo)
+
+	supplierRepo := repository.NewSupplier(db)
+	supplierService := service.NewSupplierService(supplierRepo)
+	purchaseOrderRepo := repository.NewCachedPurchaseOrder(repository.NewPurchaseOrder(db), appCache)
+	purchaseOrderService := service.NewPurchaseOrderService(purchaseOrderRepo, supplierRepo, productRepo, warehouseRepo)
+	stocktakeRepo := repository.NewCachedStocktake(repository.NewStocktake(db), appCache)
+	stocktakeService := service.NewStocktakeService(stocktakeRepo, categoryRepo, warehouseRepo)
+	reportService := service.NewReportService(repository.NewReport(db))
+
+	notifier, err := newNotifier()
+	if err != nil {
+		log.Fatal("Invalid notifier config:", err)
+	}
+	lowStockService := service.NewLowStockService(repository.NewLowStock(db), notifier)
+
+	// Scheduler harga, reaper reservasi, dan monitor stok rendah berjalan
+	// di background. Di database aman dijalankan di beberapa instance
+	// sekaligus, tetapi cache hanya diinvalidasi di instance yang
+	// menjalankan job; instance lain menunggu CACHE_TTL kecuali memakai
+	// cache bersama
+	go priceService.RunScheduler(context.Background(), envDuration("PRICE_SCHEDULER_INTERVAL", time.Minute))
+	go reservationService.RunReaper(context.Background(), envDuration("RESERVATION_REAPER_INTERVAL", 30*time.Second))
+	go lowStockService.RunMonitor(context.Background(), envDuration("LOW_STOCK_CHECK_INTERVAL", time.Minute))
 
 	// Setup router dengan middleware
-	mux := http.NewServeMux()
+	cacheControl := map[string]string{
+		"products.list":   envString("CACHE_CONTROL_PRODUCT_LIST", "no-cache"),
+		"products.get":    envString("CACHE_CONTROL_PRODUCT_DETAIL", "no-cache"),
+		"categories.list": envString("CACHE_CONTROL_CATEGORY_LIST", "public, max-age=60"),
+		"categories.get":  envString("CACHE_CONTROL_CATEGORY_DETAIL", "public, max-age=60"),
+	}
+	cacheControl["products.get_by_slug"] = cacheControl["products.get"]
+	cacheControl["products.by_category"] = cacheControl["products.list"]
+	cacheControl["categories.get_by_slug"] = cacheControl["categories.get"]
+	mux := router.New()
+	mux.Use(func(route router.Route, next http.HandlerFunc) http.HandlerFunc {
+		return middleware.CacheControl(cacheControl[route.Name], next)
+	})
+	if envString("OPENAPI_LOG_DRIFT", "false") == "true" {
+		mux.Use(openapi.LogDrift)
+	}
+
+	// Add routes. /api/v2 adalah versi aktif dengan price berupa Money;
+	// /api/v1 masih memakai price integer selama masa transisi. /api tanpa
+	// versi melayani route yang sama dengan v1 tapi ditandai deprecated.
+	v1 := router.Version{
+		Prefix: "/api/v1",
+		Deprecated: &router.Deprecation{
+			Since:     envInt64("API_V1_DEPRECATED_AT", 1792281600), // 2026-10-18
+			Sunset:    os.Getenv("API_V1_SUNSET"),
+			Successor: "/api/v2",
+		},
+	}
+	legacy := router.Version{
+		Prefix: "/api",
+		Deprecated: &router.Deprecation{
+			Since:     envInt64("API_LEGACY_DEPRECATED_AT", 1767225600), // 2026-01-01
+			Sunset:    os.Getenv("API_LEGACY_SUNSET"),
+			Successor: "/api/v2",
+		},
+	}
+	handler.Mount(mux, handler.Services{
+		Product:       productService,
+		Category:      categoryService,
+		Attribute:     attributeService,
+		Variant:       variantService,
+		Tag:           tagService,
+		Price:         priceService,
+		Warehouse:     warehouseService,
+		Stock:         stockService,
+		Reservation:   reservationService,
+		LowStock:      lowStockService,
+		Order:         orderService,
+		Supplier:      supplierService,
+		PurchaseOrder: purchaseOrderService,
+		Stocktake:     stocktakeService,
+		Report:        reportService,
+	}, v1, legacy)
 
-	// Add routes
-	mux.HandleFunc("/api/products", productHandler.HandleProducts)
-	mux.HandleFunc("/api/products/", productHandler.HandleProductByID)
-	mux.HandleFunc("/api/categories", categoryHandler.HandleCategories)
-	mux.HandleFunc("/api/categories/", categoryHandler.HandleCategoryByID)
+	// OpenAPI spec dan docs UI
+	mux.Mount(openapi.NewHandler(openapi.Info{Title: "Category API", Version: "1.0"}, mux))
 
 	// Health check untuk Zeabur
-	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+	mux.Handle(router.Route{Name: "health", Method: http.MethodGet, Path: "/health", Handler: func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]interface{}{
 			"status":    "healthy",
 			"timestamp": time.Now().Unix(),
 			"database":  "connected",
 		})
-	})
+	}, Doc: router.Doc{
+		Summary:   "Health check",
+		Tags:      []string{"meta"},
+		Responses: map[int]interface{}{http.StatusOK: map[string]interface{}{}},
+	}})
 
 	// Root endpoint
-	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+	mux.Handle(router.Route{Name: "root", Method: http.MethodGet, Path: "/{$}", Handler: func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]interface{}{
 			"service":   "Category API",
 			"version":   "1.0",
-			"endpoints": []string{"/api/products", "/api/categories", "/health"},
+			"endpoints": []string{"/api/v2/products", "/api/v2/categories", "/api/v2/orders", "/health", "/openapi.json", "/docs"},
 		})
-	})
+	}, Doc: router.Doc{
+		Summary:   "Service info",
+		Tags:      []string{"meta"},
+		Responses: map[int]interface{}{http.StatusOK: map[string]interface{}{}},
+	}})
+
+	// Semua route wajib terdokumentasi di OpenAPI
+	if err := openapi.Verify(mux.Routes()); err != nil {
+		log.Fatal(err)
+	}
+
+	// Tag validasi body request dicek sekali di sini, bukan saat request masuk
+	for _, route := range mux.Routes() {
+		if err := validator.Check(route.Doc.Request); err != nil {
+			log.Fatalf("Invalid validation tag on %s: %v", route.Name, err)
+		}
+	}
 
 	// Get port
 	port := os.Getenv("PORT")
@@ -71,8 +214,43 @@ func main() {
 		port = "8080"
 	}
 
+	// Rate limit per client supaya satu client tidak menghabiskan connection pool
+	trustedProxies, err := middleware.ParseCIDRs(os.Getenv("TRUSTED_PROXIES"))
+	if err != nil {
+		log.Fatal("Invalid TRUSTED_PROXIES:", err)
+	}
+	readLimit := middleware.Limit{Rate: envFloat("RATE_LIMIT_RATE", 10), Burst: envInt("RATE_LIMIT_BURST", 20)}
+	writeLimit := middleware.Limit{Rate: envFloat("RATE_LIMIT_WRITE_RATE", 2), Burst: envInt("RATE_LIMIT_WRITE_BURST", 5)}
+	rateLimited := middleware.RateLimit(middleware.RateLimitConfig{
+		Store:   middleware.NewMemoryStore(10 * time.Minute),
+		Default: readLimit,
+		Rules: []middleware.RateLimitRule{
+			{Method: http.MethodPost, PathPrefix: "/api/", Limit: writeLimit},
+			{Method: http.MethodPut, PathPrefix: "/api/", Limit: writeLimit},
+			{Method: http.MethodDelete, PathPrefix: "/api/", Limit: writeLimit},
+			{PathPrefix: "/health", Limit: middleware.Limit{}},
+		},
+		TrustedProxies: trustedProxies,
+		APIKeys:        middleware.SplitList(os.Getenv("RATE_LIMIT_API_KEYS")),
+	}, mux)
+
+	// CORS di luar rate limit: preflight tidak memakan kuota, dan response
+	// 429 tetap bisa dibaca oleh browser
+	corsConfig := middleware.CORSConfig{
+		AllowedOrigins:   middleware.SplitList(os.Getenv("CORS_ALLOWED_ORIGINS")),
+		AllowedMethods:   middleware.SplitList(envString("CORS_ALLOWED_METHODS", "GET, POST, PUT, DELETE")),
+		AllowedHeaders:   middleware.SplitList(envString("CORS_ALLOWED_HEADERS", "Content-Type, Authorization, X-API-Key, X-Actor, If-None-Match, If-Modified-Since")),
+		ExposedHeaders:   []string{"ETag", "Last-Modified", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "Retry-After"},
+		AllowCredentials: envString("CORS_ALLOW_CREDENTIALS", "false") == "true",
+		MaxAge:           envDuration("CORS_MAX_AGE", 10*time.Minute),
+	}
+	if err := corsConfig.Validate(); err != nil {
+		log.Fatal("Invalid CORS config:", err)
+	}
+	withCORS := middleware.CORS(corsConfig, rateLimited)
+
 	// Add logging middleware
-	handlerWithLogging := loggingMiddleware(mux)
+	handlerWithLogging := loggingMiddleware(withCORS)
 
 	log.Printf("🚀 Server starting on port %s", port)
 	log.Printf("📡 Access URL: http://localhost:%s", port)
@@ -99,3 +277,78 @@ func loggingMiddleware(next http.Handler) http.Handler {
 		log.Printf("%s %s %v", r.Method, r.URL.Path, time.Since(start))
 	})
 }
+
+// newNotifier membuat notifier sesuai NOTIFIER: log (default), webhook,
+// atau smtp
+func newNotifier() (notify.Notifier, error) {
+	switch kind := envString("NOTIFIER", "log"); kind {
+	case "log":
+		return notify.NewLog(), nil
+	case "webhook":
+		url := os.Getenv("NOTIFY_WEBHOOK_URL")
+		if url == "" {
+			return nil, errors.New("NOTIFY_WEBHOOK_URL is required for the webhook notifier")
+		}
+		return notify.NewWebhook(url, &http.Client{Timeout: envDuration("NOTIFY_WEBHOOK_TIMEOUT", 10*time.Second)}), nil
+	case "smtp":
+		to := middleware.SplitList(os.Getenv("SMTP_TO"))
+		if len(to) == 0 {
+			return nil, errors.New("SMTP_TO is required for the smtp notifier")
+		}
+		return notify.NewSMTP(notify.SMTPConfig{
+			Addr:     envString("SMTP_ADDR", "localhost:1025"),
+			From:     envString("SMTP_FROM", "inventory@localhost"),
+			To:       to,
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			Timeout:  envDuration("SMTP_TIMEOUT", 30*time.Second),
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown NOTIFIER %q", kind)
+	}
+}
+
+// envString membaca env var, atau fallback jika tidak di-set
+func envString(key, fallback string) string {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	return value
+}
+
+// envInt membaca env var bertipe int, atau fallback jika kosong/tidak valid
+func envInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+// envInt64 membaca env var bertipe int64, atau fallback jika kosong/tidak valid
+func envInt64(key string, fallback int64) int64 {
+	value, err := strconv.ParseInt(os.Getenv(key), 10, 64)
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+// envFloat membaca env var bertipe float64, atau fallback jika kosong/tidak valid
+func envFloat(key string, fallback float64) float64 {
+	value, err := strconv.ParseFloat(os.Getenv(key), 64)
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+// envDuration membaca env var dengan format time.ParseDuration, misal "5m"
+func envDuration(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
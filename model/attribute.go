@@ -0,0 +1,49 @@
+package model
+
+// AttributeDefinition mendefinisikan atribut produk yang berlaku untuk satu
+// kategori, misal "voltage" bertipe number untuk kategori elektronik.
+// AllowedValues kosong berarti nilai bebas.
+type AttributeDefinition struct {
+	ID            int      `json:"id"`
+	CategoryID    int      `json:"category_id"`
+	Name          string   `json:"name"`
+	Type          string   `json:"type"`
+	Required      bool     `json:"required"`
+	AllowedValues []string `json:"allowed_values"`
+}
+
+// AttributeDefinitionInput adalah field definisi atribut yang boleh diisi client.
+type AttributeDefinitionInput struct {
+	Name          string   `json:"name" validate:"required,min=1,max=64"`
+	Type          string   `json:"type" validate:"required,oneof=string number boolean date"`
+	Required      bool     `json:"required"`
+	AllowedValues []string `json:"allowed_values" validate:"max=100"`
+}
+
+func (in AttributeDefinitionInput) AttributeDefinition(categoryID int) *AttributeDefinition {
+	allowed := in.AllowedValues
+	if allowed == nil {
+		allowed = []string{}
+	}
+	return &AttributeDefinition{CategoryID: categoryID, Name: in.Name, Type: in.Type, Required: in.Required, AllowedValues: allowed}
+}
+
+// AttributeFilter adalah satu filter atribut dari query string, misal
+// attr.voltage_gte=110 menjadi {Key: "voltage", Op: "gte", Value: "110"}.
+type AttributeFilter struct {
+	Key   string
+	Op    string
+	Value string
+}
+
+// ProductFilter adalah kriteria pencarian produk. Tags berisi grup tag:
+// produk cocok jika memiliki minimal satu tag dari setiap grup, misal
+// [["sale", "new-arrival"], ["halal"]] berarti (sale OR new-arrival) AND halal.
+// CategoryIDs kosong berarti semua kategori. Fields membatasi field yang
+// dibaca dari database (lihat ProductFields); kosong berarti semua field.
+type ProductFilter struct {
+	Attributes  []AttributeFilter
+	Tags        [][]string
+	CategoryIDs []int
+	Fields      []string
+}
@@ -1,7 +1,29 @@
 package model
 
+import "time"
+
+// Category beserta ringkasan produknya. StockValue adalah total
+// price x stock per mata uang, karena produk dalam satu kategori bisa
+// memakai mata uang berbeda.
 type Category struct {
-	ID          int    `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	ID           int       `json:"id"`
+	Name         string    `json:"name"`
+	Slug         string    `json:"slug"`
+	Description  string    `json:"description"`
+	ProductCount int       `json:"product_count"`
+	TotalStock   int       `json:"total_stock"`
+	StockValue   []Money   `json:"stock_value"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	Products     []Product `json:"products,omitempty"`
+}
+
+// CategoryInput adalah field kategori yang boleh diisi client saat
+// create/update. Field read-only seperti id tidak ada di sini.
+type CategoryInput struct {
+	Name        string `json:"name" validate:"required,min=3,max=255"`
+	Description string `json:"description" validate:"max=500"`
+}
+
+func (in CategoryInput) Category() *Category {
+	return &Category{Name: in.Name, Description: in.Description, StockValue: []Money{}}
 }
@@ -0,0 +1,22 @@
+package model
+
+// LowStock adalah produk yang stok on-hand-nya sudah sama dengan atau di
+// bawah reorder point.
+type LowStock struct {
+	ProductID    int    `json:"product_id"`
+	ProductName  string `json:"product_name"`
+	Slug         string `json:"slug"`
+	CategoryID   int    `json:"category_id"`
+	Stock        int    `json:"stock"`
+	Available    int    `json:"available"`
+	ReorderPoint int    `json:"reorder_point"`
+	ReorderQty   int    `json:"reorder_qty"`
+}
+
+// LowStockEvent adalah notifikasi stok rendah yang menunggu dikirim,
+// dicatat saat stok produk turun melewati reorder point. Stock adalah stok
+// saat batas dilewati; field lain dibaca ulang saat event diambil.
+type LowStockEvent struct {
+	ID int64 `json:"-"`
+	LowStock
+}
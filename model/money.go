@@ -0,0 +1,156 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// currencyExponents adalah jumlah digit desimal (minor unit) per mata uang
+// ISO 4217 yang didukung.
+var currencyExponents = map[string]int{
+	"IDR": 2,
+	"USD": 2,
+}
+
+// Money adalah nilai uang dalam minor unit (misal sen) beserta kode mata
+// uang ISO 4217. Di JSON, amount ditulis sebagai string desimal supaya
+// tidak ada pembulatan float: {"amount": "12.34", "currency": "USD"}.
+type Money struct {
+	Amount   int64  `json:"amount" validate:"min=0" openapi:"type=string,format=decimal"`
+	Currency string `json:"currency" validate:"required,oneof=IDR USD"`
+}
+
+// CurrencyExponent mengembalikan jumlah digit minor unit sebuah mata uang.
+func CurrencyExponent(currency string) (int, bool) {
+	exp, ok := currencyExponents[currency]
+	return exp, ok
+}
+
+// NewMoneyFromMajor membuat Money dari nilai dalam unit utuh, misal 15000 IDR.
+// Nilai yang tidak muat di int64 setelah dikonversi ke minor unit ditolak.
+func NewMoneyFromMajor(major int64, currency string) (Money, error) {
+	exp, ok := CurrencyExponent(currency)
+	if !ok {
+		return Money{}, fmt.Errorf("unsupported currency %q", currency)
+	}
+	unit := pow10(exp)
+	if major > math.MaxInt64/unit || major < math.MinInt64/unit {
+		return Money{}, fmt.Errorf("amount %d %s is out of range", major, currency)
+	}
+	return Money{Amount: major * unit, Currency: currency}, nil
+}
+
+// Major mengembalikan nilai dalam unit utuh, dibulatkan ke bawah.
+func (m Money) Major() int64 {
+	exp, _ := CurrencyExponent(m.Currency)
+	return m.Amount / pow10(exp)
+}
+
+// String mengembalikan amount sebagai desimal, misal "12.34".
+func (m Money) String() string {
+	exp, _ := CurrencyExponent(m.Currency)
+	if exp == 0 {
+		return strconv.FormatInt(m.Amount, 10)
+	}
+
+	sign := ""
+	amount := m.Amount
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	unit := pow10(exp)
+	return fmt.Sprintf("%s%d.%0*d", sign, amount/unit, exp, amount%unit)
+}
+
+// ParseMoney mengubah string desimal menjadi Money tanpa melewati float.
+// Format yang diterima: opsional satu "-", digit, lalu opsional "." diikuti
+// paling banyak sejumlah digit minor unit mata uangnya, misal "-12.34".
+func ParseMoney(amount, currency string) (Money, error) {
+	exp, ok := CurrencyExponent(currency)
+	if !ok {
+		return Money{}, fmt.Errorf("unsupported currency %q", currency)
+	}
+
+	raw := strings.TrimSpace(amount)
+	digits, negative := strings.CutPrefix(raw, "-")
+	whole, frac, hasFrac := strings.Cut(digits, ".")
+	if !isDigits(whole) || (hasFrac && (!isDigits(frac) || len(frac) > exp)) {
+		return Money{}, fmt.Errorf("invalid amount %q for %s", raw, currency)
+	}
+	frac += strings.Repeat("0", exp-len(frac))
+
+	// ParseInt menolak nilai di luar int64, jadi tidak bisa overflow
+	value, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid amount %q for %s", raw, currency)
+	}
+	if negative {
+		value = -value
+	}
+	return Money{Amount: value, Currency: currency}, nil
+}
+
+// isDigits memastikan s tidak kosong dan hanya berisi digit 0-9.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Amount   string `json:"amount"`
+		Currency string `json:"currency"`
+	}{Amount: m.String(), Currency: m.Currency})
+}
+
+// UnmarshalJSON menerima amount berupa string ("12.34") maupun angka (12.34).
+// Field selain amount dan currency ditolak, supaya salah ketik seperti
+// "curency" tidak diam-diam diabaikan.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Amount   json.RawMessage `json:"amount"`
+		Currency string          `json:"currency"`
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&raw); err != nil {
+		return err
+	}
+	if len(raw.Amount) == 0 {
+		return errors.New("money amount is required")
+	}
+
+	amount := string(raw.Amount)
+	if strings.HasPrefix(amount, `"`) {
+		if err := json.Unmarshal(raw.Amount, &amount); err != nil {
+			return err
+		}
+	}
+	parsed, err := ParseMoney(amount, raw.Currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+func pow10(exp int) int64 {
+	result := int64(1)
+	for i := 0; i < exp; i++ {
+		result *= 10
+	}
+	return result
+}
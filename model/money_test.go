@@ -0,0 +1,124 @@
+package model
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		amount   string
+		currency string
+		want     int64
+		wantErr  bool
+	}{
+		{amount: "12.34", currency: "USD", want: 1234},
+		{amount: "12.3", currency: "USD", want: 1230},
+		{amount: "12", currency: "USD", want: 1200},
+		{amount: "0", currency: "IDR", want: 0},
+		{amount: "-5", currency: "IDR", want: -500},
+		{amount: "-0.05", currency: "USD", want: -5},
+		{amount: " 7.50 ", currency: "USD", want: 750},
+		{amount: "92233720368547758.07", currency: "USD", want: math.MaxInt64},
+		{amount: "--5", currency: "USD", wantErr: true},
+		{amount: "+5", currency: "USD", wantErr: true},
+		{amount: "-+5", currency: "USD", wantErr: true},
+		{amount: "5.-1", currency: "USD", wantErr: true},
+		{amount: "5.+1", currency: "USD", wantErr: true},
+		{amount: "1.234", currency: "USD", wantErr: true},
+		{amount: "1.2.3", currency: "USD", wantErr: true},
+		{amount: "1.", currency: "USD", wantErr: true},
+		{amount: ".5", currency: "USD", wantErr: true},
+		{amount: "", currency: "USD", wantErr: true},
+		{amount: "-", currency: "USD", wantErr: true},
+		{amount: "1e3", currency: "USD", wantErr: true},
+		{amount: "1 000", currency: "USD", wantErr: true},
+		{amount: "92233720368547758.08", currency: "USD", wantErr: true},
+		{amount: "10", currency: "EUR", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.amount+" "+tt.currency, func(t *testing.T) {
+			got, err := ParseMoney(tt.amount, tt.currency)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseMoney() = %v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseMoney() error = %v", err)
+			}
+			if got.Amount != tt.want || got.Currency != tt.currency {
+				t.Errorf("ParseMoney() = %+v, want %d %s", got, tt.want, tt.currency)
+			}
+		})
+	}
+}
+
+func TestNewMoneyFromMajor(t *testing.T) {
+	tests := []struct {
+		name    string
+		major   int64
+		want    int64
+		wantErr bool
+	}{
+		{name: "zero", major: 0, want: 0},
+		{name: "positive", major: 15000, want: 1500000},
+		{name: "negative", major: -3, want: -300},
+		{name: "largest", major: math.MaxInt64 / 100, want: math.MaxInt64 / 100 * 100},
+		{name: "overflow", major: math.MaxInt64/100 + 1, wantErr: true},
+		{name: "underflow", major: math.MinInt64/100 - 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewMoneyFromMajor(tt.major, "IDR")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("NewMoneyFromMajor() = %v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewMoneyFromMajor() error = %v", err)
+			}
+			if got.Amount != tt.want {
+				t.Errorf("NewMoneyFromMajor() = %d, want %d", got.Amount, tt.want)
+			}
+		})
+	}
+
+	if _, err := NewMoneyFromMajor(1, "EUR"); err == nil {
+		t.Error("NewMoneyFromMajor() with unsupported currency = nil, want error")
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	var m Money
+	if err := json.Unmarshal([]byte(`{"amount": 12.5, "currency": "USD"}`), &m); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if m.Amount != 1250 {
+		t.Errorf("Amount = %d, want 1250", m.Amount)
+	}
+
+	data, err := json.Marshal(Money{Amount: -5, Currency: "USD"})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if string(data) != `{"amount":"-0.05","currency":"USD"}` {
+		t.Errorf("Marshal() = %s", data)
+	}
+
+	if err := json.Unmarshal([]byte(`{"amount": "--1", "currency": "USD"}`), &m); err == nil {
+		t.Error("Unmarshal() with double sign = nil, want error")
+	}
+	if err := json.Unmarshal([]byte(`{"amount": "1", "curency": "USD"}`), &m); err == nil {
+		t.Error("Unmarshal() with unknown field = nil, want error")
+	}
+	if err := json.Unmarshal([]byte(`{"amount": "1", "currency": "USD", "scale": 2}`), &m); err == nil {
+		t.Error("Unmarshal() with extra field = nil, want error")
+	}
+}
@@ -0,0 +1,76 @@
+package model
+
+import (
+	"slices"
+	"time"
+)
+
+// Status pesanan
+const (
+	OrderPending   = "pending"
+	OrderPaid      = "paid"
+	OrderShipped   = "shipped"
+	OrderCompleted = "completed"
+	OrderCancelled = "cancelled"
+)
+
+// orderTransitions adalah status tujuan yang boleh dicapai dari setiap
+// status. completed dan cancelled adalah status akhir.
+var orderTransitions = map[string][]string{
+	OrderPending: {OrderPaid, OrderCancelled},
+	OrderPaid:    {OrderShipped, OrderCancelled},
+	OrderShipped: {OrderCompleted},
+}
+
+// CanTransitionOrder cek apakah pesanan berstatus from boleh diubah
+// menjadi to.
+func CanTransitionOrder(from, to string) bool {
+	return slices.Contains(orderTransitions[from], to)
+}
+
+// Order adalah pesanan penjualan. Total adalah jumlah Total semua baris;
+// semua baris memakai mata uang yang sama.
+type Order struct {
+	ID        int64       `json:"id"`
+	Status    string      `json:"status"`
+	Customer  string      `json:"customer"`
+	Note      string      `json:"note"`
+	Total     Money       `json:"total"`
+	Actor     string      `json:"actor"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+	Lines     []OrderLine `json:"lines"`
+}
+
+// OrderLine adalah satu produk dalam pesanan. ProductName dan UnitPrice
+// disalin dari produk saat pesanan dibuat; ProductID menjadi null jika
+// produknya sudah dihapus.
+type OrderLine struct {
+	ID          int64  `json:"id"`
+	ProductID   *int   `json:"product_id"`
+	ProductName string `json:"product_name"`
+	WarehouseID int    `json:"warehouse_id"`
+	Quantity    int    `json:"quantity"`
+	UnitPrice   Money  `json:"unit_price"`
+	Total       Money  `json:"total"`
+}
+
+// OrderInput adalah body untuk membuat pesanan.
+type OrderInput struct {
+	Customer string           `json:"customer" validate:"max=255"`
+	Note     string           `json:"note" validate:"max=500"`
+	Lines    []OrderLineInput `json:"lines" validate:"required,min=1,max=100"`
+}
+
+// OrderLineInput adalah satu baris pesanan. WarehouseID kosong berarti
+// stok diambil dari gudang default.
+type OrderLineInput struct {
+	ProductID   int `json:"product_id" validate:"required,min=1"`
+	WarehouseID int `json:"warehouse_id,omitempty" validate:"min=0"`
+	Quantity    int `json:"quantity" validate:"required,min=1"`
+}
+
+// OrderStatusInput adalah body untuk mengubah status pesanan.
+type OrderStatusInput struct {
+	Status string `json:"status" validate:"required,oneof=paid shipped completed cancelled"`
+}
@@ -0,0 +1,45 @@
+package model
+
+// Pagination adalah halaman yang diminta client, dimulai dari 1.
+type Pagination struct {
+	Page    int
+	PerPage int
+}
+
+// Offset adalah jumlah baris yang dilewati untuk halaman ini.
+func (p Pagination) Offset() int {
+	return (p.Page - 1) * p.PerPage
+}
+
+// ProductPage adalah satu halaman daftar produk. Total adalah jumlah
+// seluruh produk yang cocok dengan filter, bukan hanya di halaman ini.
+type ProductPage struct {
+	Items   []Product `json:"items"`
+	Page    int       `json:"page"`
+	PerPage int       `json:"per_page"`
+	Total   int       `json:"total"`
+}
+
+// OrderPage adalah satu halaman daftar pesanan.
+type OrderPage struct {
+	Items   []Order `json:"items"`
+	Page    int     `json:"page"`
+	PerPage int     `json:"per_page"`
+	Total   int     `json:"total"`
+}
+
+// PurchaseOrderPage adalah satu halaman daftar purchase order.
+type PurchaseOrderPage struct {
+	Items   []PurchaseOrder `json:"items"`
+	Page    int             `json:"page"`
+	PerPage int             `json:"per_page"`
+	Total   int             `json:"total"`
+}
+
+// StocktakePage adalah satu halaman daftar sesi stocktake.
+type StocktakePage struct {
+	Items   []Stocktake `json:"items"`
+	Page    int         `json:"page"`
+	PerPage int         `json:"per_page"`
+	Total   int         `json:"total"`
+}
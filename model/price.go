@@ -0,0 +1,47 @@
+package model
+
+import "time"
+
+// PriceChange adalah satu entri riwayat harga produk: harga yang berlaku
+// sejak EffectiveAt, dan siapa yang mengubahnya.
+type PriceChange struct {
+	ID          int64     `json:"id"`
+	ProductID   int       `json:"product_id"`
+	Price       Money     `json:"price"`
+	EffectiveAt time.Time `json:"effective_at"`
+	Actor       string    `json:"actor"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Status harga terjadwal
+const (
+	ScheduledPricePending   = "pending"
+	ScheduledPriceApplied   = "applied"
+	ScheduledPriceCancelled = "cancelled"
+)
+
+// ScheduledPrice adalah harga yang akan diterapkan ke produk oleh
+// scheduler ketika EffectiveAt tercapai.
+type ScheduledPrice struct {
+	ID          int64      `json:"id"`
+	ProductID   int        `json:"product_id"`
+	Price       Money      `json:"price"`
+	EffectiveAt time.Time  `json:"effective_at"`
+	Actor       string     `json:"actor"`
+	Status      string     `json:"status"`
+	AppliedAt   *time.Time `json:"applied_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// ScheduledPriceInput adalah body untuk menjadwalkan harga baru.
+type ScheduledPriceInput struct {
+	Price       Money     `json:"price"`
+	EffectiveAt time.Time `json:"effective_at" validate:"required"`
+}
+
+// PriceSchedule adalah riwayat harga produk, terbaru lebih dulu, beserta
+// harga terjadwal yang belum diterapkan.
+type PriceSchedule struct {
+	History   []PriceChange    `json:"history"`
+	Scheduled []ScheduledPrice `json:"scheduled"`
+}
@@ -1,10 +1,63 @@
 package model
 
+import "time"
+
+// Product adalah read model produk. Stock adalah total stok on-hand di
+// semua gudang; Locations berisi rinciannya jika di-expand. Available
+// adalah stok yang masih bisa dijual, yaitu Stock dikurangi reservasi
+// yang masih aktif. CostPrice adalah harga pokok per unit dari penerimaan
+// barang, dalam mata uang yang sama dengan Price.
 type Product struct {
-	ID           int    `json:"id"`
-	Name         string `json:"name"`
-	Price        int    `json:"price"`
-	Stock        int    `json:"stock"`
-	CategoryId   int    `json:"category_id"`
-	CategoryName string `json:"category_name"`
+	ID           int                    `json:"id"`
+	Name         string                 `json:"name"`
+	Slug         string                 `json:"slug"`
+	Price        Money                  `json:"price"`
+	Stock        int                    `json:"stock"`
+	Available    int                    `json:"available"`
+	ReorderPoint int                    `json:"reorder_point"`
+	ReorderQty   int                    `json:"reorder_qty"`
+	CostPrice    Money                  `json:"cost_price"`
+	CategoryId   int                    `json:"category_id"`
+	CategoryName string                 `json:"category_name"`
+	Attributes   map[string]interface{} `json:"attributes"`
+	Tags         []string               `json:"tags"`
+	UpdatedAt    time.Time              `json:"updated_at"`
+	Variants     []Variant              `json:"variants,omitempty"`
+	Category     *Category              `json:"category,omitempty"`
+	Locations    []StockLevel           `json:"locations,omitempty"`
+}
+
+// ProductFields adalah field produk yang boleh dipilih lewat ?fields=.
+var ProductFields = []string{"id", "name", "slug", "price", "stock", "available", "reorder_point", "reorder_qty", "cost_price", "category_id", "category_name", "attributes", "tags", "updated_at"}
+
+// ProductInput adalah field produk yang boleh diisi client saat
+// create/update. Field read-only seperti id dan category_name tidak ada
+// di sini. Stock adalah stok awal di gudang default saat create. Saat
+// update stok tidak diubah; jika diisi, Stock harus sama dengan stok saat
+// ini. Stok diubah lewat penyesuaian stok, transfer, pesanan, dan
+// penerimaan barang supaya setiap perubahan tercatat di ledger.
+// ReorderPoint 0 berarti stok produk tidak dipantau. UpdatedAt opsional
+// saat update: jika diisi dengan updated_at produk yang terakhir dibaca,
+// update ditolak jika produk sudah berubah sejak itu.
+type ProductInput struct {
+	Name         string                 `json:"name" validate:"required,min=3,max=255"`
+	Price        Money                  `json:"price"`
+	Stock        *int                   `json:"stock,omitempty" validate:"min=0"`
+	ReorderPoint int                    `json:"reorder_point" validate:"min=0"`
+	ReorderQty   int                    `json:"reorder_qty" validate:"min=0"`
+	CategoryId   int                    `json:"category_id" validate:"required,min=1"`
+	Attributes   map[string]interface{} `json:"attributes"`
+	UpdatedAt    *time.Time             `json:"updated_at,omitempty"`
+}
+
+func (in ProductInput) Product() *Product {
+	attributes := in.Attributes
+	if attributes == nil {
+		attributes = map[string]interface{}{}
+	}
+	stock := 0
+	if in.Stock != nil {
+		stock = *in.Stock
+	}
+	return &Product{Name: in.Name, Price: in.Price, Stock: stock, ReorderPoint: in.ReorderPoint, ReorderQty: in.ReorderQty, CategoryId: in.CategoryId, Attributes: attributes, Tags: []string{}}
 }
@@ -0,0 +1,106 @@
+package model
+
+import "time"
+
+// Supplier adalah pemasok barang untuk purchase order.
+type Supplier struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Phone     string    `json:"phone"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SupplierInput adalah field supplier yang boleh diisi client.
+type SupplierInput struct {
+	Name  string `json:"name" validate:"required,min=2,max=255"`
+	Email string `json:"email" validate:"max=255"`
+	Phone string `json:"phone" validate:"max=50"`
+}
+
+// Status purchase order, dihitung dari jumlah yang sudah diterima
+const (
+	PurchaseOrderOpen              = "open"
+	PurchaseOrderPartiallyReceived = "partially_received"
+	PurchaseOrderReceived          = "received"
+)
+
+// PurchaseOrder adalah pesanan pembelian ke supplier. Total adalah jumlah
+// harga pokok semua baris; semua baris memakai mata uang yang sama.
+type PurchaseOrder struct {
+	ID           int64               `json:"id"`
+	SupplierID   int                 `json:"supplier_id"`
+	SupplierName string              `json:"supplier_name"`
+	Status       string              `json:"status"`
+	Note         string              `json:"note"`
+	Total        Money               `json:"total"`
+	Actor        string              `json:"actor"`
+	CreatedAt    time.Time           `json:"created_at"`
+	UpdatedAt    time.Time           `json:"updated_at"`
+	Lines        []PurchaseOrderLine `json:"lines"`
+}
+
+// PurchaseOrderLine adalah satu produk dalam purchase order. Received
+// adalah jumlah yang sudah diterima dari semua penerimaan; ProductID
+// menjadi null jika produknya sudah dihapus.
+type PurchaseOrderLine struct {
+	ID          int64  `json:"id"`
+	ProductID   *int   `json:"product_id"`
+	ProductName string `json:"product_name"`
+	Quantity    int    `json:"quantity"`
+	Received    int    `json:"received"`
+	UnitCost    Money  `json:"unit_cost"`
+}
+
+// PurchaseOrderInput adalah body untuk membuat purchase order.
+type PurchaseOrderInput struct {
+	SupplierID int                      `json:"supplier_id" validate:"required,min=1"`
+	Note       string                   `json:"note" validate:"max=500"`
+	Lines      []PurchaseOrderLineInput `json:"lines" validate:"required,min=1,max=100"`
+}
+
+// PurchaseOrderLineInput adalah satu baris purchase order.
+type PurchaseOrderLineInput struct {
+	ProductID int   `json:"product_id" validate:"required,min=1"`
+	Quantity  int   `json:"quantity" validate:"required,min=1"`
+	UnitCost  Money `json:"unit_cost"`
+}
+
+// PurchaseReceipt adalah satu penerimaan barang untuk purchase order ke
+// satu gudang. Harga pokok setiap baris disalin dari baris purchase
+// order-nya.
+type PurchaseReceipt struct {
+	ID              int64                 `json:"id"`
+	PurchaseOrderID int64                 `json:"purchase_order_id"`
+	WarehouseID     int                   `json:"warehouse_id"`
+	Note            string                `json:"note"`
+	Actor           string                `json:"actor"`
+	CreatedAt       time.Time             `json:"created_at"`
+	Lines           []PurchaseReceiptLine `json:"lines"`
+}
+
+// PurchaseReceiptLine adalah jumlah yang diterima untuk satu baris
+// purchase order.
+type PurchaseReceiptLine struct {
+	ID                  int64 `json:"id"`
+	PurchaseOrderLineID int64 `json:"purchase_order_line_id"`
+	ProductID           *int  `json:"product_id"`
+	Quantity            int   `json:"quantity"`
+	UnitCost            Money `json:"unit_cost"`
+}
+
+// PurchaseReceiptInput adalah body untuk menerima barang. WarehouseID
+// kosong berarti gudang default.
+type PurchaseReceiptInput struct {
+	WarehouseID int                        `json:"warehouse_id,omitempty" validate:"min=0"`
+	Note        string                     `json:"note" validate:"max=500"`
+	Lines       []PurchaseReceiptLineInput `json:"lines" validate:"required,min=1,max=100"`
+}
+
+// PurchaseReceiptLineInput adalah jumlah yang diterima untuk satu baris
+// purchase order.
+type PurchaseReceiptLineInput struct {
+	LineID   int64 `json:"line_id" validate:"required,min=1"`
+	Quantity int   `json:"quantity" validate:"required,min=1"`
+}
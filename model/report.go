@@ -0,0 +1,64 @@
+package model
+
+import "time"
+
+// ProductMargin adalah laba kotor satu produk dari pesanan yang tidak
+// dibatalkan. Cost adalah nilai persediaan yang keluar untuk pesanan
+// tersebut sesuai metode costing; MarginPercent relatif terhadap Revenue.
+type ProductMargin struct {
+	ProductID     int     `json:"product_id"`
+	ProductName   string  `json:"product_name"`
+	CategoryID    int     `json:"category_id"`
+	CategoryName  string  `json:"category_name"`
+	QuantitySold  int     `json:"quantity_sold"`
+	Revenue       Money   `json:"revenue"`
+	Cost          Money   `json:"cost"`
+	Margin        Money   `json:"margin"`
+	MarginPercent float64 `json:"margin_percent"`
+}
+
+// CategoryMargin adalah laba kotor semua produk dalam satu kategori, satu
+// baris per mata uang.
+type CategoryMargin struct {
+	CategoryID    int     `json:"category_id"`
+	CategoryName  string  `json:"category_name"`
+	QuantitySold  int     `json:"quantity_sold"`
+	Revenue       Money   `json:"revenue"`
+	Cost          Money   `json:"cost"`
+	Margin        Money   `json:"margin"`
+	MarginPercent float64 `json:"margin_percent"`
+}
+
+// ProductMarginReport adalah laba kotor per produk untuk pesanan yang
+// dibuat dalam rentang [From, To).
+type ProductMarginReport struct {
+	From  time.Time       `json:"from"`
+	To    time.Time       `json:"to"`
+	Items []ProductMargin `json:"items"`
+}
+
+// CategoryMarginReport adalah laba kotor per kategori untuk pesanan yang
+// dibuat dalam rentang [From, To).
+type CategoryMarginReport struct {
+	From  time.Time        `json:"from"`
+	To    time.Time        `json:"to"`
+	Items []CategoryMargin `json:"items"`
+}
+
+// InventoryValue adalah stok on-hand dan nilai persediaan satu produk.
+type InventoryValue struct {
+	ProductID    int    `json:"product_id"`
+	ProductName  string `json:"product_name"`
+	CategoryID   int    `json:"category_id"`
+	CategoryName string `json:"category_name"`
+	Quantity     int    `json:"quantity"`
+	Value        Money  `json:"value"`
+}
+
+// InventoryValuation adalah nilai persediaan semua produk sebelum At.
+// Total berisi satu nilai per mata uang.
+type InventoryValuation struct {
+	At    time.Time        `json:"at"`
+	Items []InventoryValue `json:"items"`
+	Total []Money          `json:"total"`
+}
@@ -0,0 +1,40 @@
+package model
+
+import "time"
+
+// Status reservasi stok
+const (
+	ReservationActive    = "active"
+	ReservationConfirmed = "confirmed"
+	ReservationReleased  = "released"
+	ReservationExpired   = "expired"
+)
+
+// Reservation menahan Quantity stok produk di satu gudang sampai
+// ExpiresAt. Selama active, stok yang ditahan tidak bisa dipakai
+// reservasi, transfer, atau pengurangan stok lain. Confirm mengurangi stok
+// on-hand; release dan expired melepas tahanannya. ProductID menjadi null
+// jika produknya sudah dihapus.
+type Reservation struct {
+	ID          int64     `json:"id"`
+	ProductID   *int      `json:"product_id"`
+	WarehouseID int       `json:"warehouse_id"`
+	Quantity    int       `json:"quantity"`
+	Status      string    `json:"status"`
+	Reference   string    `json:"reference"`
+	Actor       string    `json:"actor"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ReservationInput adalah body untuk membuat reservasi. WarehouseID
+// kosong berarti gudang default; TTLSeconds kosong berarti TTL default
+// server. Reference bebas diisi client, misal ID keranjang.
+type ReservationInput struct {
+	ProductID   int    `json:"product_id" validate:"required,min=1"`
+	WarehouseID int    `json:"warehouse_id,omitempty" validate:"min=0"`
+	Quantity    int    `json:"quantity" validate:"required,min=1"`
+	TTLSeconds  int    `json:"ttl_seconds,omitempty" validate:"min=0,max=86400"`
+	Reference   string `json:"reference" validate:"max=100"`
+}
@@ -0,0 +1,81 @@
+package model
+
+import "time"
+
+// Status sesi stocktake. approved dan cancelled adalah status akhir.
+const (
+	StocktakeOpen      = "open"
+	StocktakeApproved  = "approved"
+	StocktakeCancelled = "cancelled"
+)
+
+// Stocktake adalah sesi penghitungan fisik stok untuk satu gudang, satu
+// kategori di semua gudang, atau kategori di satu gudang. Stok yang
+// diharapkan setiap baris disalin saat sesi dibuat; ClosedBy dan ClosedAt
+// diisi saat sesi disetujui atau dibatalkan.
+type Stocktake struct {
+	ID          int64           `json:"id"`
+	WarehouseID *int            `json:"warehouse_id"`
+	CategoryID  *int            `json:"category_id"`
+	Status      string          `json:"status"`
+	Note        string          `json:"note"`
+	Actor       string          `json:"actor"`
+	ClosedBy    string          `json:"closed_by,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	ClosedAt    *time.Time      `json:"closed_at,omitempty"`
+	Lines       []StocktakeLine `json:"lines"`
+}
+
+// StocktakeLine adalah satu produk di satu gudang dalam sesi stocktake.
+// Counted dan Variance (Counted - Expected) null jika belum dihitung;
+// ProductID menjadi null jika produknya sudah dihapus.
+type StocktakeLine struct {
+	ID          int64      `json:"id"`
+	ProductID   *int       `json:"product_id"`
+	ProductName string     `json:"product_name"`
+	WarehouseID int        `json:"warehouse_id"`
+	Expected    int        `json:"expected"`
+	Counted     *int       `json:"counted"`
+	Variance    *int       `json:"variance"`
+	UnitCost    Money      `json:"unit_cost"`
+	CountedBy   string     `json:"counted_by,omitempty"`
+	CountedAt   *time.Time `json:"counted_at,omitempty"`
+}
+
+// StocktakeInput adalah body untuk memulai sesi stocktake. Minimal salah
+// satu dari WarehouseID dan CategoryID harus diisi.
+type StocktakeInput struct {
+	WarehouseID int    `json:"warehouse_id,omitempty" validate:"min=0"`
+	CategoryID  int    `json:"category_id,omitempty" validate:"min=0"`
+	Note        string `json:"note" validate:"max=500"`
+}
+
+// StocktakeCountInput adalah body untuk mengirim hasil hitungan.
+// Hitungan ulang untuk baris yang sama menimpa hitungan sebelumnya.
+type StocktakeCountInput struct {
+	Counts []StocktakeCount `json:"counts" validate:"required,min=1,max=500"`
+}
+
+// StocktakeCount adalah jumlah fisik satu produk di satu gudang.
+// WarehouseID boleh kosong jika sesinya untuk satu gudang.
+type StocktakeCount struct {
+	ProductID   int  `json:"product_id" validate:"required,min=1"`
+	WarehouseID int  `json:"warehouse_id,omitempty" validate:"min=0"`
+	Counted     *int `json:"counted" validate:"required,min=0"`
+}
+
+// StocktakeVariance adalah ringkasan selisih hitungan sesi stocktake.
+// Over dan Short adalah jumlah unit lebih dan kurang dari yang
+// diharapkan, Value adalah nilai bersih selisih per mata uang, dan Items
+// berisi baris yang sudah dihitung dan selisihnya tidak nol.
+type StocktakeVariance struct {
+	StocktakeID  int64           `json:"stocktake_id"`
+	Status       string          `json:"status"`
+	Lines        int             `json:"lines"`
+	Counted      int             `json:"counted"`
+	WithVariance int             `json:"with_variance"`
+	Over         int             `json:"over"`
+	Short        int             `json:"short"`
+	Value        []Money         `json:"value"`
+	Items        []StocktakeLine `json:"items"`
+}
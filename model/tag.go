@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// Tag adalah label bebas untuk produk, misal "sale" atau "halal". Nama tag
+// selalu dalam bentuk slug. ProductCount adalah jumlah produk yang memakai
+// tag ini, untuk tag cloud.
+type Tag struct {
+	ID           int       `json:"id"`
+	Name         string    `json:"name"`
+	ProductCount int       `json:"product_count"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// TagInput adalah field tag yang boleh diisi client.
+type TagInput struct {
+	Name string `json:"name" validate:"required,min=1,max=50"`
+}
+
+// ProductTagsInput adalah body untuk menambah atau melepas tag dari produk.
+// Tag yang belum ada dibuat otomatis saat ditambahkan.
+type ProductTagsInput struct {
+	Tags []string `json:"tags" validate:"required,min=1,max=50"`
+}
@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// Variant adalah satu varian produk, misal kaos ukuran M warna merah.
+// Price nil berarti varian memakai harga produknya. Stok hanya dicatat
+// per produk.
+type Variant struct {
+	ID        int               `json:"id"`
+	ProductID int               `json:"product_id"`
+	SKU       string            `json:"sku"`
+	Options   map[string]string `json:"options"`
+	Price     *Money            `json:"price"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// VariantInput adalah field varian yang boleh diisi client.
+type VariantInput struct {
+	SKU     string            `json:"sku" validate:"required,min=1,max=64"`
+	Options map[string]string `json:"options" validate:"required,max=10"`
+	Price   *Money            `json:"price"`
+}
+
+func (in VariantInput) Variant(productID int) *Variant {
+	return &Variant{ProductID: productID, SKU: in.SKU, Options: in.Options, Price: in.Price}
+}
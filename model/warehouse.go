@@ -0,0 +1,93 @@
+package model
+
+import "time"
+
+// Warehouse adalah lokasi penyimpanan stok. Penyesuaian stok lewat update
+// produk selalu masuk ke gudang default.
+type Warehouse struct {
+	ID        int       `json:"id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	IsDefault bool      `json:"is_default"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WarehouseInput adalah field gudang yang boleh diisi client.
+type WarehouseInput struct {
+	Code string `json:"code" validate:"required,min=1,max=32"`
+	Name string `json:"name" validate:"required,min=3,max=255"`
+}
+
+// StockLevel adalah stok on-hand satu produk di satu gudang.
+type StockLevel struct {
+	WarehouseID   int    `json:"warehouse_id"`
+	WarehouseCode string `json:"warehouse_code"`
+	Quantity      int    `json:"quantity"`
+}
+
+// Alasan perubahan stok di ledger
+const (
+	LedgerAdjustment  = "adjustment"
+	LedgerTransferOut = "transfer_out"
+	LedgerTransferIn  = "transfer_in"
+	LedgerReservation = "reservation"
+	LedgerOrder       = "order"
+	LedgerOrderCancel = "order_cancel"
+	LedgerPurchase    = "purchase_receipt"
+	LedgerStocktake   = "stocktake"
+)
+
+// LedgerEntry adalah satu perubahan stok produk di satu gudang.
+// ReferenceID menunjuk ke sumber perubahan sesuai Reason, misal ID
+// transfer untuk transfer_out dan transfer_in, ID reservasi untuk
+// reservation, ID pesanan untuk order dan order_cancel, ID penerimaan
+// barang untuk purchase_receipt, atau ID sesi stocktake untuk stocktake.
+// ProductID menjadi null jika produknya sudah dihapus.
+type LedgerEntry struct {
+	ID          int64     `json:"id"`
+	ProductID   *int      `json:"product_id"`
+	WarehouseID int       `json:"warehouse_id"`
+	Delta       int       `json:"delta"`
+	Reason      string    `json:"reason"`
+	ReferenceID *int64    `json:"reference_id,omitempty"`
+	Actor       string    `json:"actor"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// StockTransfer adalah perpindahan stok antar gudang, dicatat sebagai
+// pasangan entri ledger transfer_out dan transfer_in. ProductID menjadi
+// null jika produknya sudah dihapus.
+type StockTransfer struct {
+	ID              int64         `json:"id"`
+	ProductID       *int          `json:"product_id"`
+	FromWarehouseID int           `json:"from_warehouse_id"`
+	ToWarehouseID   int           `json:"to_warehouse_id"`
+	Quantity        int           `json:"quantity"`
+	Note            string        `json:"note"`
+	Actor           string        `json:"actor"`
+	CreatedAt       time.Time     `json:"created_at"`
+	Entries         []LedgerEntry `json:"entries"`
+}
+
+// StockTransferInput adalah body untuk memindahkan stok antar gudang.
+type StockTransferInput struct {
+	ProductID       int    `json:"product_id" validate:"required,min=1"`
+	FromWarehouseID int    `json:"from_warehouse_id" validate:"required,min=1"`
+	ToWarehouseID   int    `json:"to_warehouse_id" validate:"required,min=1"`
+	Quantity        int    `json:"quantity" validate:"required,min=1"`
+	Note            string `json:"note" validate:"max=500"`
+}
+
+// StockAdjustmentInput adalah body untuk menambah atau mengurangi stok
+// produk di satu gudang, misal karena barang rusak atau hilang. Perubahan
+// dicatat di ledger dengan reason adjustment.
+type StockAdjustmentInput struct {
+	WarehouseID int `json:"warehouse_id" validate:"required,min=1"`
+	Delta       int `json:"delta" validate:"required"`
+}
+
+// Metode costing untuk menentukan harga pokok stok keluar
+const (
+	CostingAverage = "average"
+	CostingFIFO    = "fifo"
+)
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"fmt"
+	"go-boot-category-api/framework/repository"
+	"go-boot-category-api/framework/validator"
+	"go-boot-category-api/model"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Attribute interface {
+	GetByCategory(categoryID int) ([]model.AttributeDefinition, error)
+	Create(categoryID int, input model.AttributeDefinitionInput) (*model.AttributeDefinition, error)
+	Delete(categoryID, id int) error
+}
+
+type attributeService struct {
+	repo       repository.Attribute
+	categories repository.Category
+}
+
+func NewAttributeService(repo repository.Attribute, categories repository.Category) Attribute {
+	return &attributeService{repo: repo, categories: categories}
+}
+
+func (s *attributeService) GetByCategory(categoryID int) ([]model.AttributeDefinition, error) {
+	if _, err := s.categories.GetByID(categoryID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByCategory(categoryID)
+}
+
+func (s *attributeService) Create(categoryID int, input model.AttributeDefinitionInput) (*model.AttributeDefinition, error) {
+	if err := validator.Struct(&input); err != nil {
+		return nil, err
+	}
+	if _, err := s.categories.GetByID(categoryID); err != nil {
+		return nil, err
+	}
+
+	def := input.AttributeDefinition(categoryID)
+	for i, value := range def.AllowedValues {
+		if err := checkAttributeType(def.Type, value); err != nil {
+			return nil, fieldError(fmt.Sprintf("allowed_values.%d", i), "type", err.Error())
+		}
+	}
+
+	if err := s.repo.Create(def); err != nil {
+		return nil, conflictIfDuplicate(err)
+	}
+	return def, nil
+}
+
+func (s *attributeService) Delete(categoryID, id int) error {
+	return s.repo.Delete(categoryID, id)
+}
+
+// validateAttributes mengecek atribut produk terhadap definisi atribut
+// kategorinya: atribut wajib harus ada, tipe harus sesuai, nilai harus
+// termasuk allowed values, dan atribut yang tidak didefinisikan ditolak.
+// Kategori tanpa definisi atribut menerima atribut bebas.
+func validateAttributes(defs []model.AttributeDefinition, attributes map[string]interface{}) error {
+	if len(defs) == 0 {
+		return nil
+	}
+
+	var errs validator.Errors
+	defined := make(map[string]bool, len(defs))
+	for _, def := range defs {
+		defined[def.Name] = true
+		field := "attributes." + def.Name
+
+		value, ok := attributes[def.Name]
+		if !ok || value == nil {
+			if def.Required {
+				errs = append(errs, validator.FieldError{Field: field, Rule: "required", Message: field + " is required"})
+			}
+			continue
+		}
+
+		str, err := attributeString(def.Type, value)
+		if err == nil {
+			err = checkAttributeType(def.Type, str)
+		}
+		if err != nil {
+			errs = append(errs, validator.FieldError{Field: field, Rule: "type", Message: fmt.Sprintf("%s %s", field, err)})
+			continue
+		}
+		if len(def.AllowedValues) > 0 && !slices.Contains(def.AllowedValues, str) {
+			errs = append(errs, validator.FieldError{Field: field, Rule: "oneof", Message: fmt.Sprintf("%s must be one of: %v", field, def.AllowedValues)})
+		}
+	}
+
+	for name := range attributes {
+		if !defined[name] {
+			field := "attributes." + name
+			errs = append(errs, validator.FieldError{Field: field, Rule: "defined", Message: field + " is not defined for this category"})
+		}
+	}
+
+	if len(errs) > 0 {
+		slices.SortFunc(errs, func(a, b validator.FieldError) int {
+			return strings.Compare(a.Field, b.Field)
+		})
+		return errs
+	}
+	return nil
+}
+
+// attributeString mengubah nilai JSON atribut menjadi string untuk
+// dibandingkan dengan allowed values, sekaligus cek tipe JSON-nya.
+func attributeString(typ string, value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		if typ == "number" || typ == "boolean" {
+			return "", fmt.Errorf("must be a %s", typ)
+		}
+		return v, nil
+	case float64:
+		if typ != "number" {
+			return "", fmt.Errorf("must be a %s", typ)
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		if typ != "boolean" {
+			return "", fmt.Errorf("must be a %s", typ)
+		}
+		return strconv.FormatBool(v), nil
+	}
+	return "", fmt.Errorf("must be a %s", typ)
+}
+
+func checkAttributeType(typ, value string) error {
+	switch typ {
+	case "number":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("must be a number")
+		}
+	case "boolean":
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("must be a boolean")
+		}
+	case "date":
+		if _, err := time.Parse(time.DateOnly, value); err != nil {
+			return fmt.Errorf("must be a date in YYYY-MM-DD format")
+		}
+	}
+	return nil
+}
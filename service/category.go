@@ -1,42 +1,171 @@
 package service
 
 import (
+	"fmt"
 	"go-boot-category-api/framework/repository"
+	"go-boot-category-api/framework/validator"
 	"go-boot-category-api/model"
+	"slices"
 )
 
 type Category interface {
 	GetAll() ([]model.Category, error)
+	GetAllWithProducts() ([]model.Category, error)
 	GetByID(id int) (*model.Category, error)
-	Create(category *model.Category) error
-	Update(category *model.Category) error
+	GetByIDWithProducts(id int) (*model.Category, error)
+	Create(input model.CategoryInput) (*model.Category, error)
+	Update(id int, input model.CategoryInput) (*model.Category, error)
 	Delete(id int) error
+	GetBySlug(slug string) (*model.Category, error)
 }
 
 type categoryService struct {
-	repo repository.Category
+	repo     repository.Category
+	products repository.Product
 }
 
-func NewCategoryService(repo repository.Category) Category {
-	return &categoryService{repo: repo}
+func NewCategoryService(repo repository.Category, products repository.Product) Category {
+	return &categoryService{repo: repo, products: products}
 }
 
 func (s *categoryService) GetAll() ([]model.Category, error) {
 	return s.repo.GetAll()
 }
 
-func (s *categoryService) Create(data *model.Category) error {
-	return s.repo.Create(data)
+// GetAllWithProducts mengambil semua kategori beserta produknya. Produk
+// semua kategori diambil dengan satu query lalu dikelompokkan.
+func (s *categoryService) GetAllWithProducts() ([]model.Category, error) {
+	categories, err := s.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	// hasil repository bisa berbagi slice dengan pemanggil lain lewat cache
+	categories = slices.Clone(categories)
+	if err := s.attachProducts(categories); err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+func (s *categoryService) Create(input model.CategoryInput) (*model.Category, error) {
+	err := s.validate(0, input)
+	if err != nil {
+		return nil, err
+	}
+
+	category := input.Category()
+	category.Slug, err = uniqueSlug(input.Name, "category", 0, s.repo.SlugExists)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(category); err != nil {
+		return nil, conflictIfDuplicate(err)
+	}
+	return category, nil
 }
 
 func (s *categoryService) GetByID(id int) (*model.Category, error) {
 	return s.repo.GetByID(id)
 }
 
-func (s *categoryService) Update(Category *model.Category) error {
-	return s.repo.Update(Category)
+func (s *categoryService) GetByIDWithProducts(id int) (*model.Category, error) {
+	category, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	categories := []model.Category{*category}
+	if err := s.attachProducts(categories); err != nil {
+		return nil, err
+	}
+	return &categories[0], nil
+}
+
+// attachProducts mengisi Products setiap kategori dengan satu query produk
+// untuk semua kategori sekaligus.
+func (s *categoryService) attachProducts(categories []model.Category) error {
+	if len(categories) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(categories))
+	index := make(map[int]int, len(categories))
+	for i := range categories {
+		ids[i] = categories[i].ID
+		index[categories[i].ID] = i
+		categories[i].Products = []model.Product{}
+	}
+
+	products, err := s.products.GetAll(model.ProductFilter{CategoryIDs: ids})
+	if err != nil {
+		return err
+	}
+	for _, product := range products {
+		i := index[product.CategoryId]
+		categories[i].Products = append(categories[i].Products, product)
+	}
+	return nil
+}
+
+func (s *categoryService) Update(id int, input model.CategoryInput) (*model.Category, error) {
+	if err := s.validate(id, input); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// slug hanya dibuat ulang jika nama berubah; slug lama masuk history
+	category := input.Category()
+	category.ID = id
+	category.Slug = existing.Slug
+	category.ProductCount = existing.ProductCount
+	category.TotalStock = existing.TotalStock
+	category.StockValue = existing.StockValue
+	if input.Name != existing.Name {
+		category.Slug, err = uniqueSlug(input.Name, "category", id, s.repo.SlugExists)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := s.repo.Update(category); err != nil {
+		return nil, conflictIfDuplicate(err)
+	}
+	return category, nil
+}
+
+// GetBySlug mencari kategori by slug aktif atau slug lama. Slug kategori
+// yang dikembalikan bisa berbeda dari slug yang diminta.
+func (s *categoryService) GetBySlug(slug string) (*model.Category, error) {
+	return s.repo.GetBySlug(slug)
 }
 
+// Delete menolak menghapus kategori yang masih dipakai produk.
 func (s *categoryService) Delete(id int) error {
+	count, err := s.products.CountByCategory(id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return conflict(fmt.Sprintf("kategori masih dipakai oleh %d produk", count))
+	}
 	return s.repo.Delete(id)
 }
+
+// validate mengecek aturan field dan keunikan nama kategori.
+// excludeID adalah ID kategori yang sedang di-update (0 untuk create).
+func (s *categoryService) validate(excludeID int, input model.CategoryInput) error {
+	if err := validator.Struct(&input); err != nil {
+		return err
+	}
+
+	exists, err := s.repo.ExistsByName(input.Name, excludeID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return conflict(fmt.Sprintf("kategori dengan nama %q sudah ada", input.Name))
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"go-boot-category-api/framework/repository"
+	"go-boot-category-api/framework/validator"
+	"strings"
+)
+
+var (
+	// ErrNotFound dikembalikan jika data yang diminta tidak ada.
+	ErrNotFound = repository.ErrNotFound
+	// ErrConflict dikembalikan jika operasi melanggar keunikan atau
+	// invariant data lain, misal nama kategori sudah dipakai.
+	ErrConflict = errors.New("conflict")
+	// ErrInvalidFilter dikembalikan jika filter pencarian tidak valid.
+	ErrInvalidFilter = repository.ErrInvalidFilter
+)
+
+// businessError membawa pesan untuk user sekaligus jenis error-nya, supaya
+// transport (HTTP, CLI, gRPC) bisa memetakan ke kode status sendiri lewat
+// errors.Is.
+type businessError struct {
+	kind    error
+	message string
+}
+
+func (e *businessError) Error() string {
+	return e.message
+}
+
+func (e *businessError) Unwrap() error {
+	return e.kind
+}
+
+func conflict(message string) error {
+	return &businessError{kind: ErrConflict, message: message}
+}
+
+// fieldError membuat error validasi untuk satu field, dengan tipe yang
+// sama seperti hasil validator.Struct.
+func fieldError(field, rule, message string) error {
+	return validator.Errors{{Field: field, Rule: rule, Message: message}}
+}
+
+// itemPrefix adalah prefix nama field item ke-i dari list, sama seperti
+// yang dipakai validator.Struct, misal "lines[0].".
+func itemPrefix(field string, i int) string {
+	return fmt.Sprintf("%s[%d].", field, i)
+}
+
+// hasItemErrors melaporkan apakah errs berisi error untuk item dengan
+// prefix tersebut, supaya pemeriksaan ke database dilewati untuk item
+// yang sudah tidak valid.
+func hasItemErrors(errs validator.Errors, prefix string) bool {
+	for _, fe := range errs {
+		if strings.HasPrefix(fe.Field, prefix) {
+			return true
+		}
+	}
+	return false
+}
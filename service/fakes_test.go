@@ -0,0 +1,47 @@
+package service
+
+import (
+	"fmt"
+	"go-boot-category-api/framework/repository"
+	"go-boot-category-api/model"
+)
+
+// fakeProducts adalah repository.Product yang hanya mendukung GetByID;
+// method lain panic karena interface yang di-embed nil.
+type fakeProducts struct {
+	repository.Product
+	products map[int]*model.Product
+}
+
+func (f *fakeProducts) GetByID(id int) (*model.Product, error) {
+	if p, ok := f.products[id]; ok {
+		return p, nil
+	}
+	return nil, fmt.Errorf("produk %w", repository.ErrNotFound)
+}
+
+// fakeWarehouses adalah repository.Warehouse dengan daftar gudang tetap
+type fakeWarehouses struct {
+	repository.Warehouse
+	warehouses map[int]*model.Warehouse
+}
+
+func (f *fakeWarehouses) GetByID(id int) (*model.Warehouse, error) {
+	if w, ok := f.warehouses[id]; ok {
+		return w, nil
+	}
+	return nil, fmt.Errorf("gudang %w", repository.ErrNotFound)
+}
+
+// fakeSuppliers adalah repository.Supplier dengan daftar supplier tetap
+type fakeSuppliers struct {
+	repository.Supplier
+	suppliers map[int]*model.Supplier
+}
+
+func (f *fakeSuppliers) GetByID(id int) (*model.Supplier, error) {
+	if s, ok := f.suppliers[id]; ok {
+		return s, nil
+	}
+	return nil, fmt.Errorf("supplier %w", repository.ErrNotFound)
+}
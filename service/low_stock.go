@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"go-boot-category-api/framework/notify"
+	"go-boot-category-api/framework/repository"
+	"go-boot-category-api/model"
+	"log"
+	"time"
+)
+
+// EventLowStock adalah Message.Event untuk notifikasi stok rendah
+const EventLowStock = "product.low_stock"
+
+type LowStock interface {
+	GetAll() ([]model.LowStock, error)
+	Check(ctx context.Context) (int, error)
+	RunMonitor(ctx context.Context, interval time.Duration)
+}
+
+type lowStockService struct {
+	repo     repository.LowStock
+	notifier notify.Notifier
+}
+
+func NewLowStockService(repo repository.LowStock, notifier notify.Notifier) LowStock {
+	return &lowStockService{repo: repo, notifier: notifier}
+}
+
+// GetAll mengambil laporan produk yang stoknya rendah.
+func (s *lowStockService) GetAll() ([]model.LowStock, error) {
+	return s.repo.GetAll()
+}
+
+const (
+	// lowStockBatch adalah jumlah event yang diambil sekali jalan
+	lowStockBatch = 100
+	// lowStockRetry adalah jeda sebelum event yang gagal dikirim, atau
+	// yang instance pengirimnya mati, diambil lagi
+	lowStockRetry = 5 * time.Minute
+)
+
+// Check mengirim notifikasi untuk setiap event stok rendah yang dicatat
+// saat stok produk turun melewati reorder point, lewat jalur apa pun
+// stoknya berubah, dan mengembalikan jumlah notifikasi yang terkirim.
+// Event yang notifikasinya gagal dicoba lagi setelah lowStockRetry.
+func (s *lowStockService) Check(ctx context.Context) (int, error) {
+	sent := 0
+	for {
+		events, err := s.repo.Claim(lowStockBatch, lowStockRetry)
+		if err != nil {
+			return sent, err
+		}
+
+		for _, event := range events {
+			if err := s.notifier.Notify(ctx, lowStockMessage(event.LowStock)); err != nil {
+				log.Printf("low stock monitor: notify product %d: %v", event.ProductID, err)
+				continue
+			}
+			// event yang gagal di-Done terkirim lagi setelah lowStockRetry
+			if err := s.repo.Done(event.ID); err != nil {
+				log.Printf("low stock monitor: done event %d: %v", event.ID, err)
+			}
+			sent++
+		}
+
+		if len(events) < lowStockBatch || ctx.Err() != nil {
+			return sent, nil
+		}
+	}
+}
+
+// RunMonitor menjalankan Check setiap interval sampai ctx selesai.
+func (s *lowStockService) RunMonitor(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		count, err := s.Check(ctx)
+		if err != nil {
+			log.Printf("low stock monitor: %v", err)
+		} else if count > 0 {
+			log.Printf("low stock monitor: sent %d notifications", count)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func lowStockMessage(item model.LowStock) notify.Message {
+	return notify.Message{
+		Event:   EventLowStock,
+		Subject: fmt.Sprintf("Low stock: %s", item.ProductName),
+		Text: fmt.Sprintf("%s (product %d) has %d units on hand, %d available to sell, at or below its reorder point of %d.\nSuggested reorder quantity: %d.",
+			item.ProductName, item.ProductID, item.Stock, item.Available, item.ReorderPoint, item.ReorderQty),
+		Data: item,
+	}
+}
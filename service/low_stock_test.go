@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"go-boot-category-api/framework/notify"
+	"go-boot-category-api/model"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// fakeLowStockRepo mengembalikan events per batch sesuai limit Claim
+type fakeLowStockRepo struct {
+	events  []model.LowStockEvent
+	claims  int
+	doneErr error
+	done    []int64
+}
+
+func (f *fakeLowStockRepo) GetAll() ([]model.LowStock, error) { return nil, nil }
+
+func (f *fakeLowStockRepo) Claim(limit int, lease time.Duration) ([]model.LowStockEvent, error) {
+	f.claims++
+	n := min(limit, len(f.events))
+	claimed := f.events[:n]
+	f.events = f.events[n:]
+	return claimed, nil
+}
+
+func (f *fakeLowStockRepo) Done(id int64) error {
+	f.done = append(f.done, id)
+	return f.doneErr
+}
+
+// fakeNotifier gagal untuk produk di failing
+type fakeNotifier struct {
+	failing map[int]bool
+	sent    []int
+}
+
+func (f *fakeNotifier) Notify(ctx context.Context, msg notify.Message) error {
+	item := msg.Data.(model.LowStock)
+	if f.failing[item.ProductID] {
+		return errors.New("webhook down")
+	}
+	f.sent = append(f.sent, item.ProductID)
+	return nil
+}
+
+func lowStockEvents(n int) []model.LowStockEvent {
+	events := make([]model.LowStockEvent, n)
+	for i := range events {
+		events[i] = model.LowStockEvent{ID: int64(i + 1), LowStock: model.LowStock{ProductID: i + 1}}
+	}
+	return events
+}
+
+func TestLowStockCheck(t *testing.T) {
+	tests := []struct {
+		name       string
+		events     int
+		failing    map[int]bool
+		doneErr    error
+		wantSent   int
+		wantDone   []int64
+		wantClaims int
+	}{
+		{name: "all sent", events: 3, wantSent: 3, wantDone: []int64{1, 2, 3}, wantClaims: 1},
+		{name: "failed notification stays queued", events: 3, failing: map[int]bool{2: true}, wantSent: 2, wantDone: []int64{1, 3}, wantClaims: 1},
+		{name: "done error does not stop the loop", events: 2, doneErr: errors.New("db down"), wantSent: 2, wantDone: []int64{1, 2}, wantClaims: 1},
+		{name: "full batch claims again", events: lowStockBatch + 1, wantSent: lowStockBatch + 1, wantClaims: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeLowStockRepo{events: lowStockEvents(tt.events), doneErr: tt.doneErr}
+			notifier := &fakeNotifier{failing: tt.failing}
+
+			sent, err := NewLowStockService(repo, notifier).Check(context.Background())
+			if err != nil {
+				t.Fatalf("Check() error = %v", err)
+			}
+			if sent != tt.wantSent || len(notifier.sent) != tt.wantSent {
+				t.Errorf("Check() sent = %d (notifier %d), want %d", sent, len(notifier.sent), tt.wantSent)
+			}
+			if repo.claims != tt.wantClaims {
+				t.Errorf("Claim() called %d times, want %d", repo.claims, tt.wantClaims)
+			}
+			if tt.wantDone != nil && !reflect.DeepEqual(repo.done, tt.wantDone) {
+				t.Errorf("done = %v, want %v", repo.done, tt.wantDone)
+			}
+		})
+	}
+}
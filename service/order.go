@@ -0,0 +1,111 @@
+package service
+
+import (
+	"errors"
+	"go-boot-category-api/framework/repository"
+	"go-boot-category-api/framework/validator"
+	"go-boot-category-api/model"
+)
+
+type Order interface {
+	GetAll(status string, page model.Pagination) (*model.OrderPage, error)
+	GetByID(id int64) (*model.Order, error)
+	Create(input model.OrderInput, actor string) (*model.Order, error)
+	Transition(id int64, input model.OrderStatusInput, actor string) (*model.Order, error)
+}
+
+type orderService struct {
+	repo       repository.Order
+	products   repository.Product
+	warehouses repository.Warehouse
+}
+
+func NewOrderService(repo repository.Order, products repository.Product, warehouses repository.Warehouse) Order {
+	return &orderService{repo: repo, products: products, warehouses: warehouses}
+}
+
+func (s *orderService) GetAll(status string, page model.Pagination) (*model.OrderPage, error) {
+	return s.repo.GetAll(status, page)
+}
+
+func (s *orderService) GetByID(id int64) (*model.Order, error) {
+	return s.repo.GetByID(id)
+}
+
+// Create membuat pesanan dan mengurangi stok semua barisnya sekaligus.
+// Jika stok salah satu baris kurang, pesanan ditolak tanpa mengubah stok.
+func (s *orderService) Create(input model.OrderInput, actor string) (*model.Order, error) {
+	if err := s.validate(input); err != nil {
+		return nil, err
+	}
+
+	order := &model.Order{Customer: input.Customer, Note: input.Note, Actor: actor}
+	for _, line := range input.Lines {
+		productID := line.ProductID
+		order.Lines = append(order.Lines, model.OrderLine{ProductID: &productID, WarehouseID: line.WarehouseID, Quantity: line.Quantity})
+	}
+
+	err := s.repo.Create(order)
+	if errors.Is(err, repository.ErrInsufficientStock) {
+		return nil, &businessError{kind: ErrInsufficientStock, message: "pesanan ditolak, stok tersedia tidak mencukupi: " + err.Error()}
+	}
+	if errors.Is(err, repository.ErrMixedCurrency) {
+		return nil, fieldError("lines", "currency", "all products in an order must use the same currency")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// validate memvalidasi input beserta setiap barisnya, dan memastikan
+// produk dan gudang yang dirujuk ada.
+func (s *orderService) validate(input model.OrderInput) error {
+	var errs validator.Errors
+	if err := validator.Struct(&input); err != nil {
+		if !errors.As(err, &errs) {
+			return err
+		}
+	}
+
+	for i, line := range input.Lines {
+		prefix := itemPrefix("lines", i)
+		if hasItemErrors(errs, prefix) {
+			continue
+		}
+
+		if _, err := s.products.GetByID(line.ProductID); errors.Is(err, ErrNotFound) {
+			errs = append(errs, validator.FieldError{Field: prefix + "product_id", Rule: "exists", Message: prefix + "product_id refers to a product that does not exist"})
+		} else if err != nil {
+			return err
+		}
+		if line.WarehouseID != 0 {
+			if _, err := s.warehouses.GetByID(line.WarehouseID); errors.Is(err, ErrNotFound) {
+				errs = append(errs, validator.FieldError{Field: prefix + "warehouse_id", Rule: "exists", Message: prefix + "warehouse_id refers to a warehouse that does not exist"})
+			} else if err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Transition mengubah status pesanan. Pembatalan mengembalikan stoknya.
+func (s *orderService) Transition(id int64, input model.OrderStatusInput, actor string) (*model.Order, error) {
+	if err := validator.Struct(&input); err != nil {
+		return nil, err
+	}
+
+	order, err := s.repo.Transition(id, input.Status, actor)
+	if errors.Is(err, repository.ErrInvalidTransition) {
+		return nil, conflict(err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"errors"
+	"go-boot-category-api/framework/repository"
+	"go-boot-category-api/framework/validator"
+	"go-boot-category-api/model"
+	"testing"
+)
+
+type fakeOrderRepo struct {
+	repository.Order
+	createErr     error
+	transitionErr error
+	created       *model.Order
+}
+
+func (f *fakeOrderRepo) Create(order *model.Order) error {
+	f.created = order
+	return f.createErr
+}
+
+func (f *fakeOrderRepo) Transition(id int64, status string, actor string) (*model.Order, error) {
+	if f.transitionErr != nil {
+		return nil, f.transitionErr
+	}
+	return &model.Order{ID: id, Status: status}, nil
+}
+
+func newTestOrderService(repo *fakeOrderRepo) Order {
+	products := &fakeProducts{products: map[int]*model.Product{1: {ID: 1}, 2: {ID: 2}}}
+	warehouses := &fakeWarehouses{warehouses: map[int]*model.Warehouse{1: {ID: 1}}}
+	return NewOrderService(repo, products, warehouses)
+}
+
+func TestOrderCreate(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     model.OrderInput
+		createErr error
+		wantErr   error
+		wantField string
+	}{
+		{name: "valid", input: model.OrderInput{Lines: []model.OrderLineInput{{ProductID: 1, Quantity: 2}, {ProductID: 2, WarehouseID: 1, Quantity: 1}}}},
+		{name: "no lines", input: model.OrderInput{}, wantField: "lines"},
+		{name: "unknown product", input: model.OrderInput{Lines: []model.OrderLineInput{{ProductID: 1, Quantity: 1}, {ProductID: 9, Quantity: 1}}}, wantField: "lines[1].product_id"},
+		{name: "unknown warehouse", input: model.OrderInput{Lines: []model.OrderLineInput{{ProductID: 1, WarehouseID: 9, Quantity: 1}}}, wantField: "lines[0].warehouse_id"},
+		{name: "zero quantity", input: model.OrderInput{Lines: []model.OrderLineInput{{ProductID: 1}}}, wantField: "lines[0].quantity"},
+		{
+			name:      "insufficient stock",
+			input:     model.OrderInput{Lines: []model.OrderLineInput{{ProductID: 1, Quantity: 2}}},
+			createErr: repository.ErrInsufficientStock,
+			wantErr:   ErrInsufficientStock,
+		},
+		{
+			name:      "mixed currency",
+			input:     model.OrderInput{Lines: []model.OrderLineInput{{ProductID: 1, Quantity: 1}, {ProductID: 2, Quantity: 1}}},
+			createErr: repository.ErrMixedCurrency,
+			wantField: "lines",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeOrderRepo{createErr: tt.createErr}
+			order, err := newTestOrderService(repo).Create(tt.input, "test")
+
+			switch {
+			case tt.wantField != "":
+				var errs validator.Errors
+				if !errors.As(err, &errs) || errs[0].Field != tt.wantField {
+					t.Fatalf("Create() error = %v, want validation error on %s", err, tt.wantField)
+				}
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Create() error = %v, want %v", err, tt.wantErr)
+				}
+			case err != nil:
+				t.Fatalf("Create() error = %v", err)
+			case len(order.Lines) != len(tt.input.Lines) || order.Actor != "test":
+				t.Errorf("Create() = %+v, want %d lines by test", order, len(tt.input.Lines))
+			}
+		})
+	}
+}
+
+func TestOrderTransition(t *testing.T) {
+	repo := &fakeOrderRepo{transitionErr: repository.ErrInvalidTransition}
+	s := newTestOrderService(repo)
+
+	if _, err := s.Transition(1, model.OrderStatusInput{Status: model.OrderCancelled}, "test"); !errors.Is(err, ErrConflict) {
+		t.Errorf("Transition() invalid error = %v, want ErrConflict", err)
+	}
+
+	var errs validator.Errors
+	if _, err := s.Transition(1, model.OrderStatusInput{Status: model.OrderPending}, "test"); !errors.As(err, &errs) {
+		t.Errorf("Transition() to pending error = %v, want validation error", err)
+	}
+
+	repo.transitionErr = nil
+	order, err := s.Transition(1, model.OrderStatusInput{Status: model.OrderPaid}, "test")
+	if err != nil || order.Status != model.OrderPaid {
+		t.Errorf("Transition() = %v, %v, want paid", order, err)
+	}
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"go-boot-category-api/framework/repository"
+	"go-boot-category-api/framework/validator"
+	"go-boot-category-api/model"
+	"log"
+	"time"
+)
+
+type Price interface {
+	GetSchedule(productID int) (*model.PriceSchedule, error)
+	At(productID int, at time.Time) (*model.PriceChange, error)
+	Schedule(productID int, input model.ScheduledPriceInput, actor string) (*model.ScheduledPrice, error)
+	Cancel(productID int, id int64) error
+	ApplyDue() (int, error)
+	RunScheduler(ctx context.Context, interval time.Duration)
+}
+
+type priceService struct {
+	repo     repository.Price
+	products repository.Product
+	now      func() time.Time
+}
+
+func NewPriceService(repo repository.Price, products repository.Product) Price {
+	return &priceService{repo: repo, products: products, now: time.Now}
+}
+
+// GetSchedule mengambil riwayat harga dan harga terjadwal sebuah produk.
+func (s *priceService) GetSchedule(productID int) (*model.PriceSchedule, error) {
+	if _, err := s.products.GetByID(productID); err != nil {
+		return nil, err
+	}
+
+	history, err := s.repo.History(productID)
+	if err != nil {
+		return nil, err
+	}
+	scheduled, err := s.repo.Scheduled(productID)
+	if err != nil {
+		return nil, err
+	}
+	return &model.PriceSchedule{History: history, Scheduled: scheduled}, nil
+}
+
+// At mengambil harga yang berlaku untuk produk pada waktu at.
+func (s *priceService) At(productID int, at time.Time) (*model.PriceChange, error) {
+	if _, err := s.products.GetByID(productID); err != nil {
+		return nil, err
+	}
+	return s.repo.At(productID, at)
+}
+
+// Schedule menjadwalkan harga baru yang berlaku mulai input.EffectiveAt.
+// Perubahan harga yang berlaku segera dilakukan lewat update produk.
+func (s *priceService) Schedule(productID int, input model.ScheduledPriceInput, actor string) (*model.ScheduledPrice, error) {
+	if err := validator.Struct(&input); err != nil {
+		return nil, err
+	}
+	if !input.EffectiveAt.After(s.now()) {
+		return nil, fieldError("effective_at", "future", "effective_at must be in the future; update the product to change its price now")
+	}
+	if _, err := s.products.GetByID(productID); err != nil {
+		return nil, err
+	}
+
+	price := &model.ScheduledPrice{ProductID: productID, Price: input.Price, EffectiveAt: input.EffectiveAt, Actor: actor}
+	if err := s.repo.Schedule(price); err != nil {
+		return nil, err
+	}
+	return price, nil
+}
+
+// Cancel membatalkan harga terjadwal yang belum diterapkan.
+func (s *priceService) Cancel(productID int, id int64) error {
+	price, err := s.repo.GetScheduled(productID, id)
+	if err != nil {
+		return err
+	}
+	if price.Status != model.ScheduledPricePending {
+		return conflict("harga terjadwal sudah " + price.Status)
+	}
+	return s.repo.Cancel(productID, id)
+}
+
+// ApplyDue menerapkan harga terjadwal yang sudah jatuh tempo dan
+// mengembalikan jumlah produk yang harganya berubah.
+func (s *priceService) ApplyDue() (int, error) {
+	ids, err := s.products.ApplyScheduledPrices(s.now())
+	return len(ids), err
+}
+
+// RunScheduler menjalankan ApplyDue setiap interval sampai ctx selesai.
+func (s *priceService) RunScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		count, err := s.ApplyDue()
+		if err != nil {
+			log.Printf("price scheduler: %v", err)
+		} else if count > 0 {
+			log.Printf("price scheduler: applied scheduled prices to %d products", count)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -1,42 +1,216 @@
 package service
 
 import (
+	"errors"
 	"go-boot-category-api/framework/repository"
+	"go-boot-category-api/framework/validator"
 	"go-boot-category-api/model"
 )
 
 type Product interface {
-	GetAll() ([]model.Product, error)
+	GetAll(filter model.ProductFilter) ([]model.Product, error)
 	GetByID(id int) (*model.Product, error)
-	Create(product *model.Product) error
-	Update(product *model.Product) error
+	GetByCategory(categoryID int, filter model.ProductFilter, page model.Pagination) (*model.ProductPage, error)
+	GetByIDWithVariants(id int) (*model.Product, error)
+	ExpandCategory(products []model.Product) error
+	ExpandLocations(products []model.Product) error
+	Create(input model.ProductInput, actor string) (*model.Product, error)
+	Update(id int, input model.ProductInput, actor string) (*model.Product, error)
 	Delete(id int) error
+	GetBySlug(slug string) (*model.Product, error)
 }
 
 type productService struct {
-	repo repository.Product
+	repo       repository.Product
+	categories repository.Category
+	attributes repository.Attribute
+	stock      repository.Stock
 }
 
-func NewProductService(repo repository.Product) Product {
-	return &productService{repo: repo}
+func NewProductService(repo repository.Product, categories repository.Category, attributes repository.Attribute, stock repository.Stock) Product {
+	return &productService{repo: repo, categories: categories, attributes: attributes, stock: stock}
 }
 
-func (s *productService) GetAll() ([]model.Product, error) {
-	return s.repo.GetAll()
+func (s *productService) GetAll(filter model.ProductFilter) ([]model.Product, error) {
+	return s.repo.GetAll(filter)
 }
 
-func (s *productService) Create(data *model.Product) error {
-	return s.repo.Create(data)
+// Create menyimpan produk baru. actor dicatat sebagai pelaku di riwayat
+// harga.
+func (s *productService) Create(input model.ProductInput, actor string) (*model.Product, error) {
+	category, err := s.validate(input)
+	if err != nil {
+		return nil, err
+	}
+
+	product := input.Product()
+	product.Slug, err = uniqueSlug(input.Name, "product", 0, s.repo.SlugExists)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(product, actor); err != nil {
+		return nil, conflictIfDuplicate(err)
+	}
+	product.CategoryName = category.Name
+	return product, nil
 }
 
 func (s *productService) GetByID(id int) (*model.Product, error) {
 	return s.repo.GetByID(id)
 }
 
-func (s *productService) Update(product *model.Product) error {
-	return s.repo.Update(product)
+// GetByCategory mengambil satu halaman produk dalam kategori categoryID,
+// dengan filter tambahan seperti di GetAll.
+func (s *productService) GetByCategory(categoryID int, filter model.ProductFilter, page model.Pagination) (*model.ProductPage, error) {
+	if _, err := s.categories.GetByID(categoryID); err != nil {
+		return nil, err
+	}
+	filter.CategoryIDs = []int{categoryID}
+	return s.repo.GetPage(filter, page)
+}
+
+func (s *productService) GetByIDWithVariants(id int) (*model.Product, error) {
+	return s.repo.GetByIDWithVariants(id)
+}
+
+// ExpandCategory mengisi Category setiap produk dari daftar kategori, yang
+// diambil sekali untuk semua produk.
+func (s *productService) ExpandCategory(products []model.Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+
+	categories, err := s.categories.GetAll()
+	if err != nil {
+		return err
+	}
+	byID := make(map[int]*model.Category, len(categories))
+	for i := range categories {
+		byID[categories[i].ID] = &categories[i]
+	}
+	for i := range products {
+		products[i].Category = byID[products[i].CategoryId]
+	}
+	return nil
 }
 
+// ExpandLocations mengisi Locations setiap produk dengan stok per gudang,
+// diambil dengan satu query untuk semua produk.
+func (s *productService) ExpandLocations(products []model.Product) error {
+	ids := make([]int, len(products))
+	for i := range products {
+		ids[i] = products[i].ID
+	}
+
+	levels, err := s.stock.Levels(ids)
+	if err != nil {
+		return err
+	}
+	for i := range products {
+		products[i].Locations = levels[products[i].ID]
+	}
+	return nil
+}
+
+// Update menyimpan perubahan produk. Jika harga berubah, actor dicatat
+// sebagai pelaku di riwayat harga. Stok tidak diubah; stock yang dikirim
+// harus sama dengan stok saat ini. Update ditolak jika produk berubah
+// setelah input.UpdatedAt, atau setelah produk dibaca di sini jika
+// input.UpdatedAt kosong.
+func (s *productService) Update(id int, input model.ProductInput, actor string) (*model.Product, error) {
+	category, err := s.validate(input)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := s.update(id, input, actor)
+	if errors.Is(err, repository.ErrModified) && input.UpdatedAt == nil {
+		// produk yang dibaca berasal dari cache yang basi; cache-nya sudah
+		// dihapus oleh repository, jadi baca ulang sekali
+		product, err = s.update(id, input, actor)
+	}
+	if errors.Is(err, repository.ErrModified) {
+		return nil, conflict("produk sudah berubah sejak dibaca; muat ulang produk lalu coba lagi")
+	}
+	if errors.Is(err, repository.ErrCurrencyInUse) {
+		return nil, conflict("mata uang produk tidak bisa diganti: " + err.Error())
+	}
+	if errors.Is(err, repository.ErrStockMismatch) {
+		return nil, conflict("stok tidak bisa diubah lewat update produk: " + err.Error() + "; pakai penyesuaian stok, transfer, atau stocktake")
+	}
+	if err != nil {
+		return nil, conflictIfDuplicate(err)
+	}
+	product.CategoryName = category.Name
+	return product, nil
+}
+
+// update membaca produk lalu menyimpan input di atasnya, dengan
+// updated_at yang dibaca sebagai syarat update.
+func (s *productService) update(id int, input model.ProductInput, actor string) (*model.Product, error) {
+	existing, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// slug hanya dibuat ulang jika nama berubah; slug lama masuk history
+	product := input.Product()
+	product.ID = id
+	product.Slug = existing.Slug
+	product.Tags = existing.Tags
+	product.UpdatedAt = existing.UpdatedAt
+	if input.UpdatedAt != nil {
+		product.UpdatedAt = *input.UpdatedAt
+	}
+	if input.Name != existing.Name {
+		product.Slug, err = uniqueSlug(input.Name, "product", id, s.repo.SlugExists)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := s.repo.Update(product, input.Stock, actor); err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+// GetBySlug mencari produk by slug aktif atau slug lama. Slug produk yang
+// dikembalikan bisa berbeda dari slug yang diminta.
+func (s *productService) GetBySlug(slug string) (*model.Product, error) {
+	return s.repo.GetBySlug(slug)
+}
+
+// Delete menghapus produk yang stoknya sudah 0 dan tidak punya reservasi
+// active. Riwayat stok, pesanan, dan purchase order produk tetap disimpan.
 func (s *productService) Delete(id int) error {
-	return s.repo.Delete(id)
+	err := s.repo.Delete(id)
+	if errors.Is(err, repository.ErrStockOnHand) {
+		return conflict("produk masih punya stok atau reservasi active; sesuaikan stoknya ke 0 dan lepas reservasinya terlebih dahulu")
+	}
+	return err
+}
+
+// validate mengecek aturan field, memastikan kategori produk ada, dan
+// mengecek atribut terhadap definisi atribut kategorinya.
+func (s *productService) validate(input model.ProductInput) (*model.Category, error) {
+	if err := validator.Struct(&input); err != nil {
+		return nil, err
+	}
+
+	category, err := s.categories.GetByID(input.CategoryId)
+	if errors.Is(err, ErrNotFound) {
+		return nil, fieldError("category_id", "exists", "category_id refers to a category that does not exist")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	defs, err := s.attributes.GetByCategory(category.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateAttributes(defs, input.Attributes); err != nil {
+		return nil, err
+	}
+	return category, nil
 }
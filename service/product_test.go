@@ -0,0 +1,107 @@
+package service
+
+import (
+	"errors"
+	"go-boot-category-api/framework/repository"
+	"go-boot-category-api/model"
+	"testing"
+	"time"
+)
+
+// fakeProductRepo adalah fakeProducts yang Update-nya mengembalikan
+// error dari updateErrs secara berurutan dan mencatat produk yang
+// disimpan
+type fakeProductRepo struct {
+	fakeProducts
+	updateErrs []error
+	updated    []model.Product
+}
+
+func (f *fakeProductRepo) Update(product *model.Product, stock *int, actor string) error {
+	f.updated = append(f.updated, *product)
+	if len(f.updateErrs) == 0 {
+		return nil
+	}
+	err := f.updateErrs[0]
+	f.updateErrs = f.updateErrs[1:]
+	return err
+}
+
+// fakeCategories adalah repository.Category dengan daftar kategori tetap
+type fakeCategories struct {
+	repository.Category
+	categories map[int]*model.Category
+}
+
+func (f *fakeCategories) GetByID(id int) (*model.Category, error) {
+	if c, ok := f.categories[id]; ok {
+		return c, nil
+	}
+	return nil, repository.ErrNotFound
+}
+
+// fakeAttributes adalah repository.Attribute tanpa definisi atribut
+type fakeAttributes struct {
+	repository.Attribute
+}
+
+func (f *fakeAttributes) GetByCategory(categoryID int) ([]model.AttributeDefinition, error) {
+	return nil, nil
+}
+
+func TestProductUpdate(t *testing.T) {
+	read := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	client := read.Add(-time.Hour)
+	tests := []struct {
+		name        string
+		updatedAt   *time.Time
+		updateErrs  []error
+		wantErr     error
+		wantUpdates int
+		wantVersion time.Time
+	}{
+		{name: "ok", wantUpdates: 1, wantVersion: read},
+		{name: "stale cache is read again", updateErrs: []error{repository.ErrModified}, wantUpdates: 2, wantVersion: read},
+		{
+			name:        "modified twice",
+			updateErrs:  []error{repository.ErrModified, repository.ErrModified},
+			wantErr:     ErrConflict,
+			wantUpdates: 2,
+			wantVersion: read,
+		},
+		{
+			name:        "client version is not retried",
+			updatedAt:   &client,
+			updateErrs:  []error{repository.ErrModified},
+			wantErr:     ErrConflict,
+			wantUpdates: 1,
+			wantVersion: client,
+		},
+		{name: "stock mismatch", updateErrs: []error{repository.ErrStockMismatch}, wantErr: ErrConflict, wantUpdates: 1, wantVersion: read},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeProductRepo{
+				fakeProducts: fakeProducts{products: map[int]*model.Product{
+					1: {ID: 1, Name: "Shirt", Slug: "shirt", CategoryId: 1, UpdatedAt: read},
+				}},
+				updateErrs: tt.updateErrs,
+			}
+			categories := &fakeCategories{categories: map[int]*model.Category{1: {ID: 1, Name: "Clothing"}}}
+			s := NewProductService(repo, categories, &fakeAttributes{}, nil)
+
+			input := model.ProductInput{Name: "Shirt", Price: model.Money{Amount: 1000, Currency: "IDR"}, CategoryId: 1, UpdatedAt: tt.updatedAt}
+			_, err := s.Update(1, input, "test")
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) || tt.wantErr == nil && err != nil {
+				t.Fatalf("Update() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(repo.updated) != tt.wantUpdates {
+				t.Fatalf("repository updates = %d, want %d", len(repo.updated), tt.wantUpdates)
+			}
+			if got := repo.updated[0].UpdatedAt; !got.Equal(tt.wantVersion) {
+				t.Errorf("expected version = %v, want %v", got, tt.wantVersion)
+			}
+		})
+	}
+}
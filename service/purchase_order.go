@@ -0,0 +1,173 @@
+package service
+
+import (
+	"errors"
+	"go-boot-category-api/framework/repository"
+	"go-boot-category-api/framework/validator"
+	"go-boot-category-api/model"
+)
+
+type PurchaseOrder interface {
+	GetAll(status string, page model.Pagination) (*model.PurchaseOrderPage, error)
+	GetByID(id int64) (*model.PurchaseOrder, error)
+	Create(input model.PurchaseOrderInput, actor string) (*model.PurchaseOrder, error)
+	Receive(id int64, input model.PurchaseReceiptInput, actor string) (*model.PurchaseReceipt, error)
+	Receipts(id int64) ([]model.PurchaseReceipt, error)
+}
+
+type purchaseOrderService struct {
+	repo       repository.PurchaseOrder
+	suppliers  repository.Supplier
+	products   repository.Product
+	warehouses repository.Warehouse
+}
+
+func NewPurchaseOrderService(repo repository.PurchaseOrder, suppliers repository.Supplier, products repository.Product, warehouses repository.Warehouse) PurchaseOrder {
+	return &purchaseOrderService{repo: repo, suppliers: suppliers, products: products, warehouses: warehouses}
+}
+
+func (s *purchaseOrderService) GetAll(status string, page model.Pagination) (*model.PurchaseOrderPage, error) {
+	return s.repo.GetAll(status, page)
+}
+
+func (s *purchaseOrderService) GetByID(id int64) (*model.PurchaseOrder, error) {
+	return s.repo.GetByID(id)
+}
+
+// Create membuat purchase order. Semua baris harus memakai mata uang
+// harga pokok yang sama.
+func (s *purchaseOrderService) Create(input model.PurchaseOrderInput, actor string) (*model.PurchaseOrder, error) {
+	if err := s.validate(input); err != nil {
+		return nil, err
+	}
+
+	order := &model.PurchaseOrder{SupplierID: input.SupplierID, Note: input.Note, Actor: actor}
+	order.Total.Currency = input.Lines[0].UnitCost.Currency
+	for _, line := range input.Lines {
+		productID := line.ProductID
+		order.Lines = append(order.Lines, model.PurchaseOrderLine{ProductID: &productID, Quantity: line.Quantity, UnitCost: line.UnitCost})
+	}
+
+	if err := s.repo.Create(order); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+func (s *purchaseOrderService) validate(input model.PurchaseOrderInput) error {
+	var errs validator.Errors
+	if err := validator.Struct(&input); err != nil {
+		if !errors.As(err, &errs) {
+			return err
+		}
+	}
+
+	if input.SupplierID > 0 {
+		if _, err := s.suppliers.GetByID(input.SupplierID); errors.Is(err, ErrNotFound) {
+			errs = append(errs, validator.FieldError{Field: "supplier_id", Rule: "exists", Message: "supplier_id refers to a supplier that does not exist"})
+		} else if err != nil {
+			return err
+		}
+	}
+
+	for i, line := range input.Lines {
+		prefix := itemPrefix("lines", i)
+		if hasItemErrors(errs, prefix) {
+			continue
+		}
+		if line.UnitCost.Currency != input.Lines[0].UnitCost.Currency {
+			errs = append(errs, validator.FieldError{Field: prefix + "unit_cost.currency", Rule: "currency", Message: prefix + "unit_cost.currency must match the other lines"})
+			continue
+		}
+		product, err := s.products.GetByID(line.ProductID)
+		if errors.Is(err, ErrNotFound) {
+			errs = append(errs, validator.FieldError{Field: prefix + "product_id", Rule: "exists", Message: prefix + "product_id refers to a product that does not exist"})
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		// harga pokok dicatat dalam mata uang harga jual produk
+		if line.UnitCost.Currency != product.Price.Currency {
+			errs = append(errs, validator.FieldError{Field: prefix + "unit_cost.currency", Rule: "currency", Message: prefix + "unit_cost.currency must match the product price currency " + product.Price.Currency})
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Receive mencatat penerimaan barang untuk purchase order. Penerimaan
+// boleh sebagian, tapi tidak boleh melebihi sisa jumlah yang dipesan.
+func (s *purchaseOrderService) Receive(id int64, input model.PurchaseReceiptInput, actor string) (*model.PurchaseReceipt, error) {
+	order, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateReceipt(order, input); err != nil {
+		return nil, err
+	}
+
+	receipt := &model.PurchaseReceipt{PurchaseOrderID: id, WarehouseID: input.WarehouseID, Note: input.Note, Actor: actor}
+	for _, line := range input.Lines {
+		receipt.Lines = append(receipt.Lines, model.PurchaseReceiptLine{PurchaseOrderLineID: line.LineID, Quantity: line.Quantity})
+	}
+
+	err = s.repo.Receive(receipt)
+	if errors.Is(err, repository.ErrOverReceipt) {
+		return nil, conflict(err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return receipt, nil
+}
+
+// validateReceipt memvalidasi input dan memastikan setiap baris merujuk
+// ke baris purchase order ini. Sisa jumlah diperiksa ulang oleh
+// repository di dalam transaksi.
+func (s *purchaseOrderService) validateReceipt(order *model.PurchaseOrder, input model.PurchaseReceiptInput) error {
+	var errs validator.Errors
+	if err := validator.Struct(&input); err != nil {
+		if !errors.As(err, &errs) {
+			return err
+		}
+	}
+
+	if input.WarehouseID != 0 {
+		if _, err := s.warehouses.GetByID(input.WarehouseID); errors.Is(err, ErrNotFound) {
+			errs = append(errs, validator.FieldError{Field: "warehouse_id", Rule: "exists", Message: "warehouse_id refers to a warehouse that does not exist"})
+		} else if err != nil {
+			return err
+		}
+	}
+
+	lines := make(map[int64]bool, len(order.Lines))
+	for _, line := range order.Lines {
+		lines[line.ID] = true
+	}
+	for i, line := range input.Lines {
+		prefix := itemPrefix("lines", i)
+		if hasItemErrors(errs, prefix) {
+			continue
+		}
+		if !lines[line.LineID] {
+			errs = append(errs, validator.FieldError{Field: prefix + "line_id", Rule: "exists", Message: prefix + "line_id refers to a line that is not in this purchase order"})
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Receipts mengambil semua penerimaan barang untuk purchase order.
+func (s *purchaseOrderService) Receipts(id int64) ([]model.PurchaseReceipt, error) {
+	if _, err := s.repo.GetByID(id); err != nil {
+		return nil, err
+	}
+	return s.repo.Receipts(id)
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"errors"
+	"go-boot-category-api/framework/repository"
+	"go-boot-category-api/framework/validator"
+	"go-boot-category-api/model"
+	"testing"
+)
+
+type fakePurchaseOrderRepo struct {
+	repository.PurchaseOrder
+	order      *model.PurchaseOrder
+	created    *model.PurchaseOrder
+	receiveErr error
+	received   *model.PurchaseReceipt
+}
+
+func (f *fakePurchaseOrderRepo) Create(order *model.PurchaseOrder) error {
+	f.created = order
+	return nil
+}
+
+func (f *fakePurchaseOrderRepo) GetByID(id int64) (*model.PurchaseOrder, error) {
+	return f.order, nil
+}
+
+func (f *fakePurchaseOrderRepo) Receive(receipt *model.PurchaseReceipt) error {
+	f.received = receipt
+	return f.receiveErr
+}
+
+func TestPurchaseOrderCreate(t *testing.T) {
+	idr := func(amount int64) model.Money { return model.Money{Amount: amount, Currency: "IDR"} }
+	usd := model.Money{Amount: 100, Currency: "USD"}
+	tests := []struct {
+		name      string
+		input     model.PurchaseOrderInput
+		wantField string
+	}{
+		{name: "valid", input: model.PurchaseOrderInput{SupplierID: 1, Lines: []model.PurchaseOrderLineInput{{ProductID: 1, Quantity: 5, UnitCost: idr(700)}}}},
+		{name: "unknown supplier", input: model.PurchaseOrderInput{SupplierID: 9, Lines: []model.PurchaseOrderLineInput{{ProductID: 1, Quantity: 5, UnitCost: idr(700)}}}, wantField: "supplier_id"},
+		{name: "no lines", input: model.PurchaseOrderInput{SupplierID: 1}, wantField: "lines"},
+		{name: "zero quantity", input: model.PurchaseOrderInput{SupplierID: 1, Lines: []model.PurchaseOrderLineInput{{ProductID: 1, UnitCost: idr(700)}}}, wantField: "lines[0].quantity"},
+		{name: "unknown product", input: model.PurchaseOrderInput{SupplierID: 1, Lines: []model.PurchaseOrderLineInput{{ProductID: 9, Quantity: 5, UnitCost: idr(700)}}}, wantField: "lines[0].product_id"},
+		{
+			name: "mixed currencies",
+			input: model.PurchaseOrderInput{SupplierID: 1, Lines: []model.PurchaseOrderLineInput{
+				{ProductID: 1, Quantity: 5, UnitCost: idr(700)},
+				{ProductID: 1, Quantity: 5, UnitCost: usd},
+			}},
+			wantField: "lines[1].unit_cost.currency",
+		},
+		{name: "cost in another currency than the price", input: model.PurchaseOrderInput{SupplierID: 1, Lines: []model.PurchaseOrderLineInput{{ProductID: 1, Quantity: 5, UnitCost: usd}}}, wantField: "lines[0].unit_cost.currency"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakePurchaseOrderRepo{}
+			suppliers := &fakeSuppliers{suppliers: map[int]*model.Supplier{1: {ID: 1}}}
+			products := &fakeProducts{products: map[int]*model.Product{1: {ID: 1, Price: idr(1000)}}}
+			s := NewPurchaseOrderService(repo, suppliers, products, nil)
+
+			order, err := s.Create(tt.input, "test")
+			if tt.wantField != "" {
+				var errs validator.Errors
+				if !errors.As(err, &errs) || errs[0].Field != tt.wantField {
+					t.Fatalf("Create() error = %v, want validation error on %s", err, tt.wantField)
+				}
+				if repo.created != nil {
+					t.Error("Create() reached the repository with invalid input")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			if order.Actor != "test" || order.Total.Currency != "IDR" || *order.Lines[0].ProductID != 1 {
+				t.Errorf("Create() passed %+v", order)
+			}
+		})
+	}
+}
+
+func TestPurchaseOrderReceive(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      model.PurchaseReceiptInput
+		receiveErr error
+		wantErr    error
+		wantField  string
+	}{
+		{name: "valid", input: model.PurchaseReceiptInput{Lines: []model.PurchaseReceiptLineInput{{LineID: 7, Quantity: 3}}}},
+		{name: "line from another order", input: model.PurchaseReceiptInput{Lines: []model.PurchaseReceiptLineInput{{LineID: 8, Quantity: 3}}}, wantField: "lines[0].line_id"},
+		{name: "unknown warehouse", input: model.PurchaseReceiptInput{WarehouseID: 9, Lines: []model.PurchaseReceiptLineInput{{LineID: 7, Quantity: 3}}}, wantField: "warehouse_id"},
+		{name: "zero quantity", input: model.PurchaseReceiptInput{Lines: []model.PurchaseReceiptLineInput{{LineID: 7}}}, wantField: "lines[0].quantity"},
+		{
+			name:       "over receipt",
+			input:      model.PurchaseReceiptInput{Lines: []model.PurchaseReceiptLineInput{{LineID: 7, Quantity: 30}}},
+			receiveErr: repository.ErrOverReceipt,
+			wantErr:    ErrConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakePurchaseOrderRepo{
+				order:      &model.PurchaseOrder{ID: 1, Lines: []model.PurchaseOrderLine{{ID: 7, Quantity: 10}}},
+				receiveErr: tt.receiveErr,
+			}
+			warehouses := &fakeWarehouses{warehouses: map[int]*model.Warehouse{1: {ID: 1}}}
+			s := NewPurchaseOrderService(repo, nil, &fakeProducts{}, warehouses)
+
+			_, err := s.Receive(1, tt.input, "test")
+			switch {
+			case tt.wantField != "":
+				var errs validator.Errors
+				if !errors.As(err, &errs) || errs[0].Field != tt.wantField {
+					t.Fatalf("Receive() error = %v, want validation error on %s", err, tt.wantField)
+				}
+				if repo.received != nil {
+					t.Error("Receive() reached the repository with invalid input")
+				}
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Receive() error = %v, want %v", err, tt.wantErr)
+				}
+			case err != nil:
+				t.Fatalf("Receive() error = %v", err)
+			case repo.received.Lines[0].PurchaseOrderLineID != 7 || repo.received.Actor != "test":
+				t.Errorf("Receive() passed %+v", repo.received)
+			}
+		})
+	}
+}
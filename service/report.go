@@ -0,0 +1,68 @@
+package service
+
+import (
+	"go-boot-category-api/framework/repository"
+	"go-boot-category-api/model"
+	"time"
+)
+
+type Report interface {
+	ProductMargins(from, to time.Time) (*model.ProductMarginReport, error)
+	CategoryMargins(from, to time.Time) (*model.CategoryMarginReport, error)
+	InventoryValuation(at time.Time) (*model.InventoryValuation, error)
+}
+
+type reportService struct {
+	repo repository.Report
+}
+
+func NewReportService(repo repository.Report) Report {
+	return &reportService{repo: repo}
+}
+
+// ProductMargins menghitung laba kotor per produk untuk pesanan yang dibuat
+// dalam rentang [from, to).
+func (s *reportService) ProductMargins(from, to time.Time) (*model.ProductMarginReport, error) {
+	if err := validateRange(from, to); err != nil {
+		return nil, err
+	}
+	items, err := s.repo.ProductMargins(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return &model.ProductMarginReport{From: from, To: to, Items: items}, nil
+}
+
+// CategoryMargins menghitung laba kotor per kategori untuk pesanan yang
+// dibuat dalam rentang [from, to).
+func (s *reportService) CategoryMargins(from, to time.Time) (*model.CategoryMarginReport, error) {
+	if err := validateRange(from, to); err != nil {
+		return nil, err
+	}
+	items, err := s.repo.CategoryMargins(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return &model.CategoryMarginReport{From: from, To: to, Items: items}, nil
+}
+
+// InventoryValuation menghitung nilai persediaan dari semua pergerakan stok
+// sebelum at.
+func (s *reportService) InventoryValuation(at time.Time) (*model.InventoryValuation, error) {
+	items, err := s.repo.InventoryValues(at)
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.repo.InventoryTotals(at)
+	if err != nil {
+		return nil, err
+	}
+	return &model.InventoryValuation{At: at, Items: items, Total: total}, nil
+}
+
+func validateRange(from, to time.Time) error {
+	if !from.Before(to) {
+		return fieldError("to", "after", "to must be after from")
+	}
+	return nil
+}
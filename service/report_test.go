@@ -0,0 +1,96 @@
+package service
+
+import (
+	"errors"
+	"go-boot-category-api/framework/repository"
+	"go-boot-category-api/framework/validator"
+	"go-boot-category-api/model"
+	"testing"
+	"time"
+)
+
+type fakeReportRepo struct {
+	repository.Report
+	calls int
+}
+
+func (f *fakeReportRepo) ProductMargins(from, to time.Time) ([]model.ProductMargin, error) {
+	f.calls++
+	return []model.ProductMargin{{ProductID: 1, QuantitySold: 3}}, nil
+}
+
+func (f *fakeReportRepo) CategoryMargins(from, to time.Time) ([]model.CategoryMargin, error) {
+	f.calls++
+	return []model.CategoryMargin{{CategoryID: 2, QuantitySold: 3}}, nil
+}
+
+func (f *fakeReportRepo) InventoryValues(at time.Time) ([]model.InventoryValue, error) {
+	return []model.InventoryValue{{ProductID: 1, Quantity: 4, Value: model.Money{Amount: 4000, Currency: "IDR"}}}, nil
+}
+
+func (f *fakeReportRepo) InventoryTotals(at time.Time) ([]model.Money, error) {
+	return []model.Money{{Amount: 4000, Currency: "IDR"}}, nil
+}
+
+func TestReportMarginsRange(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		to      time.Time
+		wantErr bool
+	}{
+		{"valid", from.AddDate(0, 1, 0), false},
+		{"empty range", from, true},
+		{"reversed", from.AddDate(0, -1, 0), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeReportRepo{}
+			s := NewReportService(repo)
+
+			products, err := s.ProductMargins(from, tt.to)
+			if tt.wantErr {
+				var errs validator.Errors
+				if !errors.As(err, &errs) || errs[0].Field != "to" {
+					t.Fatalf("ProductMargins() error = %v, want validation error on to", err)
+				}
+				if _, err := s.CategoryMargins(from, tt.to); !errors.As(err, &errs) {
+					t.Fatalf("CategoryMargins() error = %v, want validation error", err)
+				}
+				if repo.calls != 0 {
+					t.Errorf("repository called %d times with an invalid range", repo.calls)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ProductMargins() error = %v", err)
+			}
+			if !products.From.Equal(from) || !products.To.Equal(tt.to) || len(products.Items) != 1 || products.Items[0].ProductID != 1 {
+				t.Errorf("ProductMargins() = %+v", products)
+			}
+			categories, err := s.CategoryMargins(from, tt.to)
+			if err != nil {
+				t.Fatalf("CategoryMargins() error = %v", err)
+			}
+			if len(categories.Items) != 1 || categories.Items[0].CategoryID != 2 {
+				t.Errorf("CategoryMargins() = %+v", categories)
+			}
+		})
+	}
+}
+
+func TestReportInventoryValuation(t *testing.T) {
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewReportService(&fakeReportRepo{})
+
+	got, err := s.InventoryValuation(at)
+	if err != nil {
+		t.Fatalf("InventoryValuation() error = %v", err)
+	}
+	if !got.At.Equal(at) || len(got.Items) != 1 || got.Items[0].Quantity != 4 {
+		t.Errorf("InventoryValuation() items = %+v", got)
+	}
+	if len(got.Total) != 1 || got.Total[0] != (model.Money{Amount: 4000, Currency: "IDR"}) {
+		t.Errorf("InventoryValuation() total = %v", got.Total)
+	}
+}
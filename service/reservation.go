@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"go-boot-category-api/framework/repository"
+	"go-boot-category-api/framework/validator"
+	"go-boot-category-api/model"
+	"log"
+	"time"
+)
+
+type Reservation interface {
+	GetByID(id int64) (*model.Reservation, error)
+	Create(input model.ReservationInput, actor string) (*model.Reservation, error)
+	Confirm(id int64, actor string) (*model.Reservation, error)
+	Release(id int64) (*model.Reservation, error)
+	ExpireDue() (int, error)
+	RunReaper(ctx context.Context, interval time.Duration)
+}
+
+type reservationService struct {
+	repo       repository.Reservation
+	products   repository.Product
+	warehouses repository.Warehouse
+	ttl        time.Duration
+}
+
+// NewReservationService membuat service reservasi; ttl dipakai jika
+// client tidak menentukan ttl_seconds.
+func NewReservationService(repo repository.Reservation, products repository.Product, warehouses repository.Warehouse, ttl time.Duration) Reservation {
+	return &reservationService{repo: repo, products: products, warehouses: warehouses, ttl: ttl}
+}
+
+func (s *reservationService) GetByID(id int64) (*model.Reservation, error) {
+	return s.repo.GetByID(id)
+}
+
+// Create menahan stok produk selama TTL reservasi. Gagal dengan
+// ErrInsufficientStock jika stok tersedia di gudang tidak cukup.
+func (s *reservationService) Create(input model.ReservationInput, actor string) (*model.Reservation, error) {
+	if err := validator.Struct(&input); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.products.GetByID(input.ProductID); errors.Is(err, ErrNotFound) {
+		return nil, fieldError("product_id", "exists", "product_id refers to a product that does not exist")
+	} else if err != nil {
+		return nil, err
+	}
+	if input.WarehouseID != 0 {
+		if _, err := s.warehouses.GetByID(input.WarehouseID); errors.Is(err, ErrNotFound) {
+			return nil, fieldError("warehouse_id", "exists", "warehouse_id refers to a warehouse that does not exist")
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	ttl := s.ttl
+	if input.TTLSeconds > 0 {
+		ttl = time.Duration(input.TTLSeconds) * time.Second
+	}
+	reservation := &model.Reservation{
+		ProductID:   &input.ProductID,
+		WarehouseID: input.WarehouseID,
+		Quantity:    input.Quantity,
+		Reference:   input.Reference,
+		Actor:       actor,
+	}
+	err := s.repo.Create(reservation, ttl)
+	if errors.Is(err, repository.ErrInsufficientStock) {
+		return nil, &businessError{kind: ErrInsufficientStock, message: "stok tersedia tidak mencukupi untuk reservasi"}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return reservation, nil
+}
+
+// Confirm mengubah reservasi menjadi pengurangan stok on-hand.
+func (s *reservationService) Confirm(id int64, actor string) (*model.Reservation, error) {
+	reservation, err := s.repo.Confirm(id, actor)
+	if err != nil {
+		return nil, err
+	}
+	if reservation.Status != model.ReservationConfirmed {
+		return nil, conflict("reservasi sudah " + reservation.Status)
+	}
+	return reservation, nil
+}
+
+// Release melepas stok yang ditahan reservasi.
+func (s *reservationService) Release(id int64) (*model.Reservation, error) {
+	reservation, err := s.repo.Release(id)
+	if err != nil {
+		return nil, err
+	}
+	if reservation.Status != model.ReservationReleased {
+		return nil, conflict("reservasi sudah " + reservation.Status)
+	}
+	return reservation, nil
+}
+
+// ExpireDue menandai reservasi yang sudah lewat TTL sebagai expired dan
+// mengembalikan jumlah produk yang stok tersedianya berubah.
+func (s *reservationService) ExpireDue() (int, error) {
+	ids, err := s.repo.Expire()
+	return len(ids), err
+}
+
+// RunReaper menjalankan ExpireDue setiap interval sampai ctx selesai.
+func (s *reservationService) RunReaper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		count, err := s.ExpireDue()
+		if err != nil {
+			log.Printf("reservation reaper: %v", err)
+		} else if count > 0 {
+			log.Printf("reservation reaper: expired reservations of %d products", count)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
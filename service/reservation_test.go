@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"go-boot-category-api/framework/repository"
+	"go-boot-category-api/framework/validator"
+	"go-boot-category-api/model"
+	"testing"
+	"time"
+)
+
+// fakeReservationRepo mencatat argumen Create dan mengembalikan hasil yang
+// sudah diatur
+type fakeReservationRepo struct {
+	repository.Reservation
+	createErr error
+	ttl       time.Duration
+	result    *model.Reservation
+	expired   []int
+	expires   int
+}
+
+func (f *fakeReservationRepo) Create(reservation *model.Reservation, ttl time.Duration) error {
+	f.ttl = ttl
+	return f.createErr
+}
+
+func (f *fakeReservationRepo) Confirm(id int64, actor string) (*model.Reservation, error) {
+	return f.result, nil
+}
+
+func (f *fakeReservationRepo) Release(id int64) (*model.Reservation, error) {
+	return f.result, nil
+}
+
+func (f *fakeReservationRepo) Expire() ([]int, error) {
+	f.expires++
+	return f.expired, nil
+}
+
+func newTestReservationService(repo *fakeReservationRepo) Reservation {
+	products := &fakeProducts{products: map[int]*model.Product{1: {ID: 1}}}
+	warehouses := &fakeWarehouses{warehouses: map[int]*model.Warehouse{1: {ID: 1}}}
+	return NewReservationService(repo, products, warehouses, 15*time.Minute)
+}
+
+func TestReservationCreate(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     model.ReservationInput
+		createErr error
+		wantTTL   time.Duration
+		wantErr   error
+		wantField string
+	}{
+		{name: "default ttl", input: model.ReservationInput{ProductID: 1, Quantity: 2}, wantTTL: 15 * time.Minute},
+		{name: "custom ttl", input: model.ReservationInput{ProductID: 1, Quantity: 2, TTLSeconds: 60}, wantTTL: time.Minute},
+		{name: "unknown product", input: model.ReservationInput{ProductID: 9, Quantity: 2}, wantField: "product_id"},
+		{name: "unknown warehouse", input: model.ReservationInput{ProductID: 1, WarehouseID: 9, Quantity: 2}, wantField: "warehouse_id"},
+		{name: "zero quantity", input: model.ReservationInput{ProductID: 1}, wantField: "quantity"},
+		{
+			name:      "insufficient stock",
+			input:     model.ReservationInput{ProductID: 1, Quantity: 2},
+			createErr: repository.ErrInsufficientStock,
+			wantErr:   ErrInsufficientStock,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeReservationRepo{createErr: tt.createErr}
+			_, err := newTestReservationService(repo).Create(tt.input, "test")
+
+			switch {
+			case tt.wantField != "":
+				var errs validator.Errors
+				if !errors.As(err, &errs) || errs[0].Field != tt.wantField {
+					t.Fatalf("Create() error = %v, want validation error on %s", err, tt.wantField)
+				}
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Create() error = %v, want %v", err, tt.wantErr)
+				}
+			case err != nil:
+				t.Fatalf("Create() error = %v", err)
+			case repo.ttl != tt.wantTTL:
+				t.Errorf("ttl = %v, want %v", repo.ttl, tt.wantTTL)
+			}
+		})
+	}
+}
+
+func TestReservationConfirmAndRelease(t *testing.T) {
+	tests := []struct {
+		status     string
+		confirmErr bool
+		releaseErr bool
+	}{
+		{status: model.ReservationConfirmed, releaseErr: true},
+		{status: model.ReservationReleased, confirmErr: true},
+		{status: model.ReservationExpired, confirmErr: true, releaseErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			repo := &fakeReservationRepo{result: &model.Reservation{ID: 1, Status: tt.status}}
+			s := newTestReservationService(repo)
+
+			if _, err := s.Confirm(1, "test"); tt.confirmErr != errors.Is(err, ErrConflict) {
+				t.Errorf("Confirm() error = %v, want conflict %v", err, tt.confirmErr)
+			}
+			if _, err := s.Release(1); tt.releaseErr != errors.Is(err, ErrConflict) {
+				t.Errorf("Release() error = %v, want conflict %v", err, tt.releaseErr)
+			}
+		})
+	}
+}
+
+func TestReservationRunReaper(t *testing.T) {
+	repo := &fakeReservationRepo{expired: []int{3, 4}}
+	s := newTestReservationService(repo)
+
+	if count, err := s.ExpireDue(); err != nil || count != 2 {
+		t.Errorf("ExpireDue() = %d, %v, want 2 products", count, err)
+	}
+
+	// reaper langsung berjalan sekali saat start, lalu berhenti saat ctx selesai
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	repo.expires = 0
+	s.RunReaper(ctx, time.Hour)
+	if repo.expires != 1 {
+		t.Errorf("RunReaper() called Expire %d times, want 1", repo.expires)
+	}
+}
@@ -0,0 +1,12 @@
+package service
+
+import "go-boot-category-api/framework/slug"
+
+// uniqueSlug membuat slug unik dari name. exists adalah SlugExists milik
+// repository, dengan excludeID sebagai ID entity yang sedang di-update
+// (0 untuk create) supaya slug miliknya sendiri tidak dianggap bentrok.
+func uniqueSlug(name, fallback string, excludeID int, exists func(slug string, excludeID int) (bool, error)) (string, error) {
+	return slug.Unique(slug.Make(name), fallback, func(candidate string) (bool, error) {
+		return exists(candidate, excludeID)
+	})
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"errors"
+	"go-boot-category-api/framework/repository"
+	"go-boot-category-api/framework/validator"
+	"go-boot-category-api/model"
+)
+
+type Stock interface {
+	Transfer(input model.StockTransferInput, actor string) (*model.StockTransfer, error)
+	GetTransfer(id int64) (*model.StockTransfer, error)
+	Adjust(productID int, input model.StockAdjustmentInput, actor string) (*model.LedgerEntry, error)
+}
+
+type stockService struct {
+	repo       repository.Stock
+	products   repository.Product
+	warehouses repository.Warehouse
+}
+
+func NewStockService(repo repository.Stock, products repository.Product, warehouses repository.Warehouse) Stock {
+	return &stockService{repo: repo, products: products, warehouses: warehouses}
+}
+
+// Transfer memindahkan stok produk antar gudang secara atomik.
+func (s *stockService) Transfer(input model.StockTransferInput, actor string) (*model.StockTransfer, error) {
+	if err := validator.Struct(&input); err != nil {
+		return nil, err
+	}
+	if input.FromWarehouseID == input.ToWarehouseID {
+		return nil, fieldError("to_warehouse_id", "different", "to_warehouse_id must differ from from_warehouse_id")
+	}
+
+	if _, err := s.products.GetByID(input.ProductID); errors.Is(err, ErrNotFound) {
+		return nil, fieldError("product_id", "exists", "product_id refers to a product that does not exist")
+	} else if err != nil {
+		return nil, err
+	}
+	if err := s.warehouseExists("from_warehouse_id", input.FromWarehouseID); err != nil {
+		return nil, err
+	}
+	if err := s.warehouseExists("to_warehouse_id", input.ToWarehouseID); err != nil {
+		return nil, err
+	}
+
+	transfer := &model.StockTransfer{
+		ProductID:       &input.ProductID,
+		FromWarehouseID: input.FromWarehouseID,
+		ToWarehouseID:   input.ToWarehouseID,
+		Quantity:        input.Quantity,
+		Note:            input.Note,
+		Actor:           actor,
+	}
+	err := s.repo.Transfer(transfer)
+	if errors.Is(err, repository.ErrInsufficientStock) {
+		return nil, &businessError{kind: ErrInsufficientStock, message: "stok tersedia di gudang asal tidak mencukupi"}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// warehouseExists mengembalikan error validasi untuk field jika gudang id
+// tidak ada.
+func (s *stockService) warehouseExists(field string, id int) error {
+	_, err := s.warehouses.GetByID(id)
+	if errors.Is(err, ErrNotFound) {
+		return fieldError(field, "exists", field+" refers to a warehouse that does not exist")
+	}
+	return err
+}
+
+func (s *stockService) GetTransfer(id int64) (*model.StockTransfer, error) {
+	return s.repo.GetTransfer(id)
+}
+
+// Adjust menambah atau mengurangi stok produk di satu gudang, misal karena
+// barang rusak atau hilang, dan mencatatnya di ledger atas nama actor.
+func (s *stockService) Adjust(productID int, input model.StockAdjustmentInput, actor string) (*model.LedgerEntry, error) {
+	if err := validator.Struct(&input); err != nil {
+		return nil, err
+	}
+	if _, err := s.products.GetByID(productID); err != nil {
+		return nil, err
+	}
+	if err := s.warehouseExists("warehouse_id", input.WarehouseID); err != nil {
+		return nil, err
+	}
+
+	entry, err := s.repo.Adjust(productID, input.WarehouseID, input.Delta, actor)
+	if errors.Is(err, repository.ErrInsufficientStock) {
+		return nil, &businessError{kind: ErrInsufficientStock, message: "stok tersedia di gudang tidak cukup untuk dikurangi; lepas reservasinya terlebih dahulu"}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
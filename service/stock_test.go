@@ -0,0 +1,74 @@
+package service
+
+import (
+	"errors"
+	"go-boot-category-api/framework/repository"
+	"go-boot-category-api/framework/validator"
+	"go-boot-category-api/model"
+	"testing"
+)
+
+// fakeStockRepo mencatat argumen Adjust dan mengembalikan error yang
+// sudah diatur
+type fakeStockRepo struct {
+	repository.Stock
+	adjustErr error
+	delta     int
+}
+
+func (f *fakeStockRepo) Adjust(productID, warehouseID, delta int, actor string) (*model.LedgerEntry, error) {
+	if f.adjustErr != nil {
+		return nil, f.adjustErr
+	}
+	f.delta = delta
+	return &model.LedgerEntry{ProductID: &productID, WarehouseID: warehouseID, Delta: delta, Reason: model.LedgerAdjustment, Actor: actor}, nil
+}
+
+func TestStockAdjust(t *testing.T) {
+	tests := []struct {
+		name      string
+		productID int
+		input     model.StockAdjustmentInput
+		adjustErr error
+		wantErr   error
+		wantField string
+	}{
+		{name: "add", productID: 1, input: model.StockAdjustmentInput{WarehouseID: 1, Delta: 3}},
+		{name: "remove", productID: 1, input: model.StockAdjustmentInput{WarehouseID: 1, Delta: -3}},
+		{name: "zero delta", productID: 1, input: model.StockAdjustmentInput{WarehouseID: 1}, wantField: "delta"},
+		{name: "unknown warehouse", productID: 1, input: model.StockAdjustmentInput{WarehouseID: 9, Delta: 3}, wantField: "warehouse_id"},
+		{name: "unknown product", productID: 9, input: model.StockAdjustmentInput{WarehouseID: 1, Delta: 3}, wantErr: ErrNotFound},
+		{
+			name:      "insufficient stock",
+			productID: 1,
+			input:     model.StockAdjustmentInput{WarehouseID: 1, Delta: -3},
+			adjustErr: repository.ErrInsufficientStock,
+			wantErr:   ErrInsufficientStock,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeStockRepo{adjustErr: tt.adjustErr}
+			products := &fakeProducts{products: map[int]*model.Product{1: {ID: 1}}}
+			warehouses := &fakeWarehouses{warehouses: map[int]*model.Warehouse{1: {ID: 1}}}
+			_, err := NewStockService(repo, products, warehouses).Adjust(tt.productID, tt.input, "test")
+
+			switch {
+			case tt.wantField != "":
+				var errs validator.Errors
+				if !errors.As(err, &errs) || errs[0].Field != tt.wantField {
+					t.Fatalf("Adjust() error = %v, want validation error on %s", err, tt.wantField)
+				}
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Adjust() error = %v, want %v", err, tt.wantErr)
+				}
+			case err != nil:
+				t.Fatalf("Adjust() error = %v", err)
+			case repo.delta != tt.input.Delta:
+				t.Errorf("delta = %d, want %d", repo.delta, tt.input.Delta)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"go-boot-category-api/framework/repository"
+	"go-boot-category-api/framework/validator"
+	"go-boot-category-api/model"
+)
+
+type Stocktake interface {
+	GetAll(status string, page model.Pagination) (*model.StocktakePage, error)
+	GetByID(id int64) (*model.Stocktake, error)
+	Create(input model.StocktakeInput, actor string) (*model.Stocktake, error)
+	Count(id int64, input model.StocktakeCountInput, actor string) (*model.Stocktake, error)
+	Approve(id int64, actor string) (*model.Stocktake, error)
+	Cancel(id int64, actor string) (*model.Stocktake, error)
+	Variance(id int64) (*model.StocktakeVariance, error)
+}
+
+type stocktakeService struct {
+	repo       repository.Stocktake
+	categories repository.Category
+	warehouses repository.Warehouse
+}
+
+func NewStocktakeService(repo repository.Stocktake, categories repository.Category, warehouses repository.Warehouse) Stocktake {
+	return &stocktakeService{repo: repo, categories: categories, warehouses: warehouses}
+}
+
+func (s *stocktakeService) GetAll(status string, page model.Pagination) (*model.StocktakePage, error) {
+	return s.repo.GetAll(status, page)
+}
+
+func (s *stocktakeService) GetByID(id int64) (*model.Stocktake, error) {
+	return s.repo.GetByID(id)
+}
+
+// Create memulai sesi stocktake untuk gudang, kategori, atau keduanya, dan
+// menyalin stok on-hand saat ini sebagai stok yang diharapkan.
+func (s *stocktakeService) Create(input model.StocktakeInput, actor string) (*model.Stocktake, error) {
+	if err := s.validate(input); err != nil {
+		return nil, err
+	}
+
+	stocktake := &model.Stocktake{Note: input.Note, Actor: actor}
+	if input.WarehouseID != 0 {
+		stocktake.WarehouseID = &input.WarehouseID
+	}
+	if input.CategoryID != 0 {
+		stocktake.CategoryID = &input.CategoryID
+	}
+	if err := s.repo.Create(stocktake); err != nil {
+		return nil, err
+	}
+	return stocktake, nil
+}
+
+func (s *stocktakeService) validate(input model.StocktakeInput) error {
+	var errs validator.Errors
+	if err := validator.Struct(&input); err != nil {
+		if !errors.As(err, &errs) {
+			return err
+		}
+	}
+
+	if input.WarehouseID == 0 && input.CategoryID == 0 {
+		errs = append(errs, validator.FieldError{Field: "warehouse_id", Rule: "required_without", Message: "warehouse_id or category_id is required"})
+	}
+	if input.WarehouseID > 0 {
+		if _, err := s.warehouses.GetByID(input.WarehouseID); errors.Is(err, ErrNotFound) {
+			errs = append(errs, validator.FieldError{Field: "warehouse_id", Rule: "exists", Message: "warehouse_id refers to a warehouse that does not exist"})
+		} else if err != nil {
+			return err
+		}
+	}
+	if input.CategoryID > 0 {
+		if _, err := s.categories.GetByID(input.CategoryID); errors.Is(err, ErrNotFound) {
+			errs = append(errs, validator.FieldError{Field: "category_id", Rule: "exists", Message: "category_id refers to a category that does not exist"})
+		} else if err != nil {
+			return err
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Count menyimpan hasil hitungan untuk sesi yang masih open. Setiap
+// hitungan harus merujuk ke produk dan gudang dalam cakupan sesi;
+// warehouse_id kosong berarti gudang sesi.
+func (s *stocktakeService) Count(id int64, input model.StocktakeCountInput, actor string) (*model.Stocktake, error) {
+	stocktake, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if stocktake.Status != model.StocktakeOpen {
+		return nil, conflict(fmt.Sprintf("stocktake %d berstatus %s dan tidak bisa dihitung lagi", id, stocktake.Status))
+	}
+	if err := s.validateCounts(stocktake, &input); err != nil {
+		return nil, err
+	}
+
+	stocktake, err = s.repo.Count(id, input.Counts, actor)
+	if errors.Is(err, repository.ErrStocktakeClosed) {
+		return nil, conflict(err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return stocktake, nil
+}
+
+// validateCounts memvalidasi input dan mengisi warehouse_id yang kosong
+// dengan gudang sesi.
+func (s *stocktakeService) validateCounts(stocktake *model.Stocktake, input *model.StocktakeCountInput) error {
+	var errs validator.Errors
+	if err := validator.Struct(input); err != nil {
+		if !errors.As(err, &errs) {
+			return err
+		}
+	}
+
+	type key struct{ productID, warehouseID int }
+	lines := make(map[key]bool, len(stocktake.Lines))
+	for _, line := range stocktake.Lines {
+		if line.ProductID != nil {
+			lines[key{*line.ProductID, line.WarehouseID}] = true
+		}
+	}
+	for i := range input.Counts {
+		count := &input.Counts[i]
+		prefix := itemPrefix("counts", i)
+		if hasItemErrors(errs, prefix) {
+			continue
+		}
+		if count.WarehouseID == 0 {
+			if stocktake.WarehouseID == nil {
+				errs = append(errs, validator.FieldError{Field: prefix + "warehouse_id", Rule: "required", Message: prefix + "warehouse_id is required for a stocktake that covers all warehouses"})
+				continue
+			}
+			count.WarehouseID = *stocktake.WarehouseID
+		}
+		if !lines[key{count.ProductID, count.WarehouseID}] {
+			errs = append(errs, validator.FieldError{Field: prefix + "product_id", Rule: "exists", Message: prefix + "product_id and warehouse_id refer to a line that is not in this stocktake"})
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Approve menyetujui sesi dan memposting selisih setiap baris yang sudah
+// dihitung sebagai penyesuaian stok.
+func (s *stocktakeService) Approve(id int64, actor string) (*model.Stocktake, error) {
+	stocktake, err := s.repo.Approve(id, actor)
+	if errors.Is(err, repository.ErrStocktakeClosed) {
+		return nil, conflict(err.Error())
+	}
+	if errors.Is(err, repository.ErrInsufficientStock) {
+		return nil, &businessError{kind: ErrInsufficientStock, message: "selisih stocktake membuat stok menjadi negatif karena stok sudah berkurang sejak sesi dimulai: " + err.Error()}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return stocktake, nil
+}
+
+// Cancel membatalkan sesi tanpa mengubah stok.
+func (s *stocktakeService) Cancel(id int64, actor string) (*model.Stocktake, error) {
+	stocktake, err := s.repo.Cancel(id, actor)
+	if errors.Is(err, repository.ErrStocktakeClosed) {
+		return nil, conflict(err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return stocktake, nil
+}
+
+// Variance mengambil laporan selisih hitungan sesi stocktake.
+func (s *stocktakeService) Variance(id int64) (*model.StocktakeVariance, error) {
+	return s.repo.Variance(id)
+}
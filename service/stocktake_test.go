@@ -0,0 +1,160 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"go-boot-category-api/framework/repository"
+	"go-boot-category-api/framework/validator"
+	"go-boot-category-api/model"
+	"testing"
+)
+
+type fakeStocktakeRepo struct {
+	repository.Stocktake
+	stocktake  *model.Stocktake
+	approveErr error
+	counts     []model.StocktakeCount
+	created    *model.Stocktake
+}
+
+func (f *fakeStocktakeRepo) Create(stocktake *model.Stocktake) error {
+	f.created = stocktake
+	return nil
+}
+
+func (f *fakeStocktakeRepo) GetByID(id int64) (*model.Stocktake, error) {
+	return f.stocktake, nil
+}
+
+func (f *fakeStocktakeRepo) Count(id int64, counts []model.StocktakeCount, actor string) (*model.Stocktake, error) {
+	f.counts = counts
+	return f.stocktake, nil
+}
+
+func (f *fakeStocktakeRepo) Approve(id int64, actor string) (*model.Stocktake, error) {
+	return f.stocktake, f.approveErr
+}
+
+func TestStocktakeCreate(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         model.StocktakeInput
+		wantField     string
+		wantWarehouse bool
+		wantCategory  bool
+	}{
+		{name: "warehouse", input: model.StocktakeInput{WarehouseID: 1}, wantWarehouse: true},
+		{name: "category", input: model.StocktakeInput{CategoryID: 2}, wantCategory: true},
+		{name: "warehouse and category", input: model.StocktakeInput{WarehouseID: 1, CategoryID: 2}, wantWarehouse: true, wantCategory: true},
+		{name: "no scope", input: model.StocktakeInput{}, wantField: "warehouse_id"},
+		{name: "unknown warehouse", input: model.StocktakeInput{WarehouseID: 9}, wantField: "warehouse_id"},
+		{name: "unknown category", input: model.StocktakeInput{CategoryID: 9}, wantField: "category_id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeStocktakeRepo{}
+			categories := &fakeCategories{categories: map[int]*model.Category{2: {ID: 2}}}
+			warehouses := &fakeWarehouses{warehouses: map[int]*model.Warehouse{1: {ID: 1}}}
+			s := NewStocktakeService(repo, categories, warehouses)
+
+			stocktake, err := s.Create(tt.input, "test")
+			if tt.wantField != "" {
+				var errs validator.Errors
+				if !errors.As(err, &errs) || errs[0].Field != tt.wantField {
+					t.Fatalf("Create() error = %v, want validation error on %s", err, tt.wantField)
+				}
+				if repo.created != nil {
+					t.Error("Create() reached the repository with invalid input")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			if (stocktake.WarehouseID != nil) != tt.wantWarehouse || (stocktake.CategoryID != nil) != tt.wantCategory {
+				t.Errorf("Create() scope warehouse = %v, category = %v", stocktake.WarehouseID, stocktake.CategoryID)
+			}
+			if repo.created != stocktake || stocktake.Actor != "test" {
+				t.Errorf("Create() passed %+v to the repository", repo.created)
+			}
+		})
+	}
+}
+
+func TestStocktakeCount(t *testing.T) {
+	warehouseID, productID := 1, 5
+	lines := []model.StocktakeLine{{ProductID: &productID, WarehouseID: 1}, {ProductID: &productID, WarehouseID: 2}}
+	counted := func(n int) *int { return &n }
+
+	tests := []struct {
+		name          string
+		sessionWH     *int
+		status        string
+		counts        []model.StocktakeCount
+		wantWarehouse int
+		wantErr       error
+		wantField     string
+	}{
+		{name: "session warehouse is the default", sessionWH: &warehouseID, counts: []model.StocktakeCount{{ProductID: 5, Counted: counted(3)}}, wantWarehouse: 1},
+		{name: "explicit warehouse", counts: []model.StocktakeCount{{ProductID: 5, WarehouseID: 2, Counted: counted(0)}}, wantWarehouse: 2},
+		{name: "warehouse required for all-warehouse session", counts: []model.StocktakeCount{{ProductID: 5, Counted: counted(3)}}, wantField: "counts[0].warehouse_id"},
+		{name: "product outside the session", sessionWH: &warehouseID, counts: []model.StocktakeCount{{ProductID: 6, Counted: counted(3)}}, wantField: "counts[0].product_id"},
+		{name: "missing count", sessionWH: &warehouseID, counts: []model.StocktakeCount{{ProductID: 5}}, wantField: "counts[0].counted"},
+		{name: "negative count", sessionWH: &warehouseID, counts: []model.StocktakeCount{{ProductID: 5, Counted: counted(-1)}}, wantField: "counts[0].counted"},
+		{name: "closed session", status: model.StocktakeApproved, counts: []model.StocktakeCount{{ProductID: 5, WarehouseID: 1, Counted: counted(3)}}, wantErr: ErrConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := tt.status
+			if status == "" {
+				status = model.StocktakeOpen
+			}
+			repo := &fakeStocktakeRepo{stocktake: &model.Stocktake{ID: 1, WarehouseID: tt.sessionWH, Status: status, Lines: lines}}
+			s := NewStocktakeService(repo, nil, nil)
+
+			_, err := s.Count(1, model.StocktakeCountInput{Counts: tt.counts}, "counter")
+			switch {
+			case tt.wantField != "":
+				var errs validator.Errors
+				if !errors.As(err, &errs) || errs[0].Field != tt.wantField {
+					t.Fatalf("Count() error = %v, want validation error on %s", err, tt.wantField)
+				}
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Count() error = %v, want %v", err, tt.wantErr)
+				}
+			case err != nil:
+				t.Fatalf("Count() error = %v", err)
+			case repo.counts[0].WarehouseID != tt.wantWarehouse:
+				t.Errorf("counted warehouse = %d, want %d", repo.counts[0].WarehouseID, tt.wantWarehouse)
+			}
+			if (err != nil) != (repo.counts == nil) {
+				t.Errorf("repository called = %v with error %v", repo.counts != nil, err)
+			}
+		})
+	}
+}
+
+func TestStocktakeApprove(t *testing.T) {
+	tests := []struct {
+		name       string
+		approveErr error
+		wantErr    error
+	}{
+		{name: "approved"},
+		{name: "closed", approveErr: fmt.Errorf("stocktake 1: %w", repository.ErrStocktakeClosed), wantErr: ErrConflict},
+		{name: "stock went negative", approveErr: fmt.Errorf("produk 5: %w", repository.ErrInsufficientStock), wantErr: ErrInsufficientStock},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeStocktakeRepo{stocktake: &model.Stocktake{ID: 1, Status: model.StocktakeApproved}, approveErr: tt.approveErr}
+			_, err := NewStocktakeService(repo, nil, nil).Approve(1, "manager")
+			if tt.wantErr == nil && err != nil || tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Approve() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"go-boot-category-api/framework/repository"
+	"go-boot-category-api/framework/validator"
+	"go-boot-category-api/model"
+	"strings"
+)
+
+type Supplier interface {
+	GetAll() ([]model.Supplier, error)
+	GetByID(id int) (*model.Supplier, error)
+	Create(input model.SupplierInput) (*model.Supplier, error)
+}
+
+type supplierService struct {
+	repo repository.Supplier
+}
+
+func NewSupplierService(repo repository.Supplier) Supplier {
+	return &supplierService{repo: repo}
+}
+
+func (s *supplierService) GetAll() ([]model.Supplier, error) {
+	return s.repo.GetAll()
+}
+
+func (s *supplierService) GetByID(id int) (*model.Supplier, error) {
+	return s.repo.GetByID(id)
+}
+
+func (s *supplierService) Create(input model.SupplierInput) (*model.Supplier, error) {
+	if err := validator.Struct(&input); err != nil {
+		return nil, err
+	}
+
+	supplier := &model.Supplier{Name: strings.TrimSpace(input.Name), Email: strings.TrimSpace(input.Email), Phone: strings.TrimSpace(input.Phone)}
+	if err := s.repo.Create(supplier); err != nil {
+		return nil, conflictIfDuplicate(err)
+	}
+	return supplier, nil
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"fmt"
+	"go-boot-category-api/framework/repository"
+	"go-boot-category-api/framework/slug"
+	"go-boot-category-api/framework/validator"
+	"go-boot-category-api/model"
+	"slices"
+)
+
+// maxTagLength sama dengan panjang kolom tags.name
+const maxTagLength = 50
+
+type Tag interface {
+	GetAll() ([]model.Tag, error)
+	Create(input model.TagInput) (*model.Tag, error)
+	Delete(id int) error
+	AddToProduct(productID int, input model.ProductTagsInput) (*model.Product, error)
+	RemoveFromProduct(productID int, input model.ProductTagsInput) (*model.Product, error)
+}
+
+type tagService struct {
+	repo     repository.Tag
+	products repository.Product
+}
+
+func NewTagService(repo repository.Tag, products repository.Product) Tag {
+	return &tagService{repo: repo, products: products}
+}
+
+func (s *tagService) GetAll() ([]model.Tag, error) {
+	return s.repo.GetAll()
+}
+
+func (s *tagService) Create(input model.TagInput) (*model.Tag, error) {
+	if err := validator.Struct(&input); err != nil {
+		return nil, err
+	}
+	name, fe := tagName("name", input.Name)
+	if fe != nil {
+		return nil, validator.Errors{*fe}
+	}
+
+	tag := &model.Tag{Name: name}
+	if err := s.repo.Create(tag); err != nil {
+		return nil, conflictIfDuplicate(err)
+	}
+	return tag, nil
+}
+
+func (s *tagService) Delete(id int) error {
+	return s.repo.Delete(id)
+}
+
+// AddToProduct memasang tag ke produk dan mengembalikan produk terbaru.
+func (s *tagService) AddToProduct(productID int, input model.ProductTagsInput) (*model.Product, error) {
+	names, err := tagNames(input)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.products.AddTags(productID, names); err != nil {
+		return nil, err
+	}
+	return s.products.GetByID(productID)
+}
+
+// RemoveFromProduct melepas tag dari produk dan mengembalikan produk terbaru.
+func (s *tagService) RemoveFromProduct(productID int, input model.ProductTagsInput) (*model.Product, error) {
+	names, err := tagNames(input)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.products.RemoveTags(productID, names); err != nil {
+		return nil, err
+	}
+	return s.products.GetByID(productID)
+}
+
+// tagName menormalisasi nama tag ke bentuk slug, misal "New Arrival"
+// menjadi "new-arrival". field dipakai untuk pesan error validasi.
+func tagName(field, raw string) (string, *validator.FieldError) {
+	name := slug.Make(raw)
+	if name == "" {
+		return "", &validator.FieldError{Field: field, Rule: "format", Message: field + " must contain at least one letter or digit"}
+	}
+	if len(name) > maxTagLength {
+		return "", &validator.FieldError{Field: field, Rule: "max", Message: fmt.Sprintf("%s must not exceed %d characters", field, maxTagLength)}
+	}
+	return name, nil
+}
+
+// tagNames memvalidasi input dan mengembalikan nama tag yang sudah
+// dinormalisasi tanpa duplikat.
+func tagNames(input model.ProductTagsInput) ([]string, error) {
+	if err := validator.Struct(&input); err != nil {
+		return nil, err
+	}
+
+	var errs validator.Errors
+	names := make([]string, 0, len(input.Tags))
+	for i, raw := range input.Tags {
+		name, fe := tagName(fmt.Sprintf("tags.%d", i), raw)
+		if fe != nil {
+			errs = append(errs, *fe)
+			continue
+		}
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return names, nil
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"go-boot-category-api/framework/repository"
+	"go-boot-category-api/framework/validator"
+	"go-boot-category-api/model"
+)
+
+// ErrInsufficientStock dikembalikan ketika stok tidak cukup untuk dikurangi.
+var ErrInsufficientStock = repository.ErrInsufficientStock
+
+type Variant interface {
+	GetByProduct(productID int) ([]model.Variant, error)
+	GetByID(productID, id int) (*model.Variant, error)
+	Create(productID int, input model.VariantInput) (*model.Variant, error)
+	Update(productID, id int, input model.VariantInput) (*model.Variant, error)
+	Delete(productID, id int) error
+}
+
+type variantService struct {
+	repo     repository.Variant
+	products repository.Product
+}
+
+func NewVariantService(repo repository.Variant, products repository.Product) Variant {
+	return &variantService{repo: repo, products: products}
+}
+
+func (s *variantService) GetByProduct(productID int) ([]model.Variant, error) {
+	if _, err := s.products.GetByID(productID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByProduct(productID)
+}
+
+func (s *variantService) GetByID(productID, id int) (*model.Variant, error) {
+	return s.repo.GetByID(productID, id)
+}
+
+func (s *variantService) Create(productID int, input model.VariantInput) (*model.Variant, error) {
+	if err := s.validate(productID, 0, input); err != nil {
+		return nil, err
+	}
+
+	variant := input.Variant(productID)
+	if err := s.repo.Create(variant); err != nil {
+		return nil, conflictIfDuplicate(err)
+	}
+	return variant, nil
+}
+
+func (s *variantService) Update(productID, id int, input model.VariantInput) (*model.Variant, error) {
+	if err := s.validate(productID, id, input); err != nil {
+		return nil, err
+	}
+
+	variant := input.Variant(productID)
+	variant.ID = id
+	if err := s.repo.Update(variant); err != nil {
+		return nil, conflictIfDuplicate(err)
+	}
+	return variant, nil
+}
+
+func (s *variantService) Delete(productID, id int) error {
+	return s.repo.Delete(productID, id)
+}
+
+// validate mengecek field varian, SKU yang unik, dan mata uang harga
+// override yang harus sama dengan mata uang produk.
+func (s *variantService) validate(productID, excludeID int, input model.VariantInput) error {
+	if err := validator.Struct(&input); err != nil {
+		return err
+	}
+
+	product, err := s.products.GetByID(productID)
+	if err != nil {
+		return err
+	}
+	if input.Price != nil && input.Price.Currency != product.Price.Currency {
+		return fieldError("price.currency", "currency", fmt.Sprintf("price.currency must be %s, same as the product", product.Price.Currency))
+	}
+
+	exists, err := s.repo.ExistsBySKU(input.SKU, excludeID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return conflict(fmt.Sprintf("SKU %q sudah dipakai", input.SKU))
+	}
+	return nil
+}
+
+// conflictIfDuplicate mengubah pelanggaran unique constraint dari database
+// (misal kombinasi opsi yang sama) menjadi ErrConflict.
+func conflictIfDuplicate(err error) error {
+	if errors.Is(err, repository.ErrDuplicate) {
+		return conflict(err.Error())
+	}
+	return err
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"go-boot-category-api/framework/repository"
+	"go-boot-category-api/framework/validator"
+	"go-boot-category-api/model"
+	"strings"
+)
+
+type Warehouse interface {
+	GetAll() ([]model.Warehouse, error)
+	GetByID(id int) (*model.Warehouse, error)
+	Create(input model.WarehouseInput) (*model.Warehouse, error)
+}
+
+type warehouseService struct {
+	repo repository.Warehouse
+}
+
+func NewWarehouseService(repo repository.Warehouse) Warehouse {
+	return &warehouseService{repo: repo}
+}
+
+func (s *warehouseService) GetAll() ([]model.Warehouse, error) {
+	return s.repo.GetAll()
+}
+
+func (s *warehouseService) GetByID(id int) (*model.Warehouse, error) {
+	return s.repo.GetByID(id)
+}
+
+// Create menyimpan gudang baru. Kode gudang disimpan dalam huruf besar.
+func (s *warehouseService) Create(input model.WarehouseInput) (*model.Warehouse, error) {
+	if err := validator.Struct(&input); err != nil {
+		return nil, err
+	}
+
+	warehouse := &model.Warehouse{Code: strings.ToUpper(strings.TrimSpace(input.Code)), Name: input.Name}
+	if err := s.repo.Create(warehouse); err != nil {
+		return nil, conflictIfDuplicate(err)
+	}
+	return warehouse, nil
+}